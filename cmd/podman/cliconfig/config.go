@@ -1,6 +1,8 @@
 package cliconfig
 
 import (
+	"net"
+
 	"github.com/spf13/cobra"
 )
 
@@ -155,8 +157,11 @@ type GenerateKubeValues struct {
 
 type GenerateSystemdValues struct {
 	PodmanCommand
+	Files         bool
 	Name          bool
+	New           bool
 	RestartPolicy string
+	Sockets       []string
 	StopTimeout   int
 }
 
@@ -258,6 +263,37 @@ type MountValues struct {
 	Latest  bool
 }
 
+type NetworkConnectValues struct {
+	PodmanCommand
+}
+
+type NetworkCreateValues struct {
+	PodmanCommand
+	DisableDNS bool
+	Driver     string
+	Gateway    net.IP
+	IPRange    net.IPNet
+	Network    net.IPNet
+	Opt        []string
+}
+
+type NetworkDisconnectValues struct {
+	PodmanCommand
+}
+
+type NetworkInspectValues struct {
+	PodmanCommand
+}
+
+type NetworkListValues struct {
+	PodmanCommand
+	Quiet bool
+}
+
+type NetworkRmValues struct {
+	PodmanCommand
+}
+
 type PauseValues struct {
 	PodmanCommand
 	All bool
@@ -272,6 +308,7 @@ type KubePlayValues struct {
 	Authfile        string
 	CertDir         string
 	Creds           string
+	Down            bool
 	Quiet           bool
 	SignaturePolicy string
 	TlsVerify       bool
@@ -279,13 +316,20 @@ type KubePlayValues struct {
 
 type PodCreateValues struct {
 	PodmanCommand
+	AddHost      []string
 	CgroupParent string
+	DNS          []string
+	DNSSearch    []string
+	Hostname     string
 	Infra        bool
 	InfraImage   string
 	InfraCommand string
+	IP           string
 	LabelFile    []string
 	Labels       []string
 	Name         string
+	Network      string
+	NetworkAlias []string
 	PodIDFile    string
 	Publish      []string
 	Share        string
@@ -496,8 +540,10 @@ type SignValues struct {
 
 type StartValues struct {
 	PodmanCommand
+	All         bool
 	Attach      bool
 	DetachKeys  string
+	Filter      []string
 	Interactive bool
 	Latest      bool
 	SigProxy    bool
@@ -568,6 +614,16 @@ type VolumeCreateValues struct {
 	Label  []string
 	Opt    []string
 }
+type VolumeExportValues struct {
+	PodmanCommand
+	Output string
+	Pause  bool
+}
+
+type VolumeImportValues struct {
+	PodmanCommand
+}
+
 type VolumeInspectValues struct {
 	PodmanCommand
 	All    bool
@@ -15,6 +15,7 @@ func getMainCommands() []*cobra.Command {
 		_loginCommand,
 		_logoutCommand,
 		_mountCommand,
+		_networkCommand,
 		_refreshCommand,
 		_searchCommand,
 		_statsCommand,
@@ -296,6 +296,10 @@ func getCreateFlags(c *cliconfig.PodmanCommand) {
 		"ip", "",
 		"Specify a static IPv4 address for the container",
 	)
+	createFlags.String(
+		"ip6", "",
+		"Specify a static IPv6 address for the container",
+	)
 	createFlags.String(
 		"ipc", "",
 		"IPC namespace to use",
@@ -352,6 +356,26 @@ func getCreateFlags(c *cliconfig.PodmanCommand) {
 		"network", getDefaultNetwork(),
 		"Connect a container to a network",
 	)
+	createFlags.StringSlice(
+		"network-alias", []string{},
+		"Add network-scoped alias for the container",
+	)
+	createFlags.String(
+		"network-egress-burst", "",
+		"Bits the container may send above the egress rate, e.g. 1mbit (default a tenth of a second of traffic)",
+	)
+	createFlags.String(
+		"network-egress-rate", "",
+		"Limit the traffic out of the container to a rate in bits per second, e.g. 10mbit",
+	)
+	createFlags.String(
+		"network-ingress-burst", "",
+		"Bits the container may receive above the ingress rate, e.g. 1mbit (default a tenth of a second of traffic)",
+	)
+	createFlags.String(
+		"network-ingress-rate", "",
+		"Limit the traffic into the container to a rate in bits per second, e.g. 10mbit",
+	)
 	createFlags.Bool(
 		"no-hosts", false,
 		"Do not create /etc/hosts within the container, instead use the version from the image",
@@ -404,6 +428,14 @@ func getCreateFlags(c *cliconfig.PodmanCommand) {
 		"restart", "",
 		"Restart policy to apply when a container exits",
 	)
+	createFlags.String(
+		"restart-backoff", "",
+		"Delay before the first restart by the restart policy, doubled after each restart (default 100ms)",
+	)
+	createFlags.String(
+		"restart-backoff-max", "",
+		"Maximum delay between two restarts by the restart policy (default 1m0s)",
+	)
 	createFlags.Bool(
 		"rm", false,
 		"Remove container (and pod if created) after exit",
@@ -412,6 +444,10 @@ func getCreateFlags(c *cliconfig.PodmanCommand) {
 		"rootfs", false,
 		"The first argument is not an image but the rootfs to the exploded container",
 	)
+	createFlags.String(
+		"sdnotify", define.SdNotifyModeConmon,
+		"control sd-notify behavior (`conmon|container|ignore`)",
+	)
 	createFlags.StringArray(
 		"security-opt", []string{},
 		"Security Options (default [])",
@@ -457,8 +457,11 @@ func pathWithVolumeMount(ctr *libpod.Container, runtime *libpod.Runtime, volDest
 	if !filepath.IsAbs(path) {
 		path = filepath.Join(string(os.PathSeparator), path)
 	}
-	path, err = securejoin.SecureJoin(destVolume.MountPoint(), strings.TrimPrefix(path, volDestName))
-	return path, err
+	mountPoint, err := destVolume.ResolveMountPoint()
+	if err != nil {
+		return "", err
+	}
+	return securejoin.SecureJoin(mountPoint, strings.TrimPrefix(path, volDestName))
 }
 
 func isBindMountDestName(path string, ctr *libpod.Container) (bool, specs.Mount) {
@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/containers/libpod/cmd/podman/cliconfig"
 	"github.com/containers/libpod/pkg/adapter"
@@ -12,11 +15,13 @@ import (
 
 var (
 	containerSystemdCommand     cliconfig.GenerateSystemdValues
-	containerSystemdDescription = `Command generates a systemd unit file for a Podman container
-  `
+	containerSystemdDescription = `Command generates a systemd unit file for a Podman container or pod
+
+  Generating units for a pod creates a unit for the pod, which controls its infra container, and one unit for each of its containers.
+`
 	_containerSystemdCommand = &cobra.Command{
 		Use:   "systemd [flags] CONTAINER | POD",
-		Short: "Generate a systemd unit file for a Podman container",
+		Short: "Generate systemd unit files for a Podman container or pod",
 		Long:  containerSystemdDescription,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			containerSystemdCommand.InputArgs = args
@@ -26,11 +31,14 @@ var (
 		},
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 1 || len(args) < 1 {
-				return errors.New("provide only one container name or ID")
+				return errors.New("provide only one container or pod name or ID")
 			}
 			return nil
 		},
 		Example: `podman generate systemd ctrID
+  podman generate systemd --name --files podName
+  podman generate systemd --new --name ctrName
+  podman generate systemd --socket 8080 ctrID
 `,
 	}
 )
@@ -40,7 +48,10 @@ func init() {
 	containerSystemdCommand.SetHelpTemplate(HelpTemplate())
 	containerSystemdCommand.SetUsageTemplate(UsageTemplate())
 	flags := containerSystemdCommand.Flags()
+	flags.BoolVarP(&containerSystemdCommand.Files, "files", "f", false, "write the units to files in the current working directory")
 	flags.BoolVarP(&containerSystemdCommand.Name, "name", "n", false, "use the container name instead of ID")
+	flags.BoolVar(&containerSystemdCommand.New, "new", false, "create a new container instead of starting an existing one")
+	flags.StringArrayVar(&containerSystemdCommand.Sockets, "socket", []string{}, "generate a socket unit activating the container, listening on `[stream:|datagram:]ADDRESS`")
 	flags.IntVarP(&containerSystemdCommand.StopTimeout, "timeout", "t", -1, "stop timeout override")
 	flags.StringVar(&containerSystemdCommand.RestartPolicy, "restart-policy", "on-failure", "applicable systemd restart-policy")
 }
@@ -61,10 +72,38 @@ func generateSystemdCmd(c *cliconfig.GenerateSystemdValues) error {
 		return err
 	}
 
-	unit, err := runtime.GenerateSystemd(c)
+	units, err := runtime.GenerateSystemd(c)
 	if err != nil {
 		return err
 	}
-	fmt.Println(unit)
+
+	if c.Files {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return errors.Wrapf(err, "error getting current working directory")
+		}
+		for _, unit := range units {
+			path := filepath.Join(cwd, unit.FileName())
+			if err := ioutil.WriteFile(path, []byte(unit.Content+"\n"), 0644); err != nil {
+				return errors.Wrapf(err, "error writing systemd unit %s", path)
+			}
+			fmt.Println(path)
+		}
+		return nil
+	}
+
+	// A single container unit is printed as is, multiple units are
+	// separated by a comment naming their files
+	if len(units) == 1 {
+		fmt.Println(units[0].Content)
+		return nil
+	}
+	for i, unit := range units {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("# %s\n", unit.FileName())
+		fmt.Println(unit.Content)
+	}
 	return nil
 }
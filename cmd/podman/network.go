@@ -0,0 +1,35 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkCommand     cliconfig.PodmanCommand
+	networkDescription = `Manage the CNI networks containers can be attached to.`
+	_networkCommand    = &cobra.Command{
+		Use:   "network",
+		Short: "Manage networks",
+		Long:  networkDescription,
+		RunE:  commandRunE(),
+	}
+)
+
+// Commands that are universally implemented
+var networkCommands = []*cobra.Command{
+	_networkConnectCommand,
+	_networkCreateCommand,
+	_networkDisconnectCommand,
+	_networkInspectCommand,
+	_networkListCommand,
+	_networkRmCommand,
+}
+
+func init() {
+	networkCommand.Command = _networkCommand
+	networkCommand.SetUsageTemplate(UsageTemplate())
+	networkCommand.AddCommand(networkCommands...)
+}
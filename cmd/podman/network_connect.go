@@ -0,0 +1,55 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkConnectCommand     cliconfig.NetworkConnectValues
+	networkConnectDescription = `Connect a container to a CNI network.
+
+  A running container is attached to the network immediately, a stopped
+  container when it is next started.`
+	_networkConnectCommand = &cobra.Command{
+		Use:   "connect NETWORK CONTAINER",
+		Short: "Connect a container to a network",
+		Long:  networkConnectDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkConnectCommand.InputArgs = args
+			networkConnectCommand.GlobalFlags = MainGlobalOpts
+			networkConnectCommand.Remote = remoteclient
+			return networkConnectCmd(&networkConnectCommand)
+		},
+		Example: `podman network connect mynet ctrID`,
+	}
+)
+
+func init() {
+	networkConnectCommand.Command = _networkConnectCommand
+	networkConnectCommand.SetHelpTemplate(HelpTemplate())
+	networkConnectCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func networkConnectCmd(c *cliconfig.NetworkConnectValues) error {
+	if len(c.InputArgs) != 2 {
+		return errors.Errorf("network connect requires a network and a container")
+	}
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	if err := runtime.NetworkConnect(c); err != nil {
+		return err
+	}
+	fmt.Println(c.InputArgs[1])
+	return nil
+}
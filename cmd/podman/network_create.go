@@ -0,0 +1,63 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/containers/libpod/pkg/network"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkCreateCommand     cliconfig.NetworkCreateValues
+	networkCreateDescription = `Create a CNI network configuration for use with containers.
+
+  If no subnet is given, a free subnet is picked for bridge networks.`
+	_networkCreateCommand = &cobra.Command{
+		Use:   "create [flags] [NETWORK]",
+		Short: "Create a network",
+		Long:  networkCreateDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkCreateCommand.InputArgs = args
+			networkCreateCommand.GlobalFlags = MainGlobalOpts
+			networkCreateCommand.Remote = remoteclient
+			return networkCreateCmd(&networkCreateCommand)
+		},
+		Example: `podman network create mynet
+  podman network create --subnet 192.168.55.0/24 --gateway 192.168.55.3 --ip-range 192.168.55.128/25 mynet
+  podman network create --driver macvlan --opt parent=eth0 mynet`,
+	}
+)
+
+func init() {
+	networkCreateCommand.Command = _networkCreateCommand
+	networkCreateCommand.SetHelpTemplate(HelpTemplate())
+	networkCreateCommand.SetUsageTemplate(UsageTemplate())
+	flags := networkCreateCommand.Flags()
+	flags.BoolVar(&networkCreateCommand.DisableDNS, "disable-dns", false, "Disable DNS name resolution of containers on the network")
+	flags.StringVarP(&networkCreateCommand.Driver, "driver", "d", network.BridgeDriver, "Driver to manage the network (bridge or macvlan)")
+	flags.IPVar(&networkCreateCommand.Gateway, "gateway", nil, "IPv4 or IPv6 gateway for the subnet")
+	flags.IPNetVar(&networkCreateCommand.IPRange, "ip-range", net.IPNet{}, "Allocate container IP from range")
+	flags.StringArrayVarP(&networkCreateCommand.Opt, "opt", "o", []string{}, "Set driver specific options (mtu, parent)")
+	flags.IPNetVar(&networkCreateCommand.Network, "subnet", net.IPNet{}, "Subnet in CIDR format")
+}
+
+func networkCreateCmd(c *cliconfig.NetworkCreateValues) error {
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	n, err := runtime.NetworkCreate(c)
+	if err != nil {
+		return err
+	}
+	fmt.Println(n.Path)
+	return nil
+}
@@ -0,0 +1,54 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkDisconnectCommand     cliconfig.NetworkDisconnectValues
+	networkDisconnectDescription = `Disconnect a container from a CNI network.
+
+  A running container is detached from the network immediately.`
+	_networkDisconnectCommand = &cobra.Command{
+		Use:   "disconnect NETWORK CONTAINER",
+		Short: "Disconnect a container from a network",
+		Long:  networkDisconnectDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkDisconnectCommand.InputArgs = args
+			networkDisconnectCommand.GlobalFlags = MainGlobalOpts
+			networkDisconnectCommand.Remote = remoteclient
+			return networkDisconnectCmd(&networkDisconnectCommand)
+		},
+		Example: `podman network disconnect mynet ctrID`,
+	}
+)
+
+func init() {
+	networkDisconnectCommand.Command = _networkDisconnectCommand
+	networkDisconnectCommand.SetHelpTemplate(HelpTemplate())
+	networkDisconnectCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func networkDisconnectCmd(c *cliconfig.NetworkDisconnectValues) error {
+	if len(c.InputArgs) != 2 {
+		return errors.Errorf("network disconnect requires a network and a container")
+	}
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	if err := runtime.NetworkDisconnect(c); err != nil {
+		return err
+	}
+	fmt.Println(c.InputArgs[1])
+	return nil
+}
@@ -0,0 +1,57 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkInspectCommand     cliconfig.NetworkInspectValues
+	networkInspectDescription = `Display the CNI configuration of one or more networks.`
+	_networkInspectCommand    = &cobra.Command{
+		Use:   "inspect NETWORK [NETWORK...]",
+		Short: "Display detailed information on one or more networks",
+		Long:  networkInspectDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkInspectCommand.InputArgs = args
+			networkInspectCommand.GlobalFlags = MainGlobalOpts
+			networkInspectCommand.Remote = remoteclient
+			return networkInspectCmd(&networkInspectCommand)
+		},
+		Example: `podman network inspect podman`,
+	}
+)
+
+func init() {
+	networkInspectCommand.Command = _networkInspectCommand
+	networkInspectCommand.SetHelpTemplate(HelpTemplate())
+	networkInspectCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func networkInspectCmd(c *cliconfig.NetworkInspectValues) error {
+	if len(c.InputArgs) < 1 {
+		return errors.Errorf("at least one network name is required")
+	}
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	rawConfigs, err := runtime.NetworkInspect(c)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(rawConfigs, "", "     ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
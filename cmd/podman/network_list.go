@@ -0,0 +1,69 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkListCommand     cliconfig.NetworkListValues
+	networkListDescription = `List the CNI networks containers can be attached to.`
+	_networkListCommand    = &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Args:    noSubArgs,
+		Short:   "List networks",
+		Long:    networkListDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkListCommand.InputArgs = args
+			networkListCommand.GlobalFlags = MainGlobalOpts
+			networkListCommand.Remote = remoteclient
+			return networkListCmd(&networkListCommand)
+		},
+		Example: `podman network ls`,
+	}
+)
+
+func init() {
+	networkListCommand.Command = _networkListCommand
+	networkListCommand.SetHelpTemplate(HelpTemplate())
+	networkListCommand.SetUsageTemplate(UsageTemplate())
+	flags := networkListCommand.Flags()
+	flags.BoolVarP(&networkListCommand.Quiet, "quiet", "q", false, "Display only network names")
+}
+
+func networkListCmd(c *cliconfig.NetworkListValues) error {
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	networks, err := runtime.NetworkList(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Quiet {
+		for _, n := range networks {
+			fmt.Println(n.Name)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tPLUGINS")
+	for _, n := range networks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", n.Name, n.List.CNIVersion, strings.Join(n.Plugins(), ","))
+	}
+	return w.Flush()
+}
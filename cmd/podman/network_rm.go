@@ -0,0 +1,53 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkRmCommand     cliconfig.NetworkRmValues
+	networkRmDescription = `Remove one or more CNI networks.
+
+  Networks used by containers and the default network cannot be removed.`
+	_networkRmCommand = &cobra.Command{
+		Use:     "rm NETWORK [NETWORK...]",
+		Aliases: []string{"remove"},
+		Short:   "Remove one or more networks",
+		Long:    networkRmDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkRmCommand.InputArgs = args
+			networkRmCommand.GlobalFlags = MainGlobalOpts
+			networkRmCommand.Remote = remoteclient
+			return networkRmCmd(&networkRmCommand)
+		},
+		Example: `podman network rm mynet`,
+	}
+)
+
+func init() {
+	networkRmCommand.Command = _networkRmCommand
+	networkRmCommand.SetHelpTemplate(HelpTemplate())
+	networkRmCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func networkRmCmd(c *cliconfig.NetworkRmValues) error {
+	if len(c.InputArgs) < 1 {
+		return errors.Errorf("at least one network name is required")
+	}
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	ok, failures, err := runtime.NetworkRemove(c)
+	if err != nil {
+		return err
+	}
+	return printCmdResults(ok, failures)
+}
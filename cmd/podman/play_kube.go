@@ -24,7 +24,8 @@ var (
 			playKubeCommand.Remote = remoteclient
 			return playKubeCmd(&playKubeCommand)
 		},
-		Example: `podman play kube demo.yml`,
+		Example: `podman play kube demo.yml
+  podman play kube --down demo.yml`,
 	}
 )
 
@@ -37,6 +38,7 @@ func init() {
 	playKubeCommand.SetUsageTemplate(UsageTemplate())
 	flags := playKubeCommand.Flags()
 	flags.StringVar(&playKubeCommand.Creds, "creds", "", "`Credentials` (USERNAME:PASSWORD) to use for authenticating to a registry")
+	flags.BoolVar(&playKubeCommand.Down, "down", false, "Stop and remove the pods and volumes created by a previous play of the file")
 	flags.BoolVarP(&playKubeCommand.Quiet, "quiet", "q", false, "Suppress output information when pulling images")
 	// Disabled flags for the remote client
 	if !remote {
@@ -64,6 +66,14 @@ func playKubeCmd(c *cliconfig.KubePlayValues) error {
 	}
 	defer runtime.DeferredShutdown(false)
 
+	if c.Down {
+		podIDs, err := runtime.PlayKubeDown(ctx, c, args[0])
+		for _, id := range podIDs {
+			fmt.Println(id)
+		}
+		return err
+	}
+
 	_, err = runtime.PlayKubeYAML(ctx, c, args[0])
 	return err
 }
@@ -45,13 +45,20 @@ func init() {
 	flags := podCreateCommand.Flags()
 	flags.SetInterspersed(false)
 
+	flags.StringSliceVar(&podCreateCommand.AddHost, "add-host", []string{}, "Add a custom host-to-IP mapping (host:ip) to the pod (default [])")
 	flags.StringVar(&podCreateCommand.CgroupParent, "cgroup-parent", "", "Set parent cgroup for the pod")
+	flags.StringSliceVar(&podCreateCommand.DNS, "dns", []string{}, "Set custom DNS servers of the pod")
+	flags.StringSliceVar(&podCreateCommand.DNSSearch, "dns-search", []string{}, "Set custom DNS search domains of the pod")
+	flags.StringVar(&podCreateCommand.Hostname, "hostname", "", "Set the hostname of the pod")
 	flags.BoolVar(&podCreateCommand.Infra, "infra", true, "Create an infra container associated with the pod to share namespaces with")
 	flags.StringVar(&podCreateCommand.InfraImage, "infra-image", define.DefaultInfraImage, "The image of the infra container to associate with the pod")
 	flags.StringVar(&podCreateCommand.InfraCommand, "infra-command", define.DefaultInfraCommand, "The command to run on the infra container when the pod is started")
+	flags.StringVar(&podCreateCommand.IP, "ip", "", "Specify a static IPv4 address for the pod")
 	flags.StringSliceVar(&podCreateCommand.LabelFile, "label-file", []string{}, "Read in a line delimited file of labels")
 	flags.StringSliceVarP(&podCreateCommand.Labels, "label", "l", []string{}, "Set metadata on pod (default [])")
 	flags.StringVarP(&podCreateCommand.Name, "name", "n", "", "Assign a name to the pod")
+	flags.StringVar(&podCreateCommand.Network, "network", "bridge", "Connect the pod to a network")
+	flags.StringSliceVar(&podCreateCommand.NetworkAlias, "network-alias", []string{}, "Add network-scoped alias for the pod")
 	flags.StringVar(&podCreateCommand.PodIDFile, "pod-id-file", "", "Write the pod ID to the file")
 	flags.StringSliceVarP(&podCreateCommand.Publish, "publish", "p", []string{}, "Publish a container's port, or a range of ports, to the host (default [])")
 	flags.StringVar(&podCreateCommand.Share, "share", shared.DefaultKernelNamespaces, "A comma delimited list of kernel namespaces the pod will share")
@@ -75,6 +82,14 @@ func podCreateCmd(c *cliconfig.PodCreateValues) error {
 		}
 	}
 
+	if !c.Infra {
+		for _, flag := range []string{"add-host", "dns", "dns-search", "hostname", "ip", "network", "network-alias"} {
+			if c.Flag(flag).Changed {
+				return errors.Errorf("you must have an infra container to set --%s", flag)
+			}
+		}
+	}
+
 	if !c.Infra && c.Flag("share").Changed && c.Share != "none" && c.Share != "" {
 		return errors.Errorf("You cannot share kernel namespaces on the pod level without an infra container")
 	}
@@ -63,31 +63,33 @@ type BatchContainerStruct struct {
 // PsContainerOutput is the struct being returned from a parallel
 // batch operation.
 type PsContainerOutput struct {
-	ID        string
-	Image     string
-	Command   string
-	Created   string
-	Ports     string
-	Names     string
-	IsInfra   bool
-	Status    string
-	State     define.ContainerStatus
-	Pid       int
-	Size      *ContainerSize
-	Pod       string
-	CreatedAt time.Time
-	ExitedAt  time.Time
-	StartedAt time.Time
-	Labels    map[string]string
-	PID       string
-	Cgroup    string
-	IPC       string
-	MNT       string
-	NET       string
-	PIDNS     string
-	User      string
-	UTS       string
-	Mounts    string
+	ID          string
+	Image       string
+	Command     string
+	Created     string
+	Ports       string
+	Names       string
+	IsInfra     bool
+	Status      string
+	State       define.ContainerStatus
+	Pid         int
+	Size        *ContainerSize
+	Pod         string
+	CreatedAt   time.Time
+	ExitedAt    time.Time
+	StartedAt   time.Time
+	Labels      map[string]string
+	PID         string
+	Cgroup      string
+	IPC         string
+	MNT         string
+	NET         string
+	PIDNS       string
+	User        string
+	UTS         string
+	Mounts      string
+	Restarts    uint
+	LastRestart time.Time
 }
 
 // Namespace describes output for ps namespace.
@@ -113,18 +115,21 @@ type ContainerSize struct {
 // be called in PBatch.
 func NewBatchContainer(ctr *libpod.Container, opts PsOptions) (PsContainerOutput, error) {
 	var (
-		conState  define.ContainerStatus
-		command   string
-		created   string
-		status    string
-		exitedAt  time.Time
-		startedAt time.Time
-		exitCode  int32
-		err       error
-		pid       int
-		size      *ContainerSize
-		ns        *Namespace
-		pso       PsContainerOutput
+		conState    define.ContainerStatus
+		command     string
+		created     string
+		status      string
+		exitedAt    time.Time
+		startedAt   time.Time
+		exitCode    int32
+		err         error
+		pid         int
+		size        *ContainerSize
+		ns          *Namespace
+		pso         PsContainerOutput
+		restarts    uint
+		lastRestart time.Time
+		restarting  bool
 	)
 	batchErr := ctr.Batch(func(c *libpod.Container) error {
 		if opts.Sync {
@@ -152,6 +157,14 @@ func NewBatchContainer(ctr *libpod.Container, opts PsOptions) (PsContainerOutput
 		if err != nil {
 			logrus.Errorf("error getting exited time for %q: %v", c.ID(), err)
 		}
+		restarts, lastRestart, err = c.LastRestart()
+		if err != nil {
+			logrus.Errorf("error getting restart count for %q: %v", c.ID(), err)
+		}
+		restarting, err = c.Restarting()
+		if err != nil {
+			logrus.Errorf("error getting restarting status for %q: %v", c.ID(), err)
+		}
 		if opts.Namespace {
 			pid, err = c.PID()
 			if err != nil {
@@ -188,7 +201,11 @@ func NewBatchContainer(ctr *libpod.Container, opts PsOptions) (PsContainerOutput
 		fallthrough
 	case define.ContainerStateStopped.String():
 		exitedSince := units.HumanDuration(time.Since(exitedAt))
-		status = fmt.Sprintf("Exited (%d) %s ago", exitCode, exitedSince)
+		if restarting {
+			status = fmt.Sprintf("Restarting (%d) %s ago", exitCode, exitedSince)
+		} else {
+			status = fmt.Sprintf("Exited (%d) %s ago", exitCode, exitedSince)
+		}
 	case define.ContainerStateRunning.String():
 		status = "Up " + units.HumanDuration(time.Since(startedAt)) + " ago"
 	case define.ContainerStatePaused.String():
@@ -234,6 +251,8 @@ func NewBatchContainer(ctr *libpod.Container, opts PsOptions) (PsContainerOutput
 	pso.StartedAt = startedAt
 	pso.Labels = ctr.Labels()
 	pso.Mounts = strings.Join(ctr.UserVolumes(), " ")
+	pso.Restarts = restarts
+	pso.LastRestart = lastRestart
 
 	if opts.Namespace {
 		pso.Cgroup = ns.Cgroup
@@ -394,29 +413,58 @@ func generateContainerFilterFuncs(filter, filterValue string, r *libpod.Runtime)
 			}
 			return hcStatus == filterValue
 		}, nil
+	case "restart-policy":
+		if !util.StringInSlice(filterValue, []string{libpod.RestartPolicyNo, libpod.RestartPolicyAlways, libpod.RestartPolicyOnFailure, libpod.RestartPolicyUnlessStopped}) {
+			return nil, errors.Errorf("%s is not a valid restart policy", filterValue)
+		}
+		return func(c *libpod.Container) bool {
+			policy := c.RestartPolicy()
+			if policy == libpod.RestartPolicyNone {
+				policy = libpod.RestartPolicyNo
+			}
+			return policy == filterValue
+		}, nil
+	case "running-before-reboot":
+		wanted, err := strconv.ParseBool(filterValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value %q for running-before-reboot", filterValue)
+		}
+		return func(c *libpod.Container) bool {
+			running, err := c.RunningBeforeRefresh()
+			if err != nil {
+				return false
+			}
+			return running == wanted
+		}, nil
 	}
 	return nil, errors.Errorf("%s is an invalid filter", filter)
 }
 
+// GenerateContainerFilterFuncs parses a list of filters in the form of
+// filter=value and returns the matching container filter functions.
+func GenerateContainerFilterFuncs(filters []string, r *libpod.Runtime) ([]libpod.ContainerFilter, error) {
+	var filterFuncs []libpod.ContainerFilter
+	for _, f := range filters {
+		filterSplit := strings.SplitN(f, "=", 2)
+		if len(filterSplit) < 2 {
+			return nil, errors.Errorf("filter input must be in the form of filter=value: %s is invalid", f)
+		}
+		generatedFunc, err := generateContainerFilterFuncs(filterSplit[0], filterSplit[1], r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid filter")
+		}
+		filterFuncs = append(filterFuncs, generatedFunc)
+	}
+	return filterFuncs, nil
+}
+
 // GetPsContainerOutput returns a slice of containers specifically for ps output.
 func GetPsContainerOutput(r *libpod.Runtime, opts PsOptions, filters []string, maxWorkers int) ([]PsContainerOutput, error) {
-	var (
-		filterFuncs      []libpod.ContainerFilter
-		outputContainers []*libpod.Container
-	)
+	var outputContainers []*libpod.Container
 
-	if len(filters) > 0 {
-		for _, f := range filters {
-			filterSplit := strings.SplitN(f, "=", 2)
-			if len(filterSplit) < 2 {
-				return nil, errors.Errorf("filter input must be in the form of filter=value: %s is invalid", f)
-			}
-			generatedFunc, err := generateContainerFilterFuncs(filterSplit[0], filterSplit[1], r)
-			if err != nil {
-				return nil, errors.Wrapf(err, "invalid filter")
-			}
-			filterFuncs = append(filterFuncs, generatedFunc)
-		}
+	filterFuncs, err := GenerateContainerFilterFuncs(filters, r)
+	if err != nil {
+		return nil, err
 	}
 	if !opts.Latest {
 		// Get all containers.
@@ -684,6 +732,9 @@ func portsToString(ports []ocicni.PortMapping) string {
 		hostIP := v.HostIP
 		if hostIP == "" {
 			hostIP = "0.0.0.0"
+		} else if strings.Contains(hostIP, ":") {
+			// Put IPv6 addresses in brackets to separate them from the port
+			hostIP = "[" + hostIP + "]"
 		}
 		// If hostPort and containerPort are not same, consider as individual port.
 		if v.ContainerPort != v.HostPort {
@@ -0,0 +1,25 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortsToString(t *testing.T) {
+	ports := []ocicni.PortMapping{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+		{HostPort: 443, ContainerPort: 443, Protocol: "tcp", HostIP: "192.168.1.1"},
+		{HostPort: 444, ContainerPort: 444, Protocol: "tcp", HostIP: "192.168.1.1"},
+	}
+	assert.Equal(t, "0.0.0.0:8080->80/tcp, 192.168.1.1:443-444->443-444/tcp", portsToString(ports))
+}
+
+func TestPortsToStringIPv6(t *testing.T) {
+	ports := []ocicni.PortMapping{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", HostIP: "::1"},
+		{HostPort: 53, ContainerPort: 53, Protocol: "udp", HostIP: "fd00::1"},
+	}
+	assert.Equal(t, "[::1]:8080->80/tcp, [fd00::1]:53->53/udp", portsToString(ports))
+}
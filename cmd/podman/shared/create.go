@@ -15,6 +15,7 @@ import (
 	"github.com/containers/image/manifest"
 	"github.com/containers/libpod/cmd/podman/shared/parse"
 	"github.com/containers/libpod/libpod"
+	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/libpod/image"
 	ann "github.com/containers/libpod/pkg/annotations"
 	"github.com/containers/libpod/pkg/errorhandling"
@@ -23,6 +24,7 @@ import (
 	"github.com/containers/libpod/pkg/rootless"
 	cc "github.com/containers/libpod/pkg/spec"
 	"github.com/containers/libpod/pkg/util"
+	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/docker/docker/pkg/signal"
 	"github.com/docker/go-connections/nat"
 	"github.com/docker/go-units"
@@ -305,6 +307,13 @@ func configurePod(c *GenericCLIResults, runtime *libpod.Runtime, namespaces map[
 		namespaces["pid"] = fmt.Sprintf("container:%s", podInfraID)
 	}
 	if (namespaces["net"] == cc.Pod) || (!c.IsSet("net") && !c.IsSet("network") && pod.SharesNet()) {
+		// The network is configured on the infra container. Only host
+		// entries can be added, to the hosts file shared with it.
+		for _, flag := range []string{"dns", "dns-opt", "dns-search", "ip", "ip6", "network-alias", "network-egress-burst", "network-egress-rate", "network-ingress-burst", "network-ingress-rate"} {
+			if c.IsSet(flag) {
+				return namespaces, errors.Errorf("cannot set --%s on a container sharing the network namespace of pod %s, set it when creating the pod", flag, pod.Name())
+			}
+		}
 		namespaces["net"] = fmt.Sprintf("container:%s", podInfraID)
 	}
 	if hasUserns && (namespaces["user"] == cc.Pod) || (!c.IsSet("user") && pod.SharesUser()) {
@@ -314,6 +323,9 @@ func configurePod(c *GenericCLIResults, runtime *libpod.Runtime, namespaces map[
 		namespaces["ipc"] = fmt.Sprintf("container:%s", podInfraID)
 	}
 	if (namespaces["uts"] == cc.Pod) || (!c.IsSet("uts") && pod.SharesUTS()) {
+		if c.IsSet("hostname") {
+			return namespaces, errors.Errorf("cannot set --hostname on a container sharing the UTS namespace of pod %s, set it when creating the pod", pod.Name())
+		}
 		namespaces["uts"] = fmt.Sprintf("container:%s", podInfraID)
 	}
 	return namespaces, nil
@@ -642,6 +654,24 @@ func ParseCreateOpts(ctx context.Context, c *GenericCLIResults, runtime *libpod.
 		}
 	}
 
+	// Parse the bandwidth limits
+	var bandwidth *ocicni.BandwidthConfig
+	limits := ocicni.BandwidthConfig{}
+	for flag, val := range map[string]*uint64{
+		"network-egress-burst":  &limits.EgressBurst,
+		"network-egress-rate":   &limits.EgressRate,
+		"network-ingress-burst": &limits.IngressBurst,
+		"network-ingress-rate":  &limits.IngressRate,
+	} {
+		if !c.IsSet(flag) {
+			continue
+		}
+		if *val, err = parse.ParseBits(c.String(flag)); err != nil {
+			return nil, errors.Wrapf(err, "invalid value for %s", flag)
+		}
+		bandwidth = &limits
+	}
+
 	var ImageVolumes map[string]struct{}
 	if data != nil && c.String("image-volume") != "ignore" {
 		ImageVolumes = data.Config.Volumes
@@ -656,6 +686,22 @@ func ParseCreateOpts(ctx context.Context, c *GenericCLIResults, runtime *libpod.
 		return nil, errors.Errorf("invalid image-volume type %q. Pick one of bind, tmpfs, or ignore", c.String("image-volume"))
 	}
 
+	if err := define.ValidateSdNotifyMode(c.String("sdnotify")); err != nil {
+		return nil, err
+	}
+
+	var restartBackoff, restartBackoffMax time.Duration
+	if c.IsSet("restart-backoff") {
+		if restartBackoff, err = time.ParseDuration(c.String("restart-backoff")); err != nil {
+			return nil, errors.Wrapf(err, "invalid restart-backoff %s", c.String("restart-backoff"))
+		}
+	}
+	if c.IsSet("restart-backoff-max") {
+		if restartBackoffMax, err = time.ParseDuration(c.String("restart-backoff-max")); err != nil {
+			return nil, errors.Wrapf(err, "invalid restart-backoff-max %s", c.String("restart-backoff-max"))
+		}
+	}
+
 	var systemd bool
 	if command != nil && c.Bool("systemd") && ((filepath.Base(command[0]) == "init") || (filepath.Base(command[0]) == "systemd")) {
 		systemd = true
@@ -684,6 +730,7 @@ func ParseCreateOpts(ctx context.Context, c *GenericCLIResults, runtime *libpod.
 		Annotations:       annotations,
 		BuiltinImgVolumes: ImageVolumes,
 		ConmonPidFile:     c.String("conmon-pidfile"),
+		CreateCommand:     c.CreateCommand,
 		ImageVolumeType:   c.String("image-volume"),
 		CapAdd:            c.StringSlice("cap-add"),
 		CapDrop:           c.StringSlice("cap-drop"),
@@ -711,16 +758,17 @@ func ParseCreateOpts(ctx context.Context, c *GenericCLIResults, runtime *libpod.
 		Image:       imageName,
 		ImageID:     imageID,
 		Interactive: c.Bool("interactive"),
-		// IP6Address:     c.String("ipv6"), // Not implemented yet - needs CNI support for static v6
-		IPAddress: c.String("ip"),
-		Labels:    labels,
+		IP6Address:  c.String("ip6"),
+		IPAddress:   c.String("ip"),
+		Labels:      labels,
 		// LinkLocalIP:    c.StringSlice("link-local-ip"), // Not implemented yet
-		LogDriver:    logDriver,
-		LogDriverOpt: c.StringSlice("log-opt"),
-		MacAddress:   c.String("mac-address"),
-		Name:         c.String("name"),
-		Network:      network,
-		// NetworkAlias:   c.StringSlice("network-alias"), // Not implemented - does this make sense in Podman?
+		LogDriver:      logDriver,
+		LogDriverOpt:   c.StringSlice("log-opt"),
+		MacAddress:     c.String("mac-address"),
+		Name:           c.String("name"),
+		Network:        network,
+		NetworkAlias:   c.StringSlice("network-alias"),
+		Bandwidth:      bandwidth,
 		IpcMode:        ipcMode,
 		NetMode:        netMode,
 		UtsMode:        utsMode,
@@ -760,22 +808,25 @@ func ParseCreateOpts(ctx context.Context, c *GenericCLIResults, runtime *libpod.
 			PidsLimit:         c.Int64("pids-limit"),
 			Ulimit:            c.StringSlice("ulimit"),
 		},
-		RestartPolicy: c.String("restart"),
-		Rm:            c.Bool("rm"),
-		StopSignal:    stopSignal,
-		StopTimeout:   c.Uint("stop-timeout"),
-		Sysctl:        sysctl,
-		Systemd:       systemd,
-		Tmpfs:         c.StringArray("tmpfs"),
-		Tty:           tty,
-		User:          user,
-		UsernsMode:    usernsMode,
-		MountsFlag:    c.StringArray("mount"),
-		Volumes:       c.StringArray("volume"),
-		WorkDir:       workDir,
-		Rootfs:        rootfs,
-		VolumesFrom:   c.StringSlice("volumes-from"),
-		Syslog:        c.Bool("syslog"),
+		RestartPolicy:     c.String("restart"),
+		RestartBackoff:    restartBackoff,
+		RestartBackoffMax: restartBackoffMax,
+		SdNotifyMode:      c.String("sdnotify"),
+		Rm:                c.Bool("rm"),
+		StopSignal:        stopSignal,
+		StopTimeout:       c.Uint("stop-timeout"),
+		Sysctl:            sysctl,
+		Systemd:           systemd,
+		Tmpfs:             c.StringArray("tmpfs"),
+		Tty:               tty,
+		User:              user,
+		UsernsMode:        usernsMode,
+		MountsFlag:        c.StringArray("mount"),
+		Volumes:           c.StringArray("volume"),
+		WorkDir:           workDir,
+		Rootfs:            rootfs,
+		VolumesFrom:       c.StringSlice("volumes-from"),
+		Syslog:            c.Bool("syslog"),
 	}
 
 	if config.Privileged {
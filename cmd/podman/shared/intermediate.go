@@ -1,6 +1,8 @@
 package shared
 
 import (
+	"os"
+
 	"github.com/containers/libpod/cmd/podman/cliconfig"
 	"github.com/sirupsen/logrus"
 )
@@ -89,6 +91,9 @@ type createResult struct {
 type GenericCLIResults struct {
 	results   map[string]GenericCLIResult
 	InputArgs []string
+	// CreateCommand is the command line the container is created with,
+	// if it was created from the command line
+	CreateCommand []string
 }
 
 // IsSet returns a bool if the flag was changed
@@ -413,6 +418,7 @@ func NewIntermediateLayer(c *cliconfig.PodmanCommand, remote bool) GenericCLIRes
 	m["init-path"] = newCRString(c, "init-path")
 	m["interactive"] = newCRBool(c, "interactive")
 	m["ip"] = newCRString(c, "ip")
+	m["ip6"] = newCRString(c, "ip6")
 	m["ipc"] = newCRString(c, "ipc")
 	m["kernel-memory"] = newCRString(c, "kernel-memory")
 	m["label"] = newCRStringArray(c, "label")
@@ -427,6 +433,11 @@ func NewIntermediateLayer(c *cliconfig.PodmanCommand, remote bool) GenericCLIRes
 	m["name"] = newCRString(c, "name")
 	m["net"] = newCRString(c, "net")
 	m["network"] = newCRString(c, "network")
+	m["network-alias"] = newCRStringSlice(c, "network-alias")
+	m["network-egress-burst"] = newCRString(c, "network-egress-burst")
+	m["network-egress-rate"] = newCRString(c, "network-egress-rate")
+	m["network-ingress-burst"] = newCRString(c, "network-ingress-burst")
+	m["network-ingress-rate"] = newCRString(c, "network-ingress-rate")
 	m["no-hosts"] = newCRBool(c, "no-hosts")
 	m["oom-kill-disable"] = newCRBool(c, "oom-kill-disable")
 	m["oom-score-adj"] = newCRInt(c, "oom-score-adj")
@@ -440,8 +451,11 @@ func NewIntermediateLayer(c *cliconfig.PodmanCommand, remote bool) GenericCLIRes
 	m["read-only"] = newCRBool(c, "read-only")
 	m["read-only-tmpfs"] = newCRBool(c, "read-only-tmpfs")
 	m["restart"] = newCRString(c, "restart")
+	m["restart-backoff"] = newCRString(c, "restart-backoff")
+	m["restart-backoff-max"] = newCRString(c, "restart-backoff-max")
 	m["rm"] = newCRBool(c, "rm")
 	m["rootfs"] = newCRBool(c, "rootfs")
+	m["sdnotify"] = newCRString(c, "sdnotify")
 	m["security-opt"] = newCRStringArray(c, "security-opt")
 	m["shm-size"] = newCRString(c, "shm-size")
 	m["stop-signal"] = newCRString(c, "stop-signal")
@@ -468,5 +482,5 @@ func NewIntermediateLayer(c *cliconfig.PodmanCommand, remote bool) GenericCLIRes
 		m["syslog"] = newCRBool(c, "syslog")
 	}
 
-	return GenericCLIResults{m, c.InputArgs}
+	return GenericCLIResults{results: m, InputArgs: c.InputArgs, CreateCommand: os.Args}
 }
@@ -115,6 +115,7 @@ func (g GenericCLIResults) MakeVarlink() iopodman.Create {
 		InitPath:               StringToPtr(g.Find("init-path")),
 		Interactive:            BoolToPtr(g.Find("interactive")),
 		Ip:                     StringToPtr(g.Find("ip")),
+		Ip6:                    StringToPtr(g.Find("ip6")),
 		Ipc:                    StringToPtr(g.Find("ipc")),
 		KernelMemory:           StringToPtr(g.Find("kernel-memory")),
 		Label:                  StringSliceToPtr(g.Find("label")),
@@ -129,6 +130,11 @@ func (g GenericCLIResults) MakeVarlink() iopodman.Create {
 		Name:                   StringToPtr(g.Find("name")),
 		Net:                    StringToPtr(g.Find("net")),
 		Network:                StringToPtr(g.Find("network")),
+		NetworkAlias:           StringSliceToPtr(g.Find("network-alias")),
+		NetworkEgressBurst:     StringToPtr(g.Find("network-egress-burst")),
+		NetworkEgressRate:      StringToPtr(g.Find("network-egress-rate")),
+		NetworkIngressBurst:    StringToPtr(g.Find("network-ingress-burst")),
+		NetworkIngressRate:     StringToPtr(g.Find("network-ingress-rate")),
 		OomKillDisable:         BoolToPtr(g.Find("oom-kill-disable")),
 		OomScoreAdj:            AnyIntToInt64Ptr(g.Find("oom-score-adj")),
 		Pid:                    StringToPtr(g.Find("pid")),
@@ -141,8 +147,11 @@ func (g GenericCLIResults) MakeVarlink() iopodman.Create {
 		Readonly:               BoolToPtr(g.Find("read-only")),
 		Readonlytmpfs:          BoolToPtr(g.Find("read-only-tmpfs")),
 		Restart:                StringToPtr(g.Find("restart")),
+		RestartBackoff:         StringToPtr(g.Find("restart-backoff")),
+		RestartBackoffMax:      StringToPtr(g.Find("restart-backoff-max")),
 		Rm:                     BoolToPtr(g.Find("rm")),
 		Rootfs:                 BoolToPtr(g.Find("rootfs")),
+		Sdnotify:               StringToPtr(g.Find("sdnotify")),
 		SecurityOpt:            StringSliceToPtr(g.Find("security-opt")),
 		ShmSize:                StringToPtr(g.Find("shm-size")),
 		StopSignal:             StringToPtr(g.Find("stop-signal")),
@@ -370,6 +379,7 @@ func VarlinkCreateToGeneric(opts iopodman.Create) GenericCLIResults {
 	m["init-path"] = stringFromVarlink(opts.InitPath, "init-path", nil)
 	m["interactive"] = boolFromVarlink(opts.Interactive, "interactive", false)
 	m["ip"] = stringFromVarlink(opts.Ip, "ip", nil)
+	m["ip6"] = stringFromVarlink(opts.Ip6, "ip6", nil)
 	m["ipc"] = stringFromVarlink(opts.Ipc, "ipc", nil)
 	m["kernel-memory"] = stringFromVarlink(opts.KernelMemory, "kernel-memory", nil)
 	m["label"] = stringArrayFromVarlink(opts.Label, "label", nil)
@@ -384,6 +394,11 @@ func VarlinkCreateToGeneric(opts iopodman.Create) GenericCLIResults {
 	m["name"] = stringFromVarlink(opts.Name, "name", nil)
 	m["net"] = stringFromVarlink(opts.Net, "net", &netModeDefault)
 	m["network"] = stringFromVarlink(opts.Network, "network", &netModeDefault)
+	m["network-alias"] = stringSliceFromVarlink(opts.NetworkAlias, "network-alias", nil)
+	m["network-egress-burst"] = stringFromVarlink(opts.NetworkEgressBurst, "network-egress-burst", nil)
+	m["network-egress-rate"] = stringFromVarlink(opts.NetworkEgressRate, "network-egress-rate", nil)
+	m["network-ingress-burst"] = stringFromVarlink(opts.NetworkIngressBurst, "network-ingress-burst", nil)
+	m["network-ingress-rate"] = stringFromVarlink(opts.NetworkIngressRate, "network-ingress-rate", nil)
 	m["no-hosts"] = boolFromVarlink(opts.NoHosts, "no-hosts", false)
 	m["oom-kill-disable"] = boolFromVarlink(opts.OomKillDisable, "oon-kill-disable", false)
 	m["oom-score-adj"] = intFromVarlink(opts.OomScoreAdj, "oom-score-adj", nil)
@@ -397,8 +412,11 @@ func VarlinkCreateToGeneric(opts iopodman.Create) GenericCLIResults {
 	m["read-only"] = boolFromVarlink(opts.Readonly, "read-only", false)
 	m["read-only-tmpfs"] = boolFromVarlink(opts.Readonlytmpfs, "read-only-tmpfs", true)
 	m["restart"] = stringFromVarlink(opts.Restart, "restart", nil)
+	m["restart-backoff"] = stringFromVarlink(opts.RestartBackoff, "restart-backoff", nil)
+	m["restart-backoff-max"] = stringFromVarlink(opts.RestartBackoffMax, "restart-backoff-max", nil)
 	m["rm"] = boolFromVarlink(opts.Rm, "rm", false)
 	m["rootfs"] = boolFromVarlink(opts.Rootfs, "rootfs", false)
+	m["sdnotify"] = stringFromVarlink(opts.Sdnotify, "sdnotify", nil)
 	m["security-opt"] = stringArrayFromVarlink(opts.SecurityOpt, "security-opt", nil)
 	m["shm-size"] = stringFromVarlink(opts.ShmSize, "shm-size", &cliconfig.DefaultShmSize)
 	m["stop-signal"] = stringFromVarlink(opts.StopSignal, "stop-signal", nil)
@@ -420,7 +438,7 @@ func VarlinkCreateToGeneric(opts iopodman.Create) GenericCLIResults {
 	m["volumes-from"] = stringSliceFromVarlink(opts.VolumesFrom, "volumes-from", nil)
 	m["workdir"] = stringFromVarlink(opts.WorkDir, "workdir", nil)
 
-	gcli := GenericCLIResults{m, opts.Args}
+	gcli := GenericCLIResults{results: m, InputArgs: opts.Args}
 	return gcli
 }
 
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -173,3 +174,35 @@ func ValidURL(urlStr string) error {
 	}
 	return nil
 }
+
+// bitUnits are the multipliers of the unit suffixes accepted by ParseBits
+var bitUnits = map[string]float64{
+	"":     1,
+	"bit":  1,
+	"k":    1e3,
+	"kbit": 1e3,
+	"m":    1e6,
+	"mbit": 1e6,
+	"g":    1e9,
+	"gbit": 1e9,
+	"t":    1e12,
+	"tbit": 1e12,
+}
+
+// ParseBits parses an amount of bits, such as a bandwidth limit, given as a
+// number with an optional k, m, g or t (kbit, mbit, gbit or tbit) suffix.
+// Units are decimal, as with tc.
+func ParseBits(val string) (uint64, error) {
+	num := strings.TrimRightFunc(val, func(r rune) bool {
+		return alphaRegexp.MatchString(string(r))
+	})
+	unit, ok := bitUnits[strings.ToLower(val[len(num):])]
+	if !ok {
+		return 0, errors.Errorf("invalid unit in %q, must be one of k, m, g or t", val)
+	}
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n < 0 {
+		return 0, errors.Errorf("invalid amount of bits %q", val)
+	}
+	return uint64(n * unit), nil
+}
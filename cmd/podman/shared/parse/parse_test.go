@@ -97,3 +97,37 @@ func TestValidateFileName(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBits(t *testing.T) {
+	tests := []struct {
+		val     string
+		want    uint64
+		wantErr bool
+	}{
+		{val: "800", want: 800},
+		{val: "800bit", want: 800},
+		{val: "10k", want: 10000},
+		{val: "10kbit", want: 10000},
+		{val: "1.5mbit", want: 1500000},
+		{val: "100M", want: 100000000},
+		{val: "2Gbit", want: 2000000000},
+		{val: "1t", want: 1000000000000},
+		{val: "", wantErr: true},
+		{val: "mbit", wantErr: true},
+		{val: "10mb", wantErr: true},
+		{val: "-1m", wantErr: true},
+		{val: "1x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.val, func(t *testing.T) {
+			got, err := ParseBits(tt.val)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBits() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseBits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
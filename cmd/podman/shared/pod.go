@@ -1,10 +1,14 @@
 package shared
 
 import (
+	"net"
 	"strconv"
+	"strings"
 
+	"github.com/containers/libpod/cmd/podman/shared/parse"
 	"github.com/containers/libpod/libpod"
 	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/pkg/rootless"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/docker/go-connections/nat"
 	"github.com/pkg/errors"
@@ -105,6 +109,68 @@ func GetNamespaceOptions(ns []string) ([]libpod.PodCreateOption, error) {
 	return options, nil
 }
 
+// PodNetworkOptions are the network settings of a pod's infra container,
+// which are shared by all containers that join the pod's network and UTS
+// namespaces
+type PodNetworkOptions struct {
+	// Network is "bridge", "host" or a comma separated list of CNI
+	// networks
+	Network      string
+	IP           string
+	Hostname     string
+	DNS          []string
+	DNSSearch    []string
+	AddHost      []string
+	NetworkAlias []string
+}
+
+// GetPodNetworkOptions converts the network settings of a pod into pod
+// create options. They must follow the infra container option.
+func GetPodNetworkOptions(opts PodNetworkOptions) ([]libpod.PodCreateOption, error) {
+	var options []libpod.PodCreateOption
+	switch {
+	case opts.Network == "" || opts.Network == "bridge":
+	case opts.Network == "host":
+		options = append(options, libpod.WithPodHostNetwork())
+	case opts.Network == "slirp4netns" && rootless.IsRootless():
+		// slirp4netns is the default for rootless pods
+	case opts.Network == "none" || strings.Contains(opts.Network, ":"):
+		return nil, errors.Wrapf(define.ErrInvalidArg, "invalid pod network %q, pods can use the bridge network, the host network or CNI networks", opts.Network)
+	default:
+		options = append(options, libpod.WithPodNetworks(strings.Split(opts.Network, ",")))
+	}
+	if opts.IP != "" {
+		ip := net.ParseIP(opts.IP)
+		if ip == nil {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "cannot parse %s as IP address", opts.IP)
+		} else if ip.To4() == nil {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "%s is not an IPv4 address", opts.IP)
+		}
+		options = append(options, libpod.WithPodStaticIP(ip))
+	}
+	if opts.Hostname != "" {
+		options = append(options, libpod.WithPodHostname(opts.Hostname))
+	}
+	if len(opts.DNS) > 0 {
+		options = append(options, libpod.WithPodDNS(opts.DNS))
+	}
+	if len(opts.DNSSearch) > 0 {
+		options = append(options, libpod.WithPodDNSSearch(opts.DNSSearch))
+	}
+	if len(opts.AddHost) > 0 {
+		for _, host := range opts.AddHost {
+			if _, err := parse.ValidateExtraHost(host); err != nil {
+				return nil, err
+			}
+		}
+		options = append(options, libpod.WithPodHosts(opts.AddHost))
+	}
+	if len(opts.NetworkAlias) > 0 {
+		options = append(options, libpod.WithPodNetworkAliases(opts.NetworkAlias))
+	}
+	return options, nil
+}
+
 // CreatePortBindings iterates ports mappings and exposed ports into a format CNI understands
 func CreatePortBindings(ports []string) ([]ocicni.PortMapping, error) {
 	var portBindings []ocicni.PortMapping
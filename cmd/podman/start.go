@@ -25,7 +25,8 @@ var (
 		},
 		Example: `podman start --latest
   podman start 860a4b231279 5421ab43b45
-  podman start --interactive --attach imageID`,
+  podman start --interactive --attach imageID
+  podman start --all --filter restart-policy=always`,
 	}
 )
 
@@ -34,11 +35,15 @@ func init() {
 	startCommand.SetHelpTemplate(HelpTemplate())
 	startCommand.SetUsageTemplate(UsageTemplate())
 	flags := startCommand.Flags()
+	flags.BoolVar(&startCommand.All, "all", false, "Start all containers, or all containers matching --filter")
 	flags.BoolVarP(&startCommand.Attach, "attach", "a", false, "Attach container's STDOUT and STDERR")
 	flags.StringVar(&startCommand.DetachKeys, "detach-keys", "", "Override the key sequence for detaching a container. Format is a single character `[a-Z]` or a comma separated sequence of `ctrl-<value>`, where `<value>` is one of: `a-z`, `@`, `^`, `[`, `\\`, `]`, `^` or `_`")
+	flags.StringArrayVarP(&startCommand.Filter, "filter", "f", []string{}, "Filter the containers started with --all (e.g. restart-policy=always)")
 	flags.BoolVarP(&startCommand.Interactive, "interactive", "i", false, "Keep STDIN open even if not attached")
 	flags.BoolVarP(&startCommand.Latest, "latest", "l", false, "Act on the latest container podman is aware of")
 	flags.BoolVar(&startCommand.SigProxy, "sig-proxy", false, "Proxy received signals to the process (default true if attaching, false otherwise)")
+	markFlagHiddenForRemoteClient("all", flags)
+	markFlagHiddenForRemoteClient("filter", flags)
 	markFlagHiddenForRemoteClient("latest", flags)
 }
 
@@ -49,7 +54,17 @@ func startCmd(c *cliconfig.StartValues) error {
 	}
 
 	args := c.InputArgs
-	if len(args) < 1 && !c.Latest {
+	if c.All {
+		if len(args) > 0 || c.Latest {
+			return errors.Errorf("--all cannot be used with container names, ids or --latest")
+		}
+		if c.Attach {
+			return errors.Errorf("you cannot start and attach multiple containers at once")
+		}
+	} else if len(c.Filter) > 0 {
+		return errors.Errorf("--filter can only be used with --all")
+	}
+	if len(args) < 1 && !c.Latest && !c.All {
 		return errors.Errorf("you must provide at least one container name or id")
 	}
 
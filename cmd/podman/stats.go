@@ -27,6 +27,7 @@ type statsOutputParams struct {
 	NetIO    string `json:"netio"`
 	BlockIO  string `json:"blocki"`
 	PIDS     string `json:"pids"`
+	NetLimit string `json:"netlimit"`
 }
 
 var (
@@ -254,6 +255,18 @@ func combineHumanValues(a, b uint64) string {
 	return fmt.Sprintf("%s / %s", units.HumanSize(float64(a)), units.HumanSize(float64(b)))
 }
 
+// combineBitRates formats the ingress and egress bandwidth limits of a
+// container, given in bits per second
+func combineBitRates(ingress, egress uint64) string {
+	rate := func(r uint64) string {
+		if r == 0 {
+			return "--"
+		}
+		return units.CustomSize("%.4g%sbit", float64(r), 1000.0, []string{"", "k", "M", "G", "T"})
+	}
+	return fmt.Sprintf("%s / %s", rate(ingress), rate(egress))
+}
+
 func floatToPercentString(f float64) string {
 	strippedFloat, err := libpod.RemoveScientificNotationFromFloat(f)
 	if err != nil || strippedFloat == 0 {
@@ -281,6 +294,7 @@ func getStatsOutputParams(stats *libpod.ContainerStats) statsOutputParams {
 		NetIO:    combineHumanValues(stats.NetInput, stats.NetOutput),
 		BlockIO:  combineHumanValues(stats.BlockInput, stats.BlockOutput),
 		PIDS:     pidsToString(stats.PIDs),
+		NetLimit: combineBitRates(stats.NetIngressRate, stats.NetEgressRate),
 	}
 }
 
@@ -294,5 +308,6 @@ func getStatsOutputParamsEmpty() statsOutputParams {
 		NetIO:    "",
 		BlockIO:  "",
 		PIDS:     "",
+		NetLimit: "",
 	}
 }
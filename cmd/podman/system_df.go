@@ -6,7 +6,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -86,12 +85,13 @@ type volumeVerboseDiskUsage struct {
 	VolumeName string
 	Links      int
 	Size       string
+	Quota      string
 }
 
 const systemDfDefaultFormat string = "table {{.Type}}\t{{.Total}}\t{{.Active}}\t{{.Size}}\t{{.Reclaimable}}"
 const imageVerboseFormat string = "table {{.Repository}}\t{{.Tag}}\t{{.ImageID}}\t{{.Created}}\t{{.Size}}\t{{.SharedSize}}\t{{.UniqueSize}}\t{{.Containers}}"
 const containerVerboseFormat string = "table {{.ContainerID}}\t{{.Image}}\t{{.Command}}\t{{.LocalVolumes}}\t{{.Size}}\t{{.Created}}\t{{.Status}}\t{{.Names}}"
-const volumeVerboseFormat string = "table {{.VolumeName}}\t{{.Links}}\t{{.Size}}"
+const volumeVerboseFormat string = "table {{.VolumeName}}\t{{.Links}}\t{{.Size}}\t{{.Quota}}"
 
 func init() {
 	dfSystemCommand.Command = _dfSystemCommand
@@ -384,10 +384,17 @@ func getVolumeDiskUsage(volumes []*libpod.Volume, volumeUsedByContainerMap map[s
 		reclaimableStr    string
 	)
 	for _, volume := range volumes {
-		size, err := volumeSize(volume)
+		// Getting the size of a volume of a volume plugin requires a
+		// request to the plugin, which may not be reachable
+		if volume.UsesVolumeDriver() {
+			continue
+		}
+		usage, err := volume.Usage()
 		if err != nil {
-			return systemDfDiskUsage{}, errors.Wrapf(err, "error getting size of volime %s", volume.Name())
+			logrus.Warnf("Error getting size of volume %s: %v", volume.Name(), err)
+			continue
 		}
+		size := int64(usage)
 		sumSize += size
 		if _, exist := volumeUsedByContainerMap[volume.Name()]; exist {
 			unreclaimableSize += size
@@ -421,17 +428,6 @@ func volumeUsedByContainer(containers []*libpod.Container) map[string][]*libpod.
 	return volumeUsedByContainerMap
 }
 
-func volumeSize(volume *libpod.Volume) (int64, error) {
-	var size int64
-	err := filepath.Walk(volume.MountPoint(), func(path string, info os.FileInfo, err error) error {
-		if err == nil && !info.IsDir() {
-			size += info.Size()
-		}
-		return err
-	})
-	return size, err
-}
-
 func getImageVerboseDiskUsage(ctx context.Context, images []*image.Image, imagesUsedbyCtr map[string][]*libpod.Container) ([]imageVerboseDiskUsage, error) {
 	var imagesVerboseDiskUsage []imageVerboseDiskUsage
 	imgUniqueSizeMap, err := imageUniqueSize(ctx, images)
@@ -520,10 +516,6 @@ func getContainerVerboseDiskUsage(containers []*libpod.Container) (containersVer
 
 func getVolumeVerboseDiskUsage(volumes []*libpod.Volume, volumeUsedByContainerMap map[string][]*libpod.Container) (volumesVerboseDiskUsage []volumeVerboseDiskUsage, err error) {
 	for _, vol := range volumes {
-		volSize, err := volumeSize(vol)
-		if err != nil {
-			return volumesVerboseDiskUsage, errors.Wrapf(err, "error getting size of volume %s", vol.Name())
-		}
 		links := 0
 		if linkCtr, exist := volumeUsedByContainerMap[vol.Name()]; exist {
 			links = len(linkCtr)
@@ -531,7 +523,18 @@ func getVolumeVerboseDiskUsage(volumes []*libpod.Volume, volumeUsedByContainerMa
 		volumeVerboseData := volumeVerboseDiskUsage{
 			VolumeName: vol.Name(),
 			Links:      links,
-			Size:       units.HumanSizeWithPrecision(float64(volSize), 3),
+			Size:       "N/A",
+		}
+		if !vol.UsesVolumeDriver() {
+			volSize, err := vol.Usage()
+			if err != nil {
+				logrus.Warnf("Error getting size of volume %s: %v", vol.Name(), err)
+			} else {
+				volumeVerboseData.Size = units.HumanSizeWithPrecision(float64(volSize), 3)
+			}
+		}
+		if vol.Quota() > 0 {
+			volumeVerboseData.Quota = units.HumanSizeWithPrecision(float64(vol.Quota()), 3)
 		}
 		volumesVerboseDiskUsage = append(volumesVerboseDiskUsage, volumeVerboseData)
 	}
@@ -588,6 +591,7 @@ func volumesVerboseOutput(ctx context.Context, metaData dfMetaData) error {
 		"VolumeName": "VOLUME NAME",
 		"Links":      "LINKS",
 		"Size":       "SIZE",
+		"Quota":      "QUOTA",
 	}
 	volumesVerboseDiskUsage, err := getVolumeVerboseDiskUsage(metaData.volumes, metaData.volumeUsedByContainerMap)
 	if err != nil {
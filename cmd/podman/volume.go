@@ -17,6 +17,8 @@ var volumeCommand = cliconfig.PodmanCommand{
 }
 var volumeSubcommands = []*cobra.Command{
 	_volumeCreateCommand,
+	_volumeExportCommand,
+	_volumeImportCommand,
 	_volumeLsCommand,
 	_volumeRmCommand,
 	_volumeInspectCommand,
@@ -12,7 +12,9 @@ import (
 
 var (
 	volumeCreateCommand     cliconfig.VolumeCreateValues
-	volumeCreateDescription = `If using the default driver, "local", the volume will be created on the host in the volumes directory under container storage.`
+	volumeCreateDescription = `If using the default driver, "local", the volume will be created on the host in the volumes directory under container storage. The type, device and o options mount a filesystem on the volume while containers use it.
+
+  Any other driver is the name of a Docker volume plugin, which creates and mounts the volume.`
 
 	_volumeCreateCommand = &cobra.Command{
 		Use:   "create [flags] [NAME]",
@@ -26,7 +28,8 @@ var (
 		},
 		Example: `podman volume create myvol
   podman volume create
-  podman volume create --label foo=bar myvol`,
+  podman volume create --label foo=bar myvol
+  podman volume create --opt type=tmpfs --opt o=size=100m myvol`,
 	}
 )
 
@@ -37,7 +40,7 @@ func init() {
 	flags := volumeCreateCommand.Flags()
 	flags.StringVar(&volumeCreateCommand.Driver, "driver", "", "Specify volume driver name (default local)")
 	flags.StringSliceVarP(&volumeCreateCommand.Label, "label", "l", []string{}, "Set metadata for a volume (default [])")
-	flags.StringSliceVarP(&volumeCreateCommand.Opt, "opt", "o", []string{}, "Set driver specific options (default [])")
+	flags.StringArrayVarP(&volumeCreateCommand.Opt, "opt", "o", []string{}, "Set driver specific options (default [])")
 
 }
 
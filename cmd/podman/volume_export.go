@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/cmd/podman/shared/parse"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	volumeExportCommand     cliconfig.VolumeExportValues
+	volumeExportDescription = `Exports the contents of a volume as a tar archive.
+
+  Running containers able to write to the volume must be paused during the export, which is refused otherwise.`
+	_volumeExportCommand = &cobra.Command{
+		Use:   "export [flags] VOLUME",
+		Short: "Export the contents of a volume as a tar archive",
+		Long:  volumeExportDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			volumeExportCommand.InputArgs = args
+			volumeExportCommand.GlobalFlags = MainGlobalOpts
+			volumeExportCommand.Remote = remoteclient
+			return volumeExportCmd(&volumeExportCommand)
+		},
+		Example: `podman volume export myvol > myvol.tar
+  podman volume export --output myvol.tar myvol
+  podman volume export --pause --output myvol.tar myvol`,
+	}
+)
+
+func init() {
+	volumeExportCommand.Command = _volumeExportCommand
+	volumeExportCommand.SetHelpTemplate(HelpTemplate())
+	volumeExportCommand.SetUsageTemplate(UsageTemplate())
+	flags := volumeExportCommand.Flags()
+	flags.StringVarP(&volumeExportCommand.Output, "output", "o", "", "Write to a specified file (default: stdout, which must be redirected)")
+	flags.BoolVar(&volumeExportCommand.Pause, "pause", false, "Pause running containers writing to the volume during the export")
+}
+
+func volumeExportCmd(c *cliconfig.VolumeExportValues) error {
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	if len(c.InputArgs) != 1 {
+		return errors.Errorf("export requires exactly 1 volume")
+	}
+
+	output := c.Output
+	if runtime.Remote && len(output) == 0 {
+		return errors.New("remote client usage must specify an output file (-o)")
+	}
+
+	if len(output) == 0 {
+		file := os.Stdout
+		if terminal.IsTerminal(int(file.Fd())) {
+			return errors.Errorf("refusing to export to terminal. Use -o flag or redirect")
+		}
+		output = "/dev/stdout"
+	}
+
+	if err := parse.ValidateFileName(output); err != nil {
+		return err
+	}
+	return runtime.ExportVolume(c.InputArgs[0], output, c.Pause)
+}
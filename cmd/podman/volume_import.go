@@ -0,0 +1,65 @@
+package main
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/cmd/podman/shared/parse"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	volumeImportCommand     cliconfig.VolumeImportValues
+	volumeImportDescription = `Imports the contents of a tar archive into a volume.
+
+  The volume is created if it does not exist, and must be empty otherwise. The archive is read from stdin if it is "-" or not given.`
+	_volumeImportCommand = &cobra.Command{
+		Use:   "import [flags] VOLUME [TARBALL]",
+		Short: "Import a tar archive into a volume",
+		Long:  volumeImportDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			volumeImportCommand.InputArgs = args
+			volumeImportCommand.GlobalFlags = MainGlobalOpts
+			volumeImportCommand.Remote = remoteclient
+			return volumeImportCmd(&volumeImportCommand)
+		},
+		Example: `podman volume import myvol myvol.tar
+  cat myvol.tar | podman volume import myvol -`,
+	}
+)
+
+func init() {
+	volumeImportCommand.Command = _volumeImportCommand
+	volumeImportCommand.SetHelpTemplate(HelpTemplate())
+	volumeImportCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func volumeImportCmd(c *cliconfig.VolumeImportValues) error {
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	var source string
+	switch len(c.InputArgs) {
+	case 1:
+		source = "-"
+	case 2:
+		source = c.InputArgs[1]
+	default:
+		return errors.Errorf("import requires a volume and at most 1 tarball")
+	}
+
+	if source == "-" {
+		if runtime.Remote {
+			return errors.New("remote client usage must specify a tarball")
+		}
+		source = "/dev/stdin"
+	}
+
+	if err := parse.ValidateFileName(source); err != nil {
+		return err
+	}
+	return runtime.ImportVolume(getContext(), c.InputArgs[0], source)
+}
@@ -25,6 +25,8 @@ type volumeLsTemplateParams struct {
 	Driver     string
 	Options    string
 	Scope      string
+	Quota      uint64
+	Usage      uint64
 }
 
 // volumeLsJSONParams is the JSON parameters to list the volumes
@@ -35,6 +37,8 @@ type volumeLsJSONParams struct {
 	Driver     string            `json:"driver"`
 	Options    map[string]string `json:"options"`
 	Scope      string            `json:"scope"`
+	Quota      uint64            `json:"quota,omitempty"`
+	Usage      uint64            `json:"usage,omitempty"`
 }
 
 var (
@@ -194,6 +198,8 @@ func getVolTemplateOutput(lsParams []volumeLsJSONParams, opts volumeLsOptions) (
 			Scope:      lsParam.Scope,
 			Labels:     labels,
 			Options:    options,
+			Quota:      lsParam.Quota,
+			Usage:      lsParam.Usage,
 		}
 
 		lsOutput = append(lsOutput, params)
@@ -202,22 +208,36 @@ func getVolTemplateOutput(lsParams []volumeLsJSONParams, opts volumeLsOptions) (
 }
 
 // getVolJSONParams returns the volumes in JSON format
-func getVolJSONParams(volumes []*adapter.Volume) []volumeLsJSONParams {
+func getVolJSONParams(volumes []*adapter.Volume) ([]volumeLsJSONParams, error) {
 	var lsOutput []volumeLsJSONParams
 
 	for _, volume := range volumes {
+		mountPoint, err := volume.ResolveMountPoint()
+		if err != nil {
+			return nil, err
+		}
 		params := volumeLsJSONParams{
 			Name:       volume.Name(),
 			Labels:     volume.Labels(),
-			MountPoint: volume.MountPoint(),
+			MountPoint: mountPoint,
 			Driver:     volume.Driver(),
 			Options:    volume.Options(),
 			Scope:      volume.Scope(),
+			Quota:      volume.Quota(),
+		}
+		// Only the usage of volumes with a quota is reported, as it
+		// requires walking the whole volume
+		if params.Quota > 0 {
+			usage, err := volume.Usage()
+			if err != nil {
+				return nil, err
+			}
+			params.Usage = usage
 		}
 
 		lsOutput = append(lsOutput, params)
 	}
-	return lsOutput
+	return lsOutput, nil
 }
 
 // generateVolLsOutput generates the output based on the format, JSON or Go Template, and prints it out
@@ -225,7 +245,10 @@ func generateVolLsOutput(volumes []*adapter.Volume, opts volumeLsOptions) error
 	if len(volumes) == 0 && opts.Format != formats.JSONString {
 		return nil
 	}
-	lsOutput := getVolJSONParams(volumes)
+	lsOutput, err := getVolJSONParams(volumes)
+	if err != nil {
+		return err
+	}
 	var out formats.Writer
 
 	switch opts.Format {
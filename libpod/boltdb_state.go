@@ -131,7 +131,7 @@ func (s *BoltState) Close() error {
 	return nil
 }
 
-// Refresh clears container and pod states after a reboot
+// Refresh clears container, pod, and volume states after a reboot
 func (s *BoltState) Refresh() error {
 	if !s.valid {
 		return define.ErrDBClosed
@@ -159,6 +159,34 @@ func (s *BoltState) Refresh() error {
 			return err
 		}
 
+		volBucket, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		// Nothing is mounted after a reboot, so reset the mount count
+		// of all volumes
+		err = volBucket.ForEach(func(name, _ []byte) error {
+			volDB := volBucket.Bucket(name)
+			if volDB == nil {
+				return errors.Wrapf(define.ErrInternal, "volume %s is not a bucket in DB", string(name))
+			}
+
+			newStateBytes, err := json.Marshal(new(VolumeState))
+			if err != nil {
+				return errors.Wrapf(err, "error marshalling modified state for volume %s", string(name))
+			}
+
+			if err := volDB.Put(stateKey, newStateBytes); err != nil {
+				return errors.Wrapf(err, "error updating state for volume %s in DB", string(name))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
 		// Iterate through all IDs. Check if they are containers.
 		// If they are, unmarshal their state, and then clear
 		// PID, mountpoint, and state for all of them
@@ -220,10 +248,16 @@ func (s *BoltState) Refresh() error {
 				return errors.Wrapf(err, "error unmarshalling state for container %s", string(id))
 			}
 
+			// Record whether the container was running when the
+			// system went down, before the state is reset
+			runningBeforeRefresh := state.State == define.ContainerStateRunning || state.State == define.ContainerStatePaused
+
 			if err := resetState(state); err != nil {
 				return errors.Wrapf(err, "error resetting state for container %s", string(id))
 			}
 
+			state.RunningBeforeRefresh = runningBeforeRefresh
+
 			newStateBytes, err := json.Marshal(state)
 			if err != nil {
 				return errors.Wrapf(err, "error marshalling modified state for container %s", string(id))
@@ -271,6 +305,7 @@ func (s *BoltState) GetDBConfig() (*DBConfig, error) {
 		storageTmp := configBucket.Get(runRootKey)
 		graphDriver := configBucket.Get(graphDriverKey)
 		volumePath := configBucket.Get(volPathKey)
+		volumeLocks := configBucket.Get(volLocksKey)
 
 		cfg.LibpodRoot = string(libpodRoot)
 		cfg.LibpodTmp = string(libpodTmp)
@@ -278,6 +313,7 @@ func (s *BoltState) GetDBConfig() (*DBConfig, error) {
 		cfg.StorageTmp = string(storageTmp)
 		cfg.GraphDriver = string(graphDriver)
 		cfg.VolumePath = string(volumePath)
+		cfg.VolumeLocksAllocated = volumeLocks != nil
 
 		return nil
 	})
@@ -308,6 +344,33 @@ func (s *BoltState) ValidateDBConfig(runtime *Runtime) error {
 	return nil
 }
 
+// SetVolumeLocksAllocated records in the database that all volumes have a lock
+// of their own
+func (s *BoltState) SetVolumeLocksAllocated() error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		configBkt, err := getRuntimeConfigBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		if err := configBkt.Put(volLocksKey, []byte("true")); err != nil {
+			return errors.Wrapf(err, "error updating volume locks in DB runtime config")
+		}
+
+		return nil
+	})
+}
+
 // SetNamespace sets the namespace that will be used for container and pod
 // retrieval
 func (s *BoltState) SetNamespace(ns string) error {
@@ -900,6 +963,50 @@ func (s *BoltState) RewritePodConfig(pod *Pod, newCfg *PodConfig) error {
 	return err
 }
 
+// RewriteVolumeConfig rewrites a volume's configuration.
+// WARNING: This function is DANGEROUS. Do not use without reading the full
+// comment on this function in state.go.
+func (s *BoltState) RewriteVolumeConfig(volume *Volume, newCfg *VolumeConfig) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if !volume.valid {
+		return define.ErrVolumeRemoved
+	}
+
+	newCfgJSON, err := json.Marshal(newCfg)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling new configuration JSON for volume %s", volume.Name())
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		volDB := volBkt.Bucket([]byte(volume.Name()))
+		if volDB == nil {
+			volume.valid = false
+			return errors.Wrapf(define.ErrNoSuchVolume, "no volume with name %s found in DB", volume.Name())
+		}
+
+		if err := volDB.Put(configKey, newCfgJSON); err != nil {
+			return errors.Wrapf(err, "error updating volume %s config JSON", volume.Name())
+		}
+
+		return nil
+	})
+	return err
+}
+
 // Pod retrieves a pod given its full ID
 func (s *BoltState) Pod(id string) (*Pod, error) {
 	if id == "" {
@@ -1308,6 +1415,11 @@ func (s *BoltState) AddVolume(volume *Volume) error {
 		return errors.Wrapf(err, "error marshalling volume %s config to JSON", volume.Name())
 	}
 
+	volStateJSON, err := json.Marshal(volume.state)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling volume %s state to JSON", volume.Name())
+	}
+
 	db, err := s.getDBCon()
 	if err != nil {
 		return err
@@ -1348,6 +1460,10 @@ func (s *BoltState) AddVolume(volume *Volume) error {
 			return errors.Wrapf(err, "error storing volume %s configuration in DB", volume.Name())
 		}
 
+		if err := newVol.Put(stateKey, volStateJSON); err != nil {
+			return errors.Wrapf(err, "error storing volume %s state in DB", volume.Name())
+		}
+
 		if err := allVolsBkt.Put(volName, volName); err != nil {
 			return errors.Wrapf(err, "error storing volume %s in all volumes bucket in DB", volume.Name())
 		}
@@ -1439,6 +1555,102 @@ func (s *BoltState) RemoveVolume(volume *Volume) error {
 	return err
 }
 
+// UpdateVolume updates a volume's state from the database
+func (s *BoltState) UpdateVolume(volume *Volume) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if !volume.valid {
+		return define.ErrVolumeRemoved
+	}
+
+	newState := new(VolumeState)
+	volName := []byte(volume.Name())
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.View(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		volDB := volBkt.Bucket(volName)
+		if volDB == nil {
+			volume.valid = false
+			return errors.Wrapf(define.ErrNoSuchVolume, "no volume with name %s found in database", volume.Name())
+		}
+
+		// Legacy volumes have no state
+		stateBytes := volDB.Get(stateKey)
+		if stateBytes == nil {
+			return nil
+		}
+
+		if err := json.Unmarshal(stateBytes, newState); err != nil {
+			return errors.Wrapf(err, "error unmarshalling volume %s state JSON", volume.Name())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	volume.state = newState
+
+	return nil
+}
+
+// SaveVolume saves a volume's state to the database
+func (s *BoltState) SaveVolume(volume *Volume) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if !volume.valid {
+		return define.ErrVolumeRemoved
+	}
+
+	stateJSON, err := json.Marshal(volume.state)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling volume %s state to JSON", volume.Name())
+	}
+
+	volName := []byte(volume.Name())
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		volBkt, err := getVolBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		volDB := volBkt.Bucket(volName)
+		if volDB == nil {
+			volume.valid = false
+			return errors.Wrapf(define.ErrNoSuchVolume, "no volume with name %s found in database", volume.Name())
+		}
+
+		if err := volDB.Put(stateKey, stateJSON); err != nil {
+			return errors.Wrapf(err, "error updating volume %s state in database", volume.Name())
+		}
+
+		return nil
+	})
+	return err
+}
+
 // AllVolumes returns all volumes present in the state
 func (s *BoltState) AllVolumes() ([]*Volume, error) {
 	if !s.valid {
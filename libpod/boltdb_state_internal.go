@@ -42,6 +42,7 @@ const (
 	graphDriverName = "graph-driver-name"
 	osName          = "os"
 	volPathName     = "volume-path"
+	volLocksName    = "volume-locks"
 )
 
 var (
@@ -72,6 +73,7 @@ var (
 	graphDriverKey = []byte(graphDriverName)
 	osKey          = []byte(osName)
 	volPathKey     = []byte(volPathName)
+	volLocksKey    = []byte(volLocksName)
 )
 
 // This represents a field in the runtime configuration that will be validated
@@ -449,6 +451,31 @@ func (s *BoltState) getVolumeFromDB(name []byte, volume *Volume, volBkt *bolt.Bu
 		return errors.Wrapf(err, "error unmarshalling volume %s config from DB", string(name))
 	}
 
+	// Volumes created before volumes had a state do not have one
+	volume.state = new(VolumeState)
+	if volStateBytes := volDB.Get(stateKey); volStateBytes != nil {
+		if err := json.Unmarshal(volStateBytes, volume.state); err != nil {
+			return errors.Wrapf(err, "error unmarshalling volume %s state from DB", string(name))
+		}
+	}
+
+	// Volumes created before volumes had locks have no lock ID, and are
+	// left without a lock until the runtime allocates one for them
+	legacyConfig := struct {
+		LockID *uint32 `json:"lockID"`
+	}{}
+	if err := json.Unmarshal(volConfigBytes, &legacyConfig); err != nil {
+		return errors.Wrapf(err, "error unmarshalling volume %s config from DB", string(name))
+	}
+	if legacyConfig.LockID != nil {
+		// Get the lock
+		lock, err := s.runtime.lockManager.RetrieveLock(volume.config.LockID)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving lock for volume %s", string(name))
+		}
+		volume.lock = lock
+	}
+
 	volume.runtime = s.runtime
 	volume.valid = true
 
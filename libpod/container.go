@@ -104,6 +104,19 @@ const (
 	// RestartPolicyOnFailure restarts the container on non-0 exit code,
 	// with an optional maximum number of retries.
 	RestartPolicyOnFailure = "on-failure"
+	// RestartPolicyUnlessStopped unconditionally restarts the container,
+	// like RestartPolicyAlways. After a system restart, it is only started
+	// again if it was running before the restart.
+	RestartPolicyUnlessStopped = "unless-stopped"
+)
+
+const (
+	// DefaultRestartBackoff is the default delay before the first restart
+	// attempted by a container's restart policy.
+	DefaultRestartBackoff = 100 * time.Millisecond
+	// DefaultRestartBackoffMax is the default maximum delay between two
+	// restarts attempted by a container's restart policy.
+	DefaultRestartBackoffMax = time.Minute
 )
 
 // Container is a single OCI container.
@@ -134,13 +147,19 @@ type Container struct {
 	rootlessSlirpSyncR *os.File
 	rootlessSlirpSyncW *os.File
 
-	// A restored container should have the same IP address as before
-	// being checkpointed. If requestedIP is set it will be used instead
-	// of config.StaticIP.
-	requestedIP net.IP
+	// A restored container should have the same IP addresses as before
+	// being checkpointed. If requestedIP or requestedIPv6 are set they
+	// will be used instead of config.StaticIP and config.StaticIPv6.
+	requestedIP   net.IP
+	requestedIPv6 net.IP
 
 	// This is true if a container is restored from a checkpoint.
 	restoreFromCheckpoint bool
+
+	// This is true if the container is started on behalf of the systemd
+	// service this process runs in. Only that container reports to
+	// systemd, not its dependencies or the other containers of its pod.
+	notifySystemd bool
 }
 
 // ContainerState contains the current state of the container
@@ -181,6 +200,12 @@ type ContainerState struct {
 	// namespace for the container, and the network namespace is currently
 	// active
 	NetworkStatus []*cnitypes.Result `json:"networkResults,omitempty"`
+	// Networks are the CNI networks the container is attached to, once
+	// networks were connected or disconnected after it was created. If
+	// nil, the networks of the container's configuration are used.
+	// Unlike the rest of the network state, they are kept across restarts
+	// of the container and the system.
+	Networks []string `json:"networks,omitempty"`
 	// BindMounts contains files that will be bind-mounted into the
 	// container when it is mounted.
 	// These include /etc/hosts and /etc/resolv.conf
@@ -197,6 +222,25 @@ type ContainerState struct {
 	// restart policy. This is NOT incremented by normal container restarts
 	// (only by restart policy).
 	RestartCount uint `json:"restartCount,omitempty"`
+	// RestartBackoffCount is the number of consecutive restarts by the
+	// restart policy after which the container did not stay up for longer
+	// than the maximum restart delay. It is used to compute the delay
+	// before the next restart.
+	RestartBackoffCount uint `json:"restartBackoffCount,omitempty"`
+	// LastRestart is the time of the last restart by the restart policy.
+	LastRestart time.Time `json:"lastRestart,omitempty"`
+	// Restarting indicates that the restart policy is waiting to restart
+	// the container.
+	Restarting bool `json:"restarting,omitempty"`
+	// RestartDeadline is when the restart policy restarts the container,
+	// while Restarting is set. Once it has passed, the container is not
+	// considered restarting anymore, as the process waiting to restart it
+	// may have been killed without resetting Restarting.
+	RestartDeadline time.Time `json:"restartDeadline,omitempty"`
+	// RunningBeforeRefresh indicates whether the container was running or
+	// paused when the system was restarted, as recorded when the state was
+	// refreshed after the restart.
+	RunningBeforeRefresh bool `json:"runningBeforeRefresh,omitempty"`
 
 	// ExtensionStageHooks holds hooks which will be executed by libpod
 	// and not delegated to the OCI runtime.
@@ -252,6 +296,9 @@ type ContainerConfig struct {
 	Mounts []string `json:"mounts,omitempty"`
 	// NamedVolumes lists the named volumes to mount into the container.
 	NamedVolumes []*ContainerNamedVolume `json:"namedVolumes,omitempty"`
+	// OverlayVolumes lists the host directories to mount into the
+	// container through an overlay filesystem.
+	OverlayVolumes []*ContainerOverlayVolume `json:"overlayVolumes,omitempty"`
 
 	// Security Config
 
@@ -296,6 +343,11 @@ type ContainerConfig struct {
 	// This cannot be set unless CreateNetNS is set.
 	// If not set, the container will be dynamically assigned an IP by CNI.
 	StaticIP net.IP `json:"staticIP"`
+	// StaticIPv6 is a static IPv6 address to request for the container.
+	// This cannot be set unless CreateNetNS is set.
+	// If not set, the container will be dynamically assigned an IPv6
+	// address by CNI if the network has an IPv6 range.
+	StaticIPv6 net.IP `json:"staticIPv6,omitempty"`
 	// PortMappings are the ports forwarded to the container's network
 	// namespace
 	// These are not used unless CreateNetNS is true
@@ -322,8 +374,20 @@ type ContainerConfig struct {
 	HostAdd []string `json:"hostsAdd,omitempty"`
 	// Network names (CNI) to add container to. Empty to use default network.
 	Networks []string `json:"networks,omitempty"`
+	// NetworkAliases are names, in addition to the container name, under
+	// which the container can be resolved by other containers on its CNI
+	// networks.
+	// These are not used unless CreateNetNS is true
+	NetworkAliases []string `json:"networkAliases,omitempty"`
 	// Network mode specified for the default network.
 	NetMode namespaces.NetworkMode `json:"networkMode,omitempty"`
+	// NetworkOptions are options for the program setting up the network,
+	// keyed by network mode. Only slirp4netns is presently supported.
+	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// Bandwidth limits the traffic into and out of the container's network
+	// namespace. Rates are in bits per second and bursts in bits.
+	// These are not used unless CreateNetNS is true
+	Bandwidth *ocicni.BandwidthConfig `json:"bandwidth,omitempty"`
 
 	// Image Config
 
@@ -375,6 +439,13 @@ type ContainerConfig struct {
 	// restart the container. Used only if RestartPolicy is set to
 	// "on-failure".
 	RestartRetries uint `json:"restart_retries,omitempty"`
+	// RestartBackoff is the delay before the first restart attempted by
+	// the restart policy. The delay is doubled after each further restart,
+	// up to RestartBackoffMax. If unset, DefaultRestartBackoff is used.
+	RestartBackoff time.Duration `json:"restart_backoff,omitempty"`
+	// RestartBackoffMax is the maximum delay between two restarts attempted
+	// by the restart policy. If unset, DefaultRestartBackoffMax is used.
+	RestartBackoffMax time.Duration `json:"restart_backoff_max,omitempty"`
 	// TODO log options for log drivers
 
 	// PostConfigureNetNS needed when a user namespace is created by an OCI runtime
@@ -388,12 +459,23 @@ type ContainerConfig struct {
 	// ExitCommand is the container's exit command.
 	// This Command will be executed when the container exits
 	ExitCommand []string `json:"exitCommand,omitempty"`
+	// CreateCommand is the full command line the container was created
+	// with, used to recreate the container elsewhere
+	CreateCommand []string `json:"createCommand,omitempty"`
 	// IsInfra is a bool indicating whether this container is an infra container used for
 	// sharing kernel namespaces in a pod
 	IsInfra bool `json:"pause"`
+	// IsInitCtr is a bool indicating whether this container is an init
+	// container of its pod, which is run to completion before the other
+	// containers of the pod are started
+	IsInitCtr bool `json:"isInitCtr,omitempty"`
 
 	// Systemd tells libpod to setup the container in systemd mode
 	Systemd bool `json:"systemd"`
+	// SdNotifyMode determines how the readiness of the container is
+	// reported to systemd, if podman runs under systemd. The empty string
+	// is treated as the default (define.SdNotifyModeConmon)
+	SdNotifyMode string `json:"sdnotifyMode,omitempty"`
 
 	// HealthCheckConfig has the health check command and related timings
 	HealthCheckConfig *manifest.Schema2HealthConfig `json:"healthcheck"`
@@ -411,6 +493,19 @@ type ContainerNamedVolume struct {
 	Options []string `json:"options,omitempty"`
 }
 
+// ContainerOverlayVolume is a host directory mounted into the container as the
+// lower layer of an overlay filesystem. Changes made by the container go to an
+// upper directory of the container, which is discarded when the container is
+// removed, and never reach the host directory.
+type ContainerOverlayVolume struct {
+	// Source is the host directory used as the lower layer
+	Source string `json:"source"`
+	// Dest is the mount's destination
+	Dest string `json:"dest"`
+	// Options are the SELinux relabel options of the source, z or Z
+	Options []string `json:"options,omitempty"`
+}
+
 // Config accessors
 // Unlocked
 
@@ -509,6 +604,20 @@ func (c *Container) StaticDir() string {
 	return c.config.StaticDir
 }
 
+// OverlayVolumes returns the container's overlay volumes.
+func (c *Container) OverlayVolumes() []*ContainerOverlayVolume {
+	volumes := []*ContainerOverlayVolume{}
+	for _, vol := range c.config.OverlayVolumes {
+		newVol := new(ContainerOverlayVolume)
+		newVol.Source = vol.Source
+		newVol.Dest = vol.Dest
+		newVol.Options = vol.Options
+		volumes = append(volumes, newVol)
+	}
+
+	return volumes
+}
+
 // NamedVolumes returns the container's named volumes.
 // The name of each is guaranteed to point to a valid libpod Volume present in
 // the state.
@@ -595,6 +704,29 @@ func (c *Container) NewNetNS() bool {
 	return c.config.CreateNetNS
 }
 
+// Networks returns the CNI networks the container will join if a new network
+// namespace is created, including the networks connected and disconnected
+// after it was created. If empty, the default network is joined.
+// If NewNetNS() is false, this value is unused
+func (c *Container) Networks() ([]string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	networks := c.config.Networks
+	if c.state.Networks != nil {
+		networks = c.state.Networks
+	}
+	ctrNetworks := make([]string, len(networks))
+	copy(ctrNetworks, networks)
+	return ctrNetworks, nil
+}
+
 // PortMappings returns the ports that will be mapped into a container if
 // a new network namespace is created
 // If NewNetNS() is false, this value is unused
@@ -718,6 +850,23 @@ func (c *Container) RestartRetries() uint {
 	return c.config.RestartRetries
 }
 
+// RestartBackoff returns the delay before the first restart attempted by the
+// restart policy, and the maximum delay between two restarts
+func (c *Container) RestartBackoff() (time.Duration, time.Duration) {
+	backoff := c.config.RestartBackoff
+	if backoff == 0 {
+		backoff = DefaultRestartBackoff
+	}
+	backoffMax := c.config.RestartBackoffMax
+	if backoffMax == 0 {
+		backoffMax = DefaultRestartBackoffMax
+	}
+	if backoffMax < backoff {
+		backoffMax = backoff
+	}
+	return backoff, backoffMax
+}
+
 // LogDriver returns the log driver for this container
 func (c *Container) LogDriver() string {
 	return c.config.LogDriver
@@ -1028,6 +1177,51 @@ func (c *Container) StoppedByUser() (bool, error) {
 	return c.state.StoppedByUser, nil
 }
 
+// LastRestart returns the number of restarts by the container's restart policy
+// and the time of the last of them
+func (c *Container) LastRestart() (uint, time.Time, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return 0, time.Time{}, err
+		}
+	}
+
+	return c.state.RestartCount, c.state.LastRestart, nil
+}
+
+// Restarting returns whether the container's restart policy is waiting to
+// restart the container
+func (c *Container) Restarting() (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	return c.isRestarting(), nil
+}
+
+// RunningBeforeRefresh returns whether the container was running or paused
+// before the last system restart
+func (c *Container) RunningBeforeRefresh() (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	return c.state.RunningBeforeRefresh, nil
+}
+
 // Misc Accessors
 // Most will require locking
 
@@ -1123,6 +1317,28 @@ func (c *Container) IsInfra() bool {
 	return c.config.IsInfra
 }
 
+// IsInitCtr returns whether the container is an init container of its pod
+func (c *Container) IsInitCtr() bool {
+	return c.config.IsInitCtr
+}
+
+// SdNotifyMode returns how the readiness of the container is reported to
+// systemd
+func (c *Container) SdNotifyMode() string {
+	if c.config.SdNotifyMode == "" {
+		return define.SdNotifyModeConmon
+	}
+	return c.config.SdNotifyMode
+}
+
+// CreateCommand returns the command line the container was created with, if
+// it was recorded
+func (c *Container) CreateCommand() []string {
+	createCommand := make([]string, 0, len(c.config.CreateCommand))
+	createCommand = append(createCommand, c.config.CreateCommand...)
+	return createCommand
+}
+
 // IsReadOnly returns whether the container is running in read only mode
 func (c *Container) IsReadOnly() bool {
 	return c.config.Spec.Root.Readonly
@@ -80,6 +80,7 @@ func (c *Container) Start(ctx context.Context, recursive bool) (err error) {
 			return err
 		}
 	}
+	c.notifySystemd = true
 	if err := c.prepareToStart(ctx, recursive); err != nil {
 		return err
 	}
@@ -109,6 +110,7 @@ func (c *Container) StartAndAttach(ctx context.Context, streams *AttachStreams,
 		}
 	}
 
+	c.notifySystemd = true
 	if err := c.prepareToStart(ctx, recursive); err != nil {
 		return nil, err
 	}
@@ -151,6 +153,7 @@ func (c *Container) RestartWithTimeout(ctx context.Context, timeout uint) (err e
 		return err
 	}
 
+	c.notifySystemd = true
 	return c.restartWithTimeout(ctx, timeout)
 }
 
@@ -185,6 +188,12 @@ func (c *Container) StopWithTimeout(timeout uint) error {
 
 	if c.state.State == define.ContainerStateStopped ||
 		c.state.State == define.ContainerStateExited {
+		// Cancel a pending restart by the restart policy
+		if c.isRestarting() {
+			c.state.StoppedByUser = true
+			c.state.RestartPolicyMatch = false
+			return c.save()
+		}
 		return define.ErrCtrStopped
 	}
 
@@ -780,6 +789,54 @@ type ContainerCheckpointOptions struct {
 	IgnoreStaticIP bool
 }
 
+// NetworkConnect connects the container to the given CNI network. If the
+// container is running, its network namespace is attached to the network
+// immediately; otherwise the network is set up on the next start.
+func (c *Container) NetworkConnect(name string) error {
+	// The network lock is taken before the container's, in the same order
+	// as when removing a network
+	unlock, err := c.runtime.lockNetworks()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.networkConnect(name)
+}
+
+// NetworkDisconnect disconnects the container from the given CNI network. If
+// the container is running, its network namespace is detached from the
+// network immediately.
+func (c *Container) NetworkDisconnect(name string) error {
+	// The network lock is taken before the container's, in the same order
+	// as when removing a network
+	unlock, err := c.runtime.lockNetworks()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.networkDisconnect(name)
+}
+
 // Checkpoint checkpoints a container
 func (c *Container) Checkpoint(ctx context.Context, options ContainerCheckpointOptions) error {
 	logrus.Debugf("Trying to checkpoint container %s", c.ID())
@@ -2,6 +2,7 @@ package libpod
 
 import (
 	"context"
+	"sort"
 	"strings"
 
 	"github.com/containers/libpod/libpod/define"
@@ -75,6 +76,38 @@ func buildContainerGraph(ctrs []*Container) (*containerGraph, error) {
 	return graph, nil
 }
 
+// Sort the containers of a graph in an order they can be started in, with
+// every container following all of its dependencies.
+// Containers which can be started at the same point are ordered by creation
+// time to keep the order stable.
+func sortContainersByDependencies(graph *containerGraph) []*Container {
+	ctrs := make([]*Container, 0, len(graph.nodes))
+	remainingDeps := make(map[string]int, len(graph.nodes))
+	for id, node := range graph.nodes {
+		remainingDeps[id] = len(node.dependsOn)
+	}
+
+	ready := make([]*containerNode, len(graph.noDepNodes))
+	copy(ready, graph.noDepNodes)
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool {
+			return ready[i].container.CreatedTime().Before(ready[j].container.CreatedTime())
+		})
+		node := ready[0]
+		ready = ready[1:]
+		ctrs = append(ctrs, node.container)
+
+		for _, successor := range node.dependedOn {
+			remainingDeps[successor.id]--
+			if remainingDeps[successor.id] == 0 {
+				ready = append(ready, successor)
+			}
+		}
+	}
+
+	return ctrs
+}
+
 // Detect cycles in a container graph using Tarjan's strongly connected
 // components algorithm
 // Return true if a cycle is found, false otherwise
@@ -2,6 +2,7 @@ package libpod
 
 import (
 	"testing"
+	"time"
 
 	"github.com/containers/libpod/libpod/lock"
 	"github.com/stretchr/testify/assert"
@@ -309,3 +310,35 @@ func TestBuildContainerGraphFourContainersNoneInCycle(t *testing.T) {
 	assert.Equal(t, 2, len(graph.noDepNodes))
 	assert.Equal(t, 2, len(graph.notDependedOnNodes))
 }
+
+func TestSortContainersByDependencies(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	if err != nil {
+		t.Fatalf("Error setting up locks: %v", err)
+	}
+
+	ctr1, err := getTestCtr1(manager)
+	assert.NoError(t, err)
+	ctr2, err := getTestCtr2(manager)
+	assert.NoError(t, err)
+	ctr3, err := getTestCtrN("3", manager)
+	assert.NoError(t, err)
+	ctr4, err := getTestCtrN("4", manager)
+	assert.NoError(t, err)
+	ctr1.config.CreatedTime = ctr4.config.CreatedTime.Add(time.Second)
+	ctr2.config.CreatedTime = ctr4.config.CreatedTime.Add(2 * time.Second)
+	ctr3.config.CreatedTime = ctr4.config.CreatedTime.Add(3 * time.Second)
+	ctr1.config.NetNsCtr = ctr3.config.ID
+	ctr2.config.IPCNsCtr = ctr3.config.ID
+	ctr2.config.UserNsCtr = ctr1.config.ID
+
+	graph, err := buildContainerGraph([]*Container{ctr1, ctr2, ctr3, ctr4})
+	assert.NoError(t, err)
+
+	ctrs := sortContainersByDependencies(graph)
+	ids := make([]string, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		ids = append(ids, ctr.ID())
+	}
+	assert.Equal(t, []string{ctr4.ID(), ctr3.ID(), ctr1.ID(), ctr2.ID()}, ids)
+}
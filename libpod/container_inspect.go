@@ -110,6 +110,7 @@ type InspectContainerData struct {
 	ConmonPidFile   string                      `json:"ConmonPidFile"`
 	Name            string                      `json:"Name"`
 	RestartCount    int32                       `json:"RestartCount"`
+	LastRestart     time.Time                   `json:"LastRestart"`
 	Driver          string                      `json:"Driver"`
 	MountLabel      string                      `json:"MountLabel"`
 	ProcessLabel    string                      `json:"ProcessLabel"`
@@ -174,6 +175,11 @@ type InspectContainerConfig struct {
 	StopSignal uint `json:"StopSignal"`
 	// Configured healthcheck for the container
 	Healthcheck *manifest.Schema2HealthConfig `json:"Healthcheck,omitempty"`
+	// CreateCommand is the command line the container was created with
+	CreateCommand []string `json:"CreateCommand,omitempty"`
+	// SdNotifyMode is how the readiness of the container is reported to
+	// systemd
+	SdNotifyMode string `json:"SdNotifyMode,omitempty"`
 }
 
 // InspectContainerHostConfig holds information used when the container was
@@ -216,6 +222,10 @@ type InspectContainerHostConfig struct {
 	// and represents the container port. A single container port may be
 	// bound to multiple host ports (on different IPs).
 	PortBindings map[string][]InspectHostPort `json:"PortBindings"`
+	// NetworkBandwidth contains the bandwidth limits of the container's
+	// network namespace. It is only set if limits were configured.
+	// This is a Podman extension not present in Docker.
+	NetworkBandwidth *InspectNetworkBandwidth `json:"NetworkBandwidth,omitempty"`
 	// RestartPolicy contains the container's restart policy.
 	RestartPolicy *InspectRestartPolicy `json:"RestartPolicy"`
 	// AutoRemove is whether the container will be automatically removed on
@@ -488,6 +498,12 @@ type InspectRestartPolicy struct {
 	// "on-failure" restart policy is in use. Not used if "on-failure" is
 	// not set.
 	MaximumRetryCount uint `json:"MaximumRetryCount"`
+	// Backoff is the delay before the first restart by the restart policy.
+	// The delay doubles after each further restart.
+	Backoff string `json:"Backoff,omitempty"`
+	// MaximumBackoff is the maximum delay between two restarts by the
+	// restart policy.
+	MaximumBackoff string `json:"MaximumBackoff,omitempty"`
 }
 
 // InspectBlkioWeightDevice holds information about the relative weight
@@ -575,6 +591,22 @@ type InspectHostPort struct {
 	HostPort string `json:"HostPort"`
 }
 
+// InspectNetworkBandwidth contains the bandwidth limits of a container's
+// network namespace. Rates are in bits per second and bursts in bits. A zero
+// rate means the direction is not limited.
+type InspectNetworkBandwidth struct {
+	// IngressRate is the limit of the traffic into the container.
+	IngressRate uint64 `json:"IngressRate"`
+	// IngressBurst is the traffic allowed into the container above the
+	// ingress rate.
+	IngressBurst uint64 `json:"IngressBurst"`
+	// EgressRate is the limit of the traffic out of the container.
+	EgressRate uint64 `json:"EgressRate"`
+	// EgressBurst is the traffic allowed out of the container above the
+	// egress rate.
+	EgressBurst uint64 `json:"EgressBurst"`
+}
+
 // InspectContainerState provides a detailed record of a container's current
 // state. It is returned as part of InspectContainerData.
 // As with InspectContainerData, many portions of this struct are matched to
@@ -585,7 +617,7 @@ type InspectContainerState struct {
 	Status      string             `json:"Status"`
 	Running     bool               `json:"Running"`
 	Paused      bool               `json:"Paused"`
-	Restarting  bool               `json:"Restarting"` // TODO
+	Restarting  bool               `json:"Restarting"`
 	OOMKilled   bool               `json:"OOMKilled"`
 	Dead        bool               `json:"Dead"`
 	Pid         int                `json:"Pid"`
@@ -619,6 +651,27 @@ type InspectNetworkSettings struct {
 	IPPrefixLen            int                  `json:"IPPrefixLen"`
 	IPv6Gateway            string               `json:"IPv6Gateway"`
 	MacAddress             string               `json:"MacAddress"`
+	// Networks contains the CNI networks the container is attached to
+	Networks map[string]*InspectAdditionalNetwork `json:"Networks,omitempty"`
+}
+
+// InspectAdditionalNetwork holds information about a CNI network the container
+// is attached to. Addresses are only populated while the container's network
+// namespace is active.
+type InspectAdditionalNetwork struct {
+	NetworkID           string   `json:"NetworkID"`
+	Aliases             []string `json:"Aliases,omitempty"`
+	Gateway             string   `json:"Gateway"`
+	IPAddress           string   `json:"IPAddress"`
+	IPPrefixLen         int      `json:"IPPrefixLen"`
+	IPv6Gateway         string   `json:"IPv6Gateway"`
+	GlobalIPv6Address   string   `json:"GlobalIPv6Address"`
+	GlobalIPv6PrefixLen int      `json:"GlobalIPv6PrefixLen"`
+	MacAddress          string   `json:"MacAddress"`
+	// SecondaryIPAddresses and SecondaryIPv6Addresses hold the addresses
+	// beyond the first of each family, in CIDR notation
+	SecondaryIPAddresses   []string `json:"SecondaryIPAddresses,omitempty"`
+	SecondaryIPv6Addresses []string `json:"SecondaryIPv6Addresses,omitempty"`
 }
 
 // Inspect a container for low-level information
@@ -704,6 +757,7 @@ func (c *Container) getContainerInspectData(size bool, driverData *driver.Data)
 			Status:     runtimeInfo.State.String(),
 			Running:    runtimeInfo.State == define.ContainerStateRunning,
 			Paused:     runtimeInfo.State == define.ContainerStatePaused,
+			Restarting: c.isRestarting(),
 			OOMKilled:  runtimeInfo.OOMKilled,
 			Dead:       runtimeInfo.State.String() == "bad state",
 			Pid:        runtimeInfo.PID,
@@ -728,6 +782,7 @@ func (c *Container) getContainerInspectData(size bool, driverData *driver.Data)
 		ConmonPidFile:   config.ConmonPidFile,
 		Name:            config.Name,
 		RestartCount:    int32(runtimeInfo.RestartCount),
+		LastRestart:     runtimeInfo.LastRestart,
 		Driver:          driverData.Name,
 		MountLabel:      config.MountLabel,
 		ProcessLabel:    config.ProcessLabel,
@@ -742,13 +797,13 @@ func (c *Container) getContainerInspectData(size bool, driverData *driver.Data)
 			Bridge:                 "",    // TODO
 			SandboxID:              "",    // TODO - is this even relevant?
 			HairpinMode:            false, // TODO
-			LinkLocalIPv6Address:   "",    // TODO - do we even support IPv6?
-			LinkLocalIPv6PrefixLen: 0,     // TODO - do we even support IPv6?
+			LinkLocalIPv6Address:   "",    // TODO
+			LinkLocalIPv6PrefixLen: 0,     // TODO
 
 			Ports:                  []ocicni.PortMapping{}, // TODO - maybe worth it to put this in Docker format?
 			SandboxKey:             "",                     // Network namespace path
-			SecondaryIPAddresses:   nil,                    // TODO - do we support this?
-			SecondaryIPv6Addresses: nil,                    // TODO - do we support this?
+			SecondaryIPAddresses:   nil,                    // Set from network namespace
+			SecondaryIPv6Addresses: nil,                    // Set from network namespace
 			EndpointID:             "",                     // TODO - is this even relevant?
 			Gateway:                "",                     // TODO
 			GlobalIPv6Address:      "",
@@ -835,12 +890,25 @@ func (c *Container) getInspectMounts(ctrSpec *spec.Spec, namedVolumes []*Contain
 			return nil, errors.Wrapf(err, "error looking up volume %s in container %s config", volume.Name, c.ID())
 		}
 		mountStruct.Driver = volFromDB.Driver()
-		mountStruct.Source = volFromDB.MountPoint()
+		mountStruct.Source, err = volFromDB.ResolveMountPoint()
+		if err != nil {
+			return nil, err
+		}
 
 		parseMountOptionsForInspect(volume.Options, &mountStruct)
 
 		inspectMounts = append(inspectMounts, mountStruct)
 	}
+	for _, volume := range c.config.OverlayVolumes {
+		mountStruct := InspectMount{}
+		mountStruct.Type = "bind"
+		mountStruct.Source = volume.Source
+		mountStruct.Destination = volume.Dest
+
+		parseMountOptionsForInspect(append([]string{"O"}, volume.Options...), &mountStruct)
+
+		inspectMounts = append(inspectMounts, mountStruct)
+	}
 	for _, mount := range mounts {
 		// It's a mount.
 		// Is it a tmpfs? If so, discard.
@@ -942,6 +1010,9 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) (*InspectCon
 	// leak.
 	ctrConfig.Healthcheck = c.config.HealthCheckConfig
 
+	ctrConfig.CreateCommand = c.config.CreateCommand
+	ctrConfig.SdNotifyMode = c.SdNotifyMode()
+
 	return ctrConfig, nil
 }
 
@@ -957,6 +1028,11 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	restartPolicy := new(InspectRestartPolicy)
 	restartPolicy.Name = c.config.RestartPolicy
 	restartPolicy.MaximumRetryCount = c.config.RestartRetries
+	if c.config.RestartPolicy != RestartPolicyNone && c.config.RestartPolicy != RestartPolicyNo {
+		backoff, backoffMax := c.RestartBackoff()
+		restartPolicy.Backoff = backoff.String()
+		restartPolicy.MaximumBackoff = backoffMax.String()
+	}
 	hostConfig.RestartPolicy = restartPolicy
 
 	hostConfig.Dns = make([]string, 0, len(c.config.DNSServer))
@@ -1235,6 +1311,15 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	}
 	hostConfig.PortBindings = portBindings
 
+	if c.config.CreateNetNS && c.config.Bandwidth != nil {
+		hostConfig.NetworkBandwidth = &InspectNetworkBandwidth{
+			IngressRate:  c.config.Bandwidth.IngressRate,
+			IngressBurst: c.config.Bandwidth.IngressBurst,
+			EgressRate:   c.config.Bandwidth.EgressRate,
+			EgressBurst:  c.config.Bandwidth.EgressBurst,
+		}
+	}
+
 	// Cap add and cap drop.
 	// We need a default set of capabilities to compare against.
 	// The OCI generate package has one, and is commonly used, so we'll
@@ -310,8 +310,6 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (restarted bool, er
 		}
 	}
 
-	logrus.Debugf("Restarting container %s due to restart policy %s", c.ID(), c.config.RestartPolicy)
-
 	// Need to check if dependencies are alive.
 	if err = c.checkDependenciesAndHandleError(ctx); err != nil {
 		return false, err
@@ -325,10 +323,35 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (restarted bool, er
 		return false, errors.Wrapf(define.ErrInternal, "invalid container state encountered in restart attempt!")
 	}
 
+	// If the container stayed up for long enough, it is not crash
+	// looping, so start over with the initial delay.
+	backoff, backoffMax := c.RestartBackoff()
+	if c.state.FinishedTime.Sub(c.state.StartedTime) > backoffMax {
+		c.state.RestartBackoffCount = 0
+	}
+	delay := restartBackoffDelay(backoff, backoffMax, c.state.RestartBackoffCount)
+
+	logrus.Debugf("Restarting container %s due to restart policy %s in %s", c.ID(), c.config.RestartPolicy, delay)
+
+	c.newContainerEvent(events.Restarting)
+
+	if err := c.waitRestartBackoff(delay); err != nil {
+		return false, err
+	}
+
+	// The container may have been started or stopped while we waited.
+	if c.state.State == define.ContainerStateRunning || c.state.State == define.ContainerStatePaused ||
+		!c.state.RestartPolicyMatch || c.state.StoppedByUser {
+		logrus.Debugf("Restart of container %s cancelled", c.ID())
+		return false, nil
+	}
+
 	c.newContainerEvent(events.Restart)
 
 	// Increment restart count
 	c.state.RestartCount = c.state.RestartCount + 1
+	c.state.RestartBackoffCount = c.state.RestartBackoffCount + 1
+	c.state.LastRestart = time.Now()
 	logrus.Debugf("Container %s now on retry %d", c.ID(), c.state.RestartCount)
 	if err := c.save(); err != nil {
 		return false, err
@@ -363,6 +386,63 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (restarted bool, er
 	return true, nil
 }
 
+// restartBackoffDelay returns how long to wait before a restart by the restart
+// policy, after the given number of consecutive restarts. The delay starts at
+// backoff and doubles with each restart, up to backoffMax.
+func restartBackoffDelay(backoff, backoffMax time.Duration, restarts uint) time.Duration {
+	delay := backoff
+	for i := uint(0); i < restarts && delay < backoffMax; i++ {
+		delay *= 2
+	}
+	if delay > backoffMax {
+		delay = backoffMax
+	}
+	return delay
+}
+
+// restartDeadlineGrace is how long after its restart deadline a container is
+// still considered restarting, giving the process waiting to restart it time
+// to take the container's lock again
+const restartDeadlineGrace = 10 * time.Second
+
+// isRestarting returns whether the restart policy is waiting to restart the
+// container. A container whose restart deadline has passed is not restarting,
+// even if the process that was waiting did not reset the state.
+func (c *Container) isRestarting() bool {
+	return c.state.Restarting && time.Now().Before(c.state.RestartDeadline.Add(restartDeadlineGrace))
+}
+
+// waitRestartBackoff waits for the given delay before a restart by the restart
+// policy. The container is marked as restarting meanwhile, and its lock is
+// released so it can still be inspected, started or stopped. The container is
+// synced again before returning.
+func (c *Container) waitRestartBackoff(delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	c.state.Restarting = true
+	c.state.RestartDeadline = time.Now().Add(delay)
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	if !c.batched {
+		c.lock.Unlock()
+	}
+	time.Sleep(delay)
+	if !c.batched {
+		c.lock.Lock()
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	c.state.Restarting = false
+	c.state.RestartDeadline = time.Time{}
+	return c.save()
+}
+
 // Sync this container with on-disk state and runtime status
 // Should only be called with container lock held
 // This function should suffice to ensure a container's state is accurate and
@@ -552,6 +632,10 @@ func resetState(state *ContainerState) error {
 	state.StoppedByUser = false
 	state.RestartPolicyMatch = false
 	state.RestartCount = 0
+	state.RestartBackoffCount = 0
+	state.LastRestart = time.Time{}
+	state.Restarting = false
+	state.RestartDeadline = time.Time{}
 
 	return nil
 }
@@ -935,9 +1019,12 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	c.state.State = define.ContainerStateCreated
 	c.state.StoppedByUser = false
 	c.state.RestartPolicyMatch = false
+	c.state.Restarting = false
+	c.state.RestartDeadline = time.Time{}
 
 	if !retainRetries {
 		c.state.RestartCount = 0
+		c.state.RestartBackoffCount = 0
 	}
 
 	if err := c.save(); err != nil {
@@ -1071,6 +1158,16 @@ func (c *Container) start() error {
 		logrus.Debugf("Starting container %s with command %v", c.ID(), c.config.Spec.Process.Args)
 	}
 
+	// If NOTIFY_SOCKET is proxied into the container, the OCI runtime
+	// forwards the container's READY=1 while starting it. Conmon has to be
+	// the main PID of the service by then, or systemd considers the service
+	// dead once podman exits.
+	if c.notifySystemd && c.SdNotifyMode() == define.SdNotifyModeContainer {
+		if err := sdNotify(fmt.Sprintf("MAINPID=%d", c.state.ConmonPID)); err != nil {
+			logrus.Errorf("Error notifying systemd of the conmon PID of container %s: %v", c.ID(), err)
+		}
+	}
+
 	if err := c.ociRuntime.startContainer(c); err != nil {
 		return err
 	}
@@ -1078,6 +1175,12 @@ func (c *Container) start() error {
 
 	c.state.State = define.ContainerStateRunning
 
+	if c.notifySystemd && c.SdNotifyMode() == define.SdNotifyModeConmon {
+		if err := sdNotify(fmt.Sprintf("MAINPID=%d\nREADY=1", c.state.ConmonPID)); err != nil {
+			logrus.Errorf("Error notifying systemd that container %s is ready: %v", c.ID(), err)
+		}
+	}
+
 	if c.config.HealthCheckConfig != nil {
 		if err := c.updateHealthStatus(HealthCheckStarting); err != nil {
 			logrus.Error(err)
@@ -1236,9 +1339,62 @@ func (c *Container) mountStorage() (string, error) {
 		}
 	}
 
+	if err := c.mountNamedVolumes(); err != nil {
+		if c.config.Rootfs == "" {
+			if err2 := c.unmount(false); err2 != nil {
+				logrus.Errorf("Error unmounting container %s after failing to mount its volumes: %v", c.ID(), err2)
+			}
+		}
+		return "", err
+	}
+
 	return mountPoint, nil
 }
 
+// mountNamedVolumes mounts the container's named volumes that need mounting:
+// volumes of volume plugins and local volumes with mount options
+func (c *Container) mountNamedVolumes() error {
+	mounted := make([]*Volume, 0, len(c.config.NamedVolumes))
+	for _, namedVol := range c.config.NamedVolumes {
+		volume, err := c.runtime.GetVolume(namedVol.Name)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving volume %s to mount in container %s", namedVol.Name, c.ID())
+		}
+		if !volume.needsMount() {
+			continue
+		}
+		if _, err := volume.mount(c.ID()); err != nil {
+			for _, vol := range mounted {
+				if err2 := vol.unmount(c.ID()); err2 != nil {
+					logrus.Errorf("Error unmounting volume %s of container %s: %v", vol.Name(), c.ID(), err2)
+				}
+			}
+			return err
+		}
+		mounted = append(mounted, volume)
+	}
+	return nil
+}
+
+// unmountNamedVolumes unmounts the container's named volumes that needed
+// mounting. Errors are only logged so that the container can always be
+// cleaned up.
+func (c *Container) unmountNamedVolumes() {
+	for _, namedVol := range c.config.NamedVolumes {
+		volume, err := c.runtime.GetVolume(namedVol.Name)
+		if err != nil {
+			logrus.Errorf("Error retrieving volume %s to unmount from container %s: %v", namedVol.Name, c.ID(), err)
+			continue
+		}
+		if !volume.needsMount() {
+			continue
+		}
+		if err := volume.unmount(c.ID()); err != nil {
+			logrus.Errorf("Error unmounting volume %s from container %s: %v", volume.Name(), c.ID(), err)
+		}
+	}
+}
+
 // cleanupStorage unmounts and cleans up the container's root filesystem
 func (c *Container) cleanupStorage() error {
 	if !c.state.Mounted {
@@ -1253,6 +1409,9 @@ func (c *Container) cleanupStorage() error {
 		}
 	}
 
+	c.unmountNamedVolumes()
+	c.unmountOverlayVolumes()
+
 	if c.config.Rootfs != "" {
 		return nil
 	}
@@ -1289,6 +1448,10 @@ func (c *Container) cleanup(ctx context.Context) error {
 
 	logrus.Debugf("Cleaning up container %s", c.ID())
 
+	// A restart by the restart policy is not pending anymore
+	c.state.Restarting = false
+	c.state.RestartDeadline = time.Time{}
+
 	// Remove healthcheck unit/timer file if it execs
 	if c.config.HealthCheckConfig != nil {
 		if err := c.removeTimer(); err != nil {
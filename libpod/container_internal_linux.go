@@ -7,8 +7,8 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"strconv"
@@ -29,6 +29,9 @@ import (
 	"github.com/containers/libpod/pkg/resolvconf"
 	"github.com/containers/libpod/pkg/rootless"
 	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/idtools"
+	"github.com/containers/storage/pkg/mount"
+	"github.com/coreos/go-systemd/activation"
 	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/opencontainers/runc/libcontainer/user"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -238,7 +241,10 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 		if err != nil {
 			return nil, errors.Wrapf(err, "error retrieving volume %s to add to container %s", namedVol.Name, c.ID())
 		}
-		mountPoint := volume.MountPoint()
+		mountPoint, err := volume.ResolveMountPoint()
+		if err != nil {
+			return nil, err
+		}
 		volMount := spec.Mount{
 			Type:        "bind",
 			Source:      mountPoint,
@@ -248,6 +254,15 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 		g.AddMount(volMount)
 	}
 
+	// Add overlay volumes
+	for i, overlayVol := range c.config.OverlayVolumes {
+		overlayMount, err := c.overlayVolumeMount(i, overlayVol)
+		if err != nil {
+			return nil, err
+		}
+		g.AddMount(overlayMount)
+	}
+
 	// Add bind mounts to container
 	for dstPath, srcPath := range c.state.BindMounts {
 		newMount := spec.Mount{
@@ -360,6 +375,16 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 		g.AddProcessEnv("container", "libpod")
 	}
 
+	// Hand the listening sockets of systemd socket activation on to the
+	// container process, which is PID 1 in the container
+	if listenFiles := c.socketActivationFiles(); len(listenFiles) > 0 {
+		g.AddProcessEnv("LISTEN_FDS", strconv.Itoa(len(listenFiles)))
+		g.AddProcessEnv("LISTEN_PID", "1")
+		if names, ok := os.LookupEnv("LISTEN_FDNAMES"); ok {
+			g.AddProcessEnv("LISTEN_FDNAMES", names)
+		}
+	}
+
 	unified, err := cgroups.IsCgroup2UnifiedMode()
 	if err != nil {
 		return nil, err
@@ -731,6 +756,7 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 	// process to ignore the static IP with '--ignore-static-ip'
 	if options.IgnoreStaticIP {
 		c.config.StaticIP = nil
+		c.config.StaticIPv6 = nil
 	}
 
 	// Read network configuration from checkpoint
@@ -754,17 +780,17 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 		if err := json.Unmarshal(networkJSON, &networkStatus); err != nil {
 			return err
 		}
-		// Take the first IP address
-		var IP net.IP
+		// Take the first IP address of each family and tell CNI
+		// which IP addresses we want
 		if len(networkStatus) > 0 {
-			if len(networkStatus[0].IPs) > 0 {
-				IP = networkStatus[0].IPs[0].Address.IP
+			for _, ip := range networkStatus[0].IPs {
+				if ip.Address.IP.To4() != nil && c.requestedIP == nil {
+					c.requestedIP = ip.Address.IP
+				} else if ip.Address.IP.To4() == nil && c.requestedIPv6 == nil {
+					c.requestedIPv6 = ip.Address.IP
+				}
 			}
 		}
-		if IP != nil {
-			// Tell CNI which IP address we want.
-			c.requestedIP = IP
-		}
 	}
 
 	defer func() {
@@ -1078,8 +1104,26 @@ func (c *Container) generateResolvConf() (string, error) {
 	nameservers := resolvconf.GetNameservers(resolv.Content)
 	// slirp4netns has a built in DNS server.
 	if c.config.NetMode.IsSlirp4netns() {
-		nameservers = append([]string{"10.0.2.3"}, nameservers...)
+		slirpOptions, err := c.slirp4netnsOptions()
+		if err != nil {
+			return "", err
+		}
+		nameservers = append([]string{slirpOptions.dnsIP().String()}, nameservers...)
+	} else if c.usesRootlessCNI() {
+		// The rootless network namespace is connected through
+		// slirp4netns, whose DNS server is reachable through the
+		// gateway of the network
+		slirpOptions, err := parseSlirp4netnsOptions(c.runtime.config.NetworkCmdOptions)
+		if err != nil {
+			return "", err
+		}
+		nameservers = append([]string{slirpOptions.dnsIP().String()}, nameservers...)
 	}
+	// Networks with a DNS server, like those using the dnsname plugin,
+	// report it in their CNI results. Put it first, so containers on the
+	// network can resolve each other by name.
+	networkNameservers, networkSearch := c.networkDNS()
+	nameservers = resolvconf.PrependUnique(networkNameservers, nameservers)
 	if len(c.config.DNSServer) > 0 {
 		// We store DNS servers as net.IP, so need to convert to string
 		nameservers = []string{}
@@ -1088,7 +1132,7 @@ func (c *Container) generateResolvConf() (string, error) {
 		}
 	}
 
-	search := resolvconf.GetSearchDomains(resolv.Content)
+	search := resolvconf.PrependUnique(networkSearch, resolvconf.GetSearchDomains(resolv.Content))
 	if len(c.config.DNSSearch) > 0 {
 		search = c.config.DNSSearch
 	}
@@ -1117,6 +1161,20 @@ func (c *Container) generateResolvConf() (string, error) {
 	return filepath.Join(c.state.RunDir, "resolv.conf"), nil
 }
 
+// networkDNS returns the nameservers and search domains reported by the CNI
+// networks of the container
+func (c *Container) networkDNS() ([]string, []string) {
+	var nameservers, search []string
+	for _, result := range c.state.NetworkStatus {
+		nameservers = append(nameservers, result.DNS.Nameservers...)
+		if result.DNS.Domain != "" {
+			search = append(search, result.DNS.Domain)
+		}
+		search = append(search, result.DNS.Search...)
+	}
+	return nameservers, search
+}
+
 // generateHosts creates a containers hosts file
 func (c *Container) generateHosts(path string) (string, error) {
 	orig, err := ioutil.ReadFile(path)
@@ -1149,7 +1207,13 @@ func (c *Container) getHosts() string {
 	}
 	if c.config.NetMode.IsSlirp4netns() {
 		// When using slirp4netns, the interface gets a static IP
-		hosts += fmt.Sprintf("# used by slirp4netns\n%s\t%s\n", "10.0.2.100", c.Hostname())
+		slirpIP := "10.0.2.100"
+		if slirpOptions, err := c.slirp4netnsOptions(); err == nil {
+			slirpIP = slirpOptions.containerIP().String()
+		} else {
+			logrus.Errorf("error parsing slirp4netns options: %v", err)
+		}
+		hosts += fmt.Sprintf("# used by slirp4netns\n%s\t%s\n", slirpIP, c.Hostname())
 	}
 	if len(c.state.NetworkStatus) > 0 && len(c.state.NetworkStatus[0].IPs) > 0 {
 		ipAddress := strings.Split(c.state.NetworkStatus[0].IPs[0].Address.String(), "/")[0]
@@ -1231,3 +1295,156 @@ func (c *Container) copyOwnerAndPerms(source, dest string) error {
 	}
 	return nil
 }
+
+var (
+	listenFilesOnce sync.Once
+	listenFiles     []*os.File
+)
+
+// socketActivationFiles returns the listening sockets systemd passed to
+// podman through socket activation, which are passed on to the container at
+// the same file descriptors.
+// LISTEN_PID can only refer to the container process if it is PID 1 of its
+// own PID namespace, otherwise no sockets are passed on.
+func (c *Container) socketActivationFiles() []*os.File {
+	// The files are only created once, as each of them closes its file
+	// descriptor when garbage collected
+	listenFilesOnce.Do(func() {
+		listenFiles = activation.Files(false)
+	})
+	if len(listenFiles) == 0 {
+		return nil
+	}
+
+	privatePIDNS := false
+	if c.config.PIDNsCtr == "" && c.config.Spec.Linux != nil {
+		for _, ns := range c.config.Spec.Linux.Namespaces {
+			if ns.Type == spec.PIDNamespace && ns.Path == "" {
+				privatePIDNS = true
+				break
+			}
+		}
+	}
+	if !privatePIDNS {
+		logrus.Warnf("Not passing socket activation file descriptors to container %s, which does not have a private PID namespace", c.ID())
+		return nil
+	}
+	return listenFiles
+}
+
+// overlayVolumeDir returns the directory holding the upper and work
+// directories of the overlay volume at the given index. It is part of the
+// container's storage, so changes made through the volume persist across
+// restarts and are discarded when the container is removed.
+func (c *Container) overlayVolumeDir(index int) string {
+	return filepath.Join(c.config.StaticDir, "overlay-volumes", strconv.Itoa(index))
+}
+
+// overlayMountProgram returns the program rootless containers mount their
+// overlay volumes with: the mount program configured for the storage driver,
+// or fuse-overlayfs if it is installed. If it returns an empty string, the
+// overlay volumes are mounted by the OCI runtime with the kernel's overlay
+// filesystem.
+func (c *Container) overlayMountProgram() string {
+	if !rootless.IsRootless() {
+		return ""
+	}
+	for _, opt := range c.runtime.store.GraphOptions() {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) == 2 && strings.HasSuffix(kv[0], ".mount_program") {
+			return kv[1]
+		}
+	}
+	if path, err := exec.LookPath("fuse-overlayfs"); err == nil {
+		return path
+	}
+	return ""
+}
+
+// overlayVolumeMount prepares the overlay volume at the given index and
+// returns the mount adding it to the container
+func (c *Container) overlayVolumeMount(index int, vol *ContainerOverlayVolume) (spec.Mount, error) {
+	info, err := os.Stat(vol.Source)
+	if err != nil {
+		return spec.Mount{}, errors.Wrapf(err, "error accessing source %s of overlay volume", vol.Source)
+	}
+	if !info.IsDir() {
+		return spec.Mount{}, errors.Wrapf(define.ErrInvalidArg, "source %s of overlay volume is not a directory", vol.Source)
+	}
+
+	contentDir := c.overlayVolumeDir(index)
+	upperDir := filepath.Join(contentDir, "upper")
+	workDir := filepath.Join(contentDir, "work")
+	if err := idtools.MkdirAllAs(contentDir, 0700, c.RootUID(), c.RootGID()); err != nil {
+		return spec.Mount{}, errors.Wrapf(err, "error creating directory of overlay volume %s", vol.Dest)
+	}
+	// The upper directory is the root of the overlay, so it gets the
+	// permissions and the owner of the source directory
+	uid, gid := c.RootUID(), c.RootGID()
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		uid, gid = int(stat.Uid), int(stat.Gid)
+	}
+	if err := idtools.MkdirAllAs(upperDir, info.Mode().Perm(), uid, gid); err != nil {
+		return spec.Mount{}, errors.Wrapf(err, "error creating upper directory of overlay volume %s", vol.Dest)
+	}
+	if err := idtools.MkdirAllAs(workDir, 0700, c.RootUID(), c.RootGID()); err != nil {
+		return spec.Mount{}, errors.Wrapf(err, "error creating work directory of overlay volume %s", vol.Dest)
+	}
+	// The container writes to the upper and work directories, so they get
+	// its private label. The source is only relabelled when asked to, as
+	// with other volumes.
+	if err := label.Relabel(contentDir, c.MountLabel(), false); err != nil {
+		return spec.Mount{}, errors.Wrapf(err, "relabel failed %q", contentDir)
+	}
+	for _, o := range vol.Options {
+		if err := label.Relabel(vol.Source, c.MountLabel(), label.IsShared(o)); err != nil {
+			return spec.Mount{}, errors.Wrapf(err, "relabel failed %q", vol.Source)
+		}
+	}
+	options := []string{"lowerdir=" + vol.Source, "upperdir=" + upperDir, "workdir=" + workDir}
+
+	mountProgram := c.overlayMountProgram()
+	if mountProgram == "" {
+		return spec.Mount{
+			Type:        "overlay",
+			Source:      "overlay",
+			Destination: vol.Dest,
+			Options:     append(options, "private"),
+		}, nil
+	}
+
+	mergeDir := filepath.Join(contentDir, "merge")
+	if err := idtools.MkdirAllAs(mergeDir, 0700, c.RootUID(), c.RootGID()); err != nil {
+		return spec.Mount{}, errors.Wrapf(err, "error creating mountpoint of overlay volume %s", vol.Dest)
+	}
+	mounted, err := mount.Mounted(mergeDir)
+	if err != nil {
+		return spec.Mount{}, errors.Wrapf(err, "error checking whether overlay volume %s is mounted", vol.Dest)
+	}
+	if !mounted {
+		logrus.Debugf("Mounting overlay volume %s of container %s with %s", vol.Dest, c.ID(), mountProgram)
+		if output, err := exec.Command(mountProgram, "-o", strings.Join(options, ","), mergeDir).CombinedOutput(); err != nil {
+			return spec.Mount{}, errors.Wrapf(err, "error mounting overlay volume %s with %s: %s", vol.Dest, mountProgram, strings.TrimSpace(string(output)))
+		}
+	}
+	return spec.Mount{
+		Type:        "bind",
+		Source:      mergeDir,
+		Destination: vol.Dest,
+		Options:     []string{"bind", "private"},
+	}, nil
+}
+
+// unmountOverlayVolumes unmounts the overlay volumes of the container which
+// were mounted by a mount program
+func (c *Container) unmountOverlayVolumes() {
+	for i, vol := range c.config.OverlayVolumes {
+		mergeDir := filepath.Join(c.overlayVolumeDir(i), "merge")
+		if _, err := os.Stat(mergeDir); err != nil {
+			continue
+		}
+		if err := mount.Unmount(mergeDir); err != nil {
+			logrus.Errorf("Error unmounting overlay volume %s of container %s: %v", vol.Dest, c.ID(), err)
+		}
+	}
+}
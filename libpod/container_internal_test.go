@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
@@ -79,3 +80,43 @@ func init() {
 		panic("we need a reliable executable path on Windows")
 	}
 }
+
+func TestRestartBackoffDelay(t *testing.T) {
+	for _, tt := range []struct {
+		restarts uint
+		expected time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{5, 2 * time.Second},
+		{1000, 2 * time.Second},
+	} {
+		assert.Equal(t, tt.expected, restartBackoffDelay(100*time.Millisecond, 2*time.Second, tt.restarts))
+	}
+}
+
+func TestRestartBackoffDefaults(t *testing.T) {
+	ctr := &Container{config: &ContainerConfig{}}
+	backoff, backoffMax := ctr.RestartBackoff()
+	assert.Equal(t, DefaultRestartBackoff, backoff)
+	assert.Equal(t, DefaultRestartBackoffMax, backoffMax)
+
+	ctr.config.RestartBackoff = 2 * time.Minute
+	backoff, backoffMax = ctr.RestartBackoff()
+	assert.Equal(t, 2*time.Minute, backoff)
+	assert.Equal(t, 2*time.Minute, backoffMax)
+}
+
+func TestIsRestartingExpires(t *testing.T) {
+	ctr := &Container{state: &ContainerState{}}
+	assert.False(t, ctr.isRestarting())
+
+	ctr.state.Restarting = true
+	ctr.state.RestartDeadline = time.Now().Add(time.Minute)
+	assert.True(t, ctr.isRestarting())
+
+	// The process waiting to restart the container was killed
+	ctr.state.RestartDeadline = time.Now().Add(-time.Minute)
+	assert.False(t, ctr.isRestarting())
+}
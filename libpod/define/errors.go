@@ -20,6 +20,13 @@ var (
 	// ErrNoSuchVolume indicates the requested volume does not exist
 	ErrNoSuchVolume = errors.New("no such volume")
 
+	// ErrMissingPlugin indicates that the requested volume plugin could
+	// not be found, or is not a volume plugin
+	ErrMissingPlugin = errors.New("missing plugin")
+
+	// ErrNoSuchNetwork indicates the requested CNI network does not exist
+	ErrNoSuchNetwork = errors.New("no such network")
+
 	// ErrCtrExists indicates a container with the same name or ID already
 	// exists
 	ErrCtrExists = errors.New("container already exists")
@@ -29,12 +36,18 @@ var (
 	ErrImageExists = errors.New("image already exists")
 	// ErrVolumeExists indicates a volume with the same name already exists
 	ErrVolumeExists = errors.New("volume already exists")
+	// ErrNetworkExists indicates a CNI network with the same name already
+	// exists
+	ErrNetworkExists = errors.New("network already exists")
 
 	// ErrCtrStateInvalid indicates a container is in an improper state for
 	// the requested operation
 	ErrCtrStateInvalid = errors.New("container state improper")
 	// ErrVolumeBeingUsed indicates that a volume is being used by at least one container
 	ErrVolumeBeingUsed = errors.New("volume is being used")
+	// ErrNetworkBeingUsed indicates that a CNI network is being used by at
+	// least one container
+	ErrNetworkBeingUsed = errors.New("network is being used")
 
 	// ErrRuntimeFinalized indicates that the runtime has already been
 	// created and cannot be modified
@@ -0,0 +1,26 @@
+package define
+
+import "github.com/pkg/errors"
+
+// Strings used for the --sdnotify option of podman, which determine how the
+// readiness of a container is reported to systemd
+const (
+	// SdNotifyModeConmon reports the container as ready as soon as it was
+	// started, with conmon as the main PID
+	SdNotifyModeConmon = "conmon"
+	// SdNotifyModeContainer proxies NOTIFY_SOCKET into the container, so
+	// the application in the container reports its readiness itself
+	SdNotifyModeContainer = "container"
+	// SdNotifyModeIgnore reports nothing to systemd
+	SdNotifyModeIgnore = "ignore"
+)
+
+// ValidateSdNotifyMode validates the specified mode
+func ValidateSdNotifyMode(mode string) error {
+	switch mode {
+	case "", SdNotifyModeConmon, SdNotifyModeContainer, SdNotifyModeIgnore:
+		return nil
+	default:
+		return errors.Wrapf(ErrInvalidArg, "sdnotify mode %q is not one of %s, %s or %s", mode, SdNotifyModeConmon, SdNotifyModeContainer, SdNotifyModeIgnore)
+	}
+}
@@ -142,6 +142,9 @@ const (
 	Renumber Status = "renumber"
 	// Restart indicates the target was restarted via an API call.
 	Restart Status = "restart"
+	// Restarting indicates that a container's restart policy is about to
+	// restart it.
+	Restarting Status = "restarting"
 	// Restore ...
 	Restore Status = "restore"
 	// Save ...
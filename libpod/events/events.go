@@ -129,6 +129,8 @@ func StringToStatus(name string) (Status, error) {
 		return Attach, nil
 	case Checkpoint.String():
 		return Checkpoint, nil
+	case Restarting.String():
+		return Restarting, nil
 	case Restore.String():
 		return Restore, nil
 	case Cleanup.String():
@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -130,8 +131,23 @@ func containersToServicePorts(containers []v1.Container) []v1.ServicePort {
 func (p *Pod) podWithContainers(containers []*Container, ports []v1.ContainerPort) (*v1.Pod, error) {
 	var (
 		podContainers []v1.Container
+		podInitCtrs   []v1.Container
 	)
 	deDupPodVolumes := make(map[string]*v1.Volume)
+	initCtrs, containers := splitInitContainers(containers)
+	for _, ctr := range initCtrs {
+		initCtr, volumes, err := containerToV1Container(ctr)
+		if err != nil {
+			return nil, err
+		}
+		// init containers cannot have port bindings of their own
+		initCtr.Ports = nil
+		podInitCtrs = append(podInitCtrs, initCtr)
+		for _, vol := range volumes {
+			vol := vol
+			deDupPodVolumes[vol.Name] = &vol
+		}
+	}
 	first := true
 	for _, ctr := range containers {
 		if !ctr.IsInfra() {
@@ -165,7 +181,81 @@ func (p *Pod) podWithContainers(containers []*Container, ports []v1.ContainerPor
 		podVolumes = append(podVolumes, *vol)
 	}
 
-	return addContainersAndVolumesToPodObject(podContainers, podVolumes, p.Name()), nil
+	pod := addContainersAndVolumesToPodObject(podContainers, podVolumes, p.Name())
+	pod.Spec.InitContainers = podInitCtrs
+	p.setKubePodSpecNamespaces(&pod.Spec, containers)
+	return pod, nil
+}
+
+// setKubePodSpecNamespaces fills in the pod level namespace, hostname, DNS and
+// hosts settings of a kube pod spec from the namespaces the pod shares and the
+// configuration of its infra container
+func (p *Pod) setKubePodSpecNamespaces(podSpec *v1.PodSpec, containers []*Container) {
+	if p.config.UsePodPID {
+		shareProcessNamespace := true
+		podSpec.ShareProcessNamespace = &shareProcessNamespace
+	} else {
+		podSpec.HostPID = ctrsUseHostNamespace(containers, specs.PIDNamespace)
+	}
+	if !p.config.UsePodIPC {
+		podSpec.HostIPC = ctrsUseHostNamespace(containers, specs.IPCNamespace)
+	}
+	podSpec.Hostname = p.config.Hostname
+
+	infraConfig := p.config.InfraContainer
+	if infraConfig == nil {
+		return
+	}
+	podSpec.HostNetwork = infraConfig.HostNetwork
+	if len(infraConfig.DNSServer) > 0 || len(infraConfig.DNSSearch) > 0 || len(infraConfig.DNSOption) > 0 {
+		dnsConfig := v1.PodDNSConfig{
+			Nameservers: infraConfig.DNSServer,
+			Searches:    infraConfig.DNSSearch,
+		}
+		for _, opt := range infraConfig.DNSOption {
+			splitOpt := strings.SplitN(opt, ":", 2)
+			dnsOption := v1.PodDNSConfigOption{Name: splitOpt[0]}
+			if len(splitOpt) == 2 {
+				dnsOption.Value = &splitOpt[1]
+			}
+			dnsConfig.Options = append(dnsConfig.Options, dnsOption)
+		}
+		podSpec.DNSConfig = &dnsConfig
+	}
+	// group the added hosts by IP, keeping the order they were added in
+	aliasIndex := make(map[string]int)
+	for _, host := range infraConfig.HostAdd {
+		// the host format has already been verified at this point
+		fields := strings.SplitN(host, ":", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		i, ok := aliasIndex[fields[1]]
+		if !ok {
+			i = len(podSpec.HostAliases)
+			aliasIndex[fields[1]] = i
+			podSpec.HostAliases = append(podSpec.HostAliases, v1.HostAlias{IP: fields[1]})
+		}
+		podSpec.HostAliases[i].Hostnames = append(podSpec.HostAliases[i].Hostnames, fields[0])
+	}
+}
+
+// ctrsUseHostNamespace returns whether all non-infra containers in the slice
+// use the host's namespace of the given type
+func ctrsUseHostNamespace(containers []*Container, nsType specs.LinuxNamespaceType) bool {
+	found := false
+	for _, ctr := range containers {
+		if ctr.IsInfra() {
+			continue
+		}
+		found = true
+		for _, ns := range ctr.config.Spec.Linux.Namespaces {
+			if ns.Type == nsType {
+				return false
+			}
+		}
+	}
+	return found
 }
 
 func addContainersAndVolumesToPodObject(containers []v1.Container, volumes []v1.Volume, podName string) *v1.Pod {
@@ -271,8 +361,7 @@ func containerToV1Container(c *Container) (v1.Container, []v1.Volume, error) {
 	// This should not be applicable
 	//container.EnvFromSource =
 	kubeContainer.Env = envVariables
-	// TODO enable resources when we can support naming conventions
-	//container.Resources
+	kubeContainer.Resources = generateKubeResources(c.config.Spec.Linux.Resources)
 	kubeContainer.SecurityContext = kubeSec
 	kubeContainer.StdinOnce = false
 	kubeContainer.TTY = c.config.Spec.Process.Terminal
@@ -498,6 +587,42 @@ func generateKubeSecurityContext(c *Container) (*v1.SecurityContext, error) {
 	return &sc, nil
 }
 
+// generateKubeResources converts the cpu and memory cgroup settings of a
+// container into kube limits and requests
+func generateKubeResources(resources *specs.LinuxResources) v1.ResourceRequirements {
+	kubeResources := v1.ResourceRequirements{}
+	if resources == nil {
+		return kubeResources
+	}
+	limits := make(v1.ResourceList)
+	requests := make(v1.ResourceList)
+	if cpu := resources.CPU; cpu != nil {
+		if cpu.Quota != nil && *cpu.Quota > 0 && cpu.Period != nil && *cpu.Period > 0 {
+			milliCPU := *cpu.Quota * 1000 / int64(*cpu.Period)
+			limits[v1.ResourceCPU] = *resource.NewMilliQuantity(milliCPU, resource.DecimalSI)
+		}
+		if cpu.Shares != nil && *cpu.Shares > 0 {
+			milliCPU := int64(*cpu.Shares) * 1000 / 1024
+			requests[v1.ResourceCPU] = *resource.NewMilliQuantity(milliCPU, resource.DecimalSI)
+		}
+	}
+	if memory := resources.Memory; memory != nil {
+		if memory.Limit != nil && *memory.Limit > 0 {
+			limits[v1.ResourceMemory] = *resource.NewQuantity(*memory.Limit, resource.BinarySI)
+		}
+		if memory.Reservation != nil && *memory.Reservation > 0 {
+			requests[v1.ResourceMemory] = *resource.NewQuantity(*memory.Reservation, resource.BinarySI)
+		}
+	}
+	if len(limits) > 0 {
+		kubeResources.Limits = limits
+	}
+	if len(requests) > 0 {
+		kubeResources.Requests = requests
+	}
+	return kubeResources
+}
+
 // generateKubeVolumeDeviceFromLinuxDevice takes a list of devices and makes a VolumeDevice struct for kube
 func generateKubeVolumeDeviceFromLinuxDevice(devices []specs.LinuxDevice) ([]v1.VolumeDevice, error) {
 	var volumeDevices []v1.VolumeDevice
@@ -0,0 +1,47 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+)
+
+func TestSetKubePodSpecNamespaces(t *testing.T) {
+	pod := &Pod{
+		config: &PodConfig{
+			UsePodPID: true,
+			UsePodIPC: true,
+			Hostname:  "testhost",
+			InfraContainer: &InfraContainerConfig{
+				HostNetwork: true,
+				DNSServer:   []string{"1.1.1.1"},
+				DNSSearch:   []string{"example.com"},
+				DNSOption:   []string{"ndots:2", "rotate"},
+				HostAdd:     []string{"foo:10.0.0.1", "bar:10.0.0.2", "baz:10.0.0.1"},
+			},
+		},
+	}
+
+	podSpec := v1.PodSpec{}
+	pod.setKubePodSpecNamespaces(&podSpec, nil)
+
+	assert.True(t, *podSpec.ShareProcessNamespace)
+	assert.False(t, podSpec.HostPID)
+	assert.False(t, podSpec.HostIPC)
+	assert.True(t, podSpec.HostNetwork)
+	assert.Equal(t, "testhost", podSpec.Hostname)
+
+	assert.Equal(t, []string{"1.1.1.1"}, podSpec.DNSConfig.Nameservers)
+	assert.Equal(t, []string{"example.com"}, podSpec.DNSConfig.Searches)
+	assert.Equal(t, 2, len(podSpec.DNSConfig.Options))
+	assert.Equal(t, "ndots", podSpec.DNSConfig.Options[0].Name)
+	assert.Equal(t, "2", *podSpec.DNSConfig.Options[0].Value)
+	assert.Equal(t, "rotate", podSpec.DNSConfig.Options[1].Name)
+	assert.Nil(t, podSpec.DNSConfig.Options[1].Value)
+
+	assert.Equal(t, []v1.HostAlias{
+		{IP: "10.0.0.1", Hostnames: []string{"foo", "baz"}},
+		{IP: "10.0.0.2", Hostnames: []string{"bar"}},
+	}, podSpec.HostAliases)
+}
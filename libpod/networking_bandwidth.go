@@ -0,0 +1,60 @@
+package libpod
+
+import (
+	"math"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/pkg/errors"
+)
+
+const (
+	// minBandwidthBurst is the smallest default burst, in bits. Token
+	// bucket filters drop packets larger than their burst, so it must hold
+	// a few full-sized packets even at low rates.
+	minBandwidthBurst = 64 * 1024 * 8
+	// minBandwidthRate is the lowest rate, in bits per second
+	minBandwidthRate = 1000
+	// maxBandwidthBurst is the largest burst, in bits, as token bucket
+	// filters count their burst in bytes in 32 bits
+	maxBandwidthBurst = math.MaxUint32 * 8
+)
+
+// validateBandwidth checks the bandwidth limits of a container, and sets the
+// bursts of limited directions that were not given one to the traffic of a
+// tenth of a second
+func validateBandwidth(bandwidth *ocicni.BandwidthConfig) error {
+	if bandwidth.IngressRate == 0 && bandwidth.EgressRate == 0 {
+		return errors.Wrapf(define.ErrInvalidArg, "bandwidth limits require an ingress or egress rate")
+	}
+	var err error
+	if bandwidth.IngressBurst, err = bandwidthBurst("ingress", bandwidth.IngressRate, bandwidth.IngressBurst); err != nil {
+		return err
+	}
+	bandwidth.EgressBurst, err = bandwidthBurst("egress", bandwidth.EgressRate, bandwidth.EgressBurst)
+	return err
+}
+
+// bandwidthBurst validates the burst of a direction limited to the given rate,
+// returning the default burst if none is given
+func bandwidthBurst(direction string, rate, burst uint64) (uint64, error) {
+	if rate == 0 {
+		if burst != 0 {
+			return 0, errors.Wrapf(define.ErrInvalidArg, "cannot set an %s burst without an %s rate", direction, direction)
+		}
+		return 0, nil
+	}
+	if rate < minBandwidthRate {
+		return 0, errors.Wrapf(define.ErrInvalidArg, "%s rate must be at least 1kbit", direction)
+	}
+	if burst == 0 {
+		burst = rate / 10
+		if burst < minBandwidthBurst {
+			burst = minBandwidthBurst
+		}
+	}
+	if burst > maxBandwidthBurst {
+		return 0, errors.Wrapf(define.ErrInvalidArg, "%s burst cannot be more than 4GB", direction)
+	}
+	return burst, nil
+}
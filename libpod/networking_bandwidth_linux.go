@@ -0,0 +1,131 @@
+// +build linux
+
+package libpod
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	// bandwidthLatency is the longest time, in milliseconds, packets may
+	// wait in a token bucket filter before being dropped. This matches the
+	// CNI bandwidth plugin.
+	bandwidthLatency = 25
+	// slirp4netnsIfbName is the interface the ingress traffic of
+	// slirp4netns containers is redirected to, to be limited
+	slirp4netnsIfbName = "ifb0"
+)
+
+// setupSlirp4netnsBandwidth limits the traffic on the tap device slirp4netns
+// created in the network namespace of the container, in the same way the CNI
+// bandwidth plugin limits the traffic of containers on CNI networks. Egress
+// traffic is shaped on the tap device. Ingress traffic cannot be shaped where
+// it is received, so it is redirected to an ifb device and shaped there.
+func setupSlirp4netnsBandwidth(ctr *Container) error {
+	bandwidth := ctr.config.Bandwidth
+	netNS, err := ns.GetNS(fmt.Sprintf("/proc/%d/ns/net", ctr.state.PID))
+	if err != nil {
+		return errors.Wrapf(err, "error opening network namespace of container %s", ctr.ID())
+	}
+	defer netNS.Close()
+
+	err = netNS.Do(func(_ ns.NetNS) error {
+		tap, err := netlink.LinkByName("tap0")
+		if err != nil {
+			return err
+		}
+		if bandwidth.EgressRate > 0 {
+			if err := createTBF(bandwidth.EgressRate, bandwidth.EgressBurst, tap.Attrs().Index); err != nil {
+				return errors.Wrapf(err, "error limiting egress traffic")
+			}
+		}
+		if bandwidth.IngressRate > 0 {
+			if err := createIngressTBF(bandwidth.IngressRate, bandwidth.IngressBurst, tap); err != nil {
+				return errors.Wrapf(err, "error limiting ingress traffic")
+			}
+		}
+		return nil
+	})
+	return errors.Wrapf(err, "error setting up bandwidth limits of container %s", ctr.ID())
+}
+
+// createIngressTBF redirects the traffic received by the given link to a new
+// ifb device, and limits it there
+func createIngressTBF(rate, burst uint64, link netlink.Link) error {
+	err := netlink.LinkAdd(&netlink.Ifb{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:  slirp4netnsIfbName,
+			Flags: net.FlagUp,
+			MTU:   link.Attrs().MTU,
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error creating ifb device %s", slirp4netnsIfbName)
+	}
+	ifb, err := netlink.LinkByName(slirp4netnsIfbName)
+	if err != nil {
+		return err
+	}
+
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+	if err := netlink.QdiscAdd(ingress); err != nil {
+		return errors.Wrapf(err, "error adding ingress qdisc to %s", link.Attrs().Name)
+	}
+
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    ingress.QdiscAttrs.Handle,
+			Priority:  1,
+			Protocol:  syscall.ETH_P_ALL,
+		},
+		ClassId:    netlink.MakeHandle(1, 1),
+		RedirIndex: ifb.Attrs().Index,
+		Actions:    []netlink.Action{netlink.NewMirredAction(ifb.Attrs().Index)},
+	}
+	if err := netlink.FilterAdd(filter); err != nil {
+		return errors.Wrapf(err, "error redirecting the traffic of %s to %s", link.Attrs().Name, slirp4netnsIfbName)
+	}
+
+	return createTBF(rate, burst, ifb.Attrs().Index)
+}
+
+// createTBF adds a token bucket filter limiting the traffic sent on the link
+// of the given index to rate bits per second, allowing bursts of burst bits
+func createTBF(rate, burst uint64, linkIndex int) error {
+	rateInBytes := rate / 8
+	burstInBytes := uint32(burst / 8)
+	buffer := time2Tick(uint32(float64(burstInBytes) * float64(netlink.TIME_UNITS_PER_SEC) / float64(rateInBytes)))
+	latency := float64(netlink.TIME_UNITS_PER_SEC) * bandwidthLatency / 1000
+	limit := uint32(float64(rateInBytes)*latency/float64(netlink.TIME_UNITS_PER_SEC)) + burstInBytes
+
+	qdisc := &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: linkIndex,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Rate:   rateInBytes,
+		Limit:  limit,
+		Buffer: buffer,
+	}
+	return netlink.QdiscAdd(qdisc)
+}
+
+// time2Tick converts a duration in microseconds to kernel packet scheduler
+// ticks
+func time2Tick(time uint32) uint32 {
+	return uint32(float64(time) * float64(netlink.TickInUsec()))
+}
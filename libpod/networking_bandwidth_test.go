@@ -0,0 +1,32 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBandwidthDefaultBurst(t *testing.T) {
+	bandwidth := ocicni.BandwidthConfig{IngressRate: 100000000, EgressRate: 1000000}
+	assert.NoError(t, validateBandwidth(&bandwidth))
+	assert.Equal(t, uint64(10000000), bandwidth.IngressBurst)
+	assert.Equal(t, uint64(minBandwidthBurst), bandwidth.EgressBurst)
+
+	bandwidth = ocicni.BandwidthConfig{EgressRate: 1000000, EgressBurst: 8000}
+	assert.NoError(t, validateBandwidth(&bandwidth))
+	assert.Equal(t, uint64(0), bandwidth.IngressBurst)
+	assert.Equal(t, uint64(8000), bandwidth.EgressBurst)
+}
+
+func TestValidateBandwidthInvalid(t *testing.T) {
+	for _, bandwidth := range []ocicni.BandwidthConfig{
+		{},
+		{IngressBurst: 8000},
+		{IngressRate: 100},
+		{IngressRate: 1000000, EgressBurst: 8000},
+		{EgressRate: 1000000, EgressBurst: maxBandwidthBurst + 1},
+	} {
+		assert.Error(t, validateBandwidth(&bandwidth), "%+v", bandwidth)
+	}
+}
@@ -3,8 +3,11 @@
 package libpod
 
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
 	"os/exec"
@@ -14,12 +17,19 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containernetworking/cni/pkg/types"
 	cnitypes "github.com/containernetworking/cni/pkg/types/current"
 	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/pkg/errorhandling"
 	"github.com/containers/libpod/pkg/firewall"
 	"github.com/containers/libpod/pkg/netns"
+	"github.com/containers/libpod/pkg/network"
 	"github.com/containers/libpod/pkg/rootless"
+	"github.com/containers/libpod/pkg/rootlessport"
+	"github.com/containers/libpod/pkg/util"
+	"github.com/containers/storage/pkg/reexec"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -28,7 +38,7 @@ import (
 )
 
 // Get an OCICNI network config
-func (r *Runtime) getPodNetwork(id, name, nsPath string, networks []string, ports []ocicni.PortMapping, staticIP net.IP) ocicni.PodNetwork {
+func (r *Runtime) getPodNetwork(id, name, nsPath string, networks []string, ports []ocicni.PortMapping, staticIPs []net.IP, bandwidth *ocicni.BandwidthConfig) ocicni.PodNetwork {
 	defaultNetwork := r.netPlugin.GetDefaultNetworkName()
 	network := ocicni.PodNetwork{
 		Name:      name,
@@ -41,61 +51,103 @@ func (r *Runtime) getPodNetwork(id, name, nsPath string, networks []string, port
 		},
 	}
 
-	if staticIP != nil {
-		network.Networks = []string{defaultNetwork}
+	if len(staticIPs) > 0 {
+		// The static IPs are requested from the default network, which
+		// containers are attached to unless they were connected to others
+		if len(network.Networks) == 0 {
+			network.Networks = []string{defaultNetwork}
+		}
+		ips := make([]string, 0, len(staticIPs))
+		for _, ip := range staticIPs {
+			ips = append(ips, ip.String())
+		}
+		// Several addresses are passed as a comma separated list, which
+		// getCNIRuntimeConf splits up again
 		network.RuntimeConfig = map[string]ocicni.RuntimeConfig{
-			defaultNetwork: {IP: staticIP.String(), PortMappings: ports},
+			defaultNetwork: {IP: strings.Join(ips, ","), PortMappings: ports},
+		}
+	}
+
+	if bandwidth != nil {
+		// The limits apply to the traffic on each network
+		if len(network.Networks) == 0 {
+			network.Networks = []string{defaultNetwork}
+		}
+		for _, n := range network.Networks {
+			runtimeConfig := network.RuntimeConfig[n]
+			runtimeConfig.Bandwidth = bandwidth
+			network.RuntimeConfig[n] = runtimeConfig
 		}
 	}
 
 	return network
 }
 
-// Create and configure a new network namespace for a container
-func (r *Runtime) configureNetNS(ctr *Container, ctrNS ns.NetNS) ([]*cnitypes.Result, error) {
-	var requestedIP net.IP
-	if ctr.requestedIP != nil {
-		requestedIP = ctr.requestedIP
-		// cancel request for a specific IP in case the container is reused later
-		ctr.requestedIP = nil
-	} else {
-		requestedIP = ctr.config.StaticIP
+// requestedIPs returns the static addresses to request for the container,
+// at most one of each family. Addresses requested when restoring a
+// container take precedence over the configured ones, and are only used
+// once.
+func (c *Container) requestedIPs() []net.IP {
+	var ips []net.IP
+	if c.requestedIP != nil {
+		ips = append(ips, c.requestedIP)
+	} else if c.config.StaticIP != nil {
+		ips = append(ips, c.config.StaticIP)
+	}
+	if c.requestedIPv6 != nil {
+		ips = append(ips, c.requestedIPv6)
+	} else if c.config.StaticIPv6 != nil {
+		ips = append(ips, c.config.StaticIPv6)
 	}
+	// cancel request for a specific IP in case the container is reused later
+	c.requestedIP = nil
+	c.requestedIPv6 = nil
+	return ips
+}
 
-	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctrNS.Path(), ctr.config.Networks, ctr.config.PortMappings, requestedIP)
+// Create and configure a new network namespace for a container
+func (r *Runtime) configureNetNS(ctr *Container, ctrNS ns.NetNS) (networkStatus []*cnitypes.Result, err error) {
+	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctrNS.Path(), ctr.networkNames(), ctr.config.PortMappings, ctr.requestedIPs(), ctr.config.Bandwidth)
 
-	results, err := r.netPlugin.SetUpPod(podNetwork)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error configuring network namespace for container %s", ctr.ID())
+	if err := ctrNS.Do(func(_ ns.NetNS) error {
+		lo, err := netlink.LinkByName("lo")
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(lo)
+	}); err != nil {
+		return nil, errors.Wrapf(err, "error bringing up loopback interface of container %s", ctr.ID())
 	}
+
+	// Attach each network individually rather than through OCICNI, so
+	// per-network arguments such as DNS aliases can be passed to the
+	// plugins.
+	networks := ctr.networkNames()
+	if len(networks) == 0 {
+		// Rootless users have no default network
+		return nil, errors.Wrapf(define.ErrInvalidArg, "container %s is not attached to any CNI network", ctr.ID())
+	}
+	networkStatus = make([]*cnitypes.Result, 0, len(networks))
 	defer func() {
 		if err != nil {
-			if err2 := r.netPlugin.TearDownPod(podNetwork); err2 != nil {
-				logrus.Errorf("Error tearing down partially created network namespace for container %s: %v", ctr.ID(), err2)
+			for i := len(networkStatus) - 1; i >= 0; i-- {
+				if err2 := r.removeFirewallRules(networkStatus[i]); err2 != nil {
+					logrus.Errorf("Error removing firewall rules for container %s: %v", ctr.ID(), err2)
+				}
+				if err2 := r.detachNetwork(ctr, ctrNS.Path(), networks[i], fmt.Sprintf("eth%d", i), podNetwork.RuntimeConfig[networks[i]]); err2 != nil {
+					logrus.Errorf("Error tearing down partially created network namespace for container %s: %v", ctr.ID(), err2)
+				}
 			}
 		}
 	}()
 
-	networkStatus := make([]*cnitypes.Result, 0)
-	for idx, r := range results {
-		logrus.Debugf("[%d] CNI result: %v", idx, r.String())
-		resultCurrent, err := cnitypes.GetResult(r)
+	for i, name := range networks {
+		result, err := r.attachNetwork(ctr, ctrNS.Path(), name, fmt.Sprintf("eth%d", i), podNetwork.RuntimeConfig[name])
 		if err != nil {
-			return nil, errors.Wrapf(err, "error parsing CNI plugin result %q: %v", r.String(), err)
-		}
-		networkStatus = append(networkStatus, resultCurrent)
-	}
-
-	// Add firewall rules to ensure the container has network access.
-	// Will not be necessary once CNI firewall plugin merges upstream.
-	// https://github.com/containernetworking/plugins/pull/75
-	for _, netStatus := range networkStatus {
-		firewallConf := &firewall.FirewallNetConf{
-			PrevResult: netStatus,
-		}
-		if err := r.firewallBackend.Add(firewallConf); err != nil {
-			return nil, errors.Wrapf(err, "error adding firewall rules for container %s", ctr.ID())
+			return nil, errors.Wrapf(err, "error configuring network namespace for container %s", ctr.ID())
 		}
+		logrus.Debugf("[%d] CNI result: %v", i, result.String())
+		networkStatus = append(networkStatus, result)
 	}
 
 	return networkStatus, nil
@@ -104,7 +156,9 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS ns.NetNS) ([]*cnitypes.Re
 // Create and configure a new network namespace for a container
 func (r *Runtime) createNetNS(ctr *Container) (n ns.NetNS, q []*cnitypes.Result, err error) {
 	if rootless.IsRootless() {
-		return nil, nil, errors.New("cannot configure a new network namespace in rootless mode, only --network=slirp4netns is supported")
+		// The port forwarder of rootless containers on CNI networks is
+		// tied to the container process
+		return nil, nil, errors.New("cannot configure a new network namespace in rootless mode before the container is started")
 	}
 	ctrNS, err := netns.NewNS()
 	if err != nil {
@@ -140,13 +194,100 @@ type slirp4netnsCmd struct {
 	Args    slirp4netnsCmdArg `json:"arguments"`
 }
 
-func checkSlirpFlags(path string) (bool, bool, error) {
+type slirpFeatures struct {
+	HasDisableHostLoopback bool
+	HasMTU                 bool
+	HasCIDR                bool
+	HasIPv6                bool
+	HasOutboundAddr        bool
+	HasNetNSType           bool
+}
+
+func checkSlirpFlags(path string) (*slirpFeatures, error) {
 	cmd := exec.Command(path, "--help")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return false, false, err
+		return nil, err
+	}
+	return &slirpFeatures{
+		HasDisableHostLoopback: strings.Contains(string(out), "--disable-host-loopback"),
+		HasMTU:                 strings.Contains(string(out), "--mtu"),
+		HasCIDR:                strings.Contains(string(out), "--cidr"),
+		HasIPv6:                strings.Contains(string(out), "--enable-ipv6"),
+		HasOutboundAddr:        strings.Contains(string(out), "--outbound-addr"),
+		HasNetNSType:           strings.Contains(string(out), "--netns-type"),
+	}, nil
+}
+
+// slirp4netnsArgs returns the slirp4netns arguments for the given options,
+// checking that the slirp4netns binary supports them
+func slirp4netnsArgs(path string, features *slirpFeatures, slirpOptions *slirp4netnsOptions) ([]string, error) {
+	cmdArgs := []string{}
+	if features.HasDisableHostLoopback && !slirpOptions.allowHostLoopback {
+		cmdArgs = append(cmdArgs, "--disable-host-loopback")
+	}
+	if features.HasMTU {
+		cmdArgs = append(cmdArgs, "--mtu", fmt.Sprintf("%d", slirpOptions.mtu))
+	}
+	if slirpOptions.cidr != nil {
+		if !features.HasCIDR {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "cidr not supported by slirp4netns binary %s", path)
+		}
+		cmdArgs = append(cmdArgs, "--cidr", slirpOptions.cidr.String())
+	}
+	if slirpOptions.enableIPv6 {
+		if !features.HasIPv6 {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "enable_ipv6 not supported by slirp4netns binary %s", path)
+		}
+		cmdArgs = append(cmdArgs, "--enable-ipv6")
 	}
-	return strings.Contains(string(out), "--disable-host-loopback"), strings.Contains(string(out), "--mtu"), nil
+	if slirpOptions.outboundAddr != "" || slirpOptions.outboundAddr6 != "" {
+		if !features.HasOutboundAddr {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "outbound_addr not supported by slirp4netns binary %s", path)
+		}
+		if slirpOptions.outboundAddr != "" {
+			cmdArgs = append(cmdArgs, "--outbound-addr", slirpOptions.outboundAddr)
+		}
+		if slirpOptions.outboundAddr6 != "" {
+			cmdArgs = append(cmdArgs, "--outbound-addr6", slirpOptions.outboundAddr6)
+		}
+	}
+	return cmdArgs, nil
+}
+
+// waitForSlirp4netns waits until the started slirp4netns process reports
+// through syncR that it configured the network namespace
+func waitForSlirp4netns(cmd *exec.Cmd, syncR *os.File) error {
+	b := make([]byte, 16)
+	for {
+		if err := syncR.SetDeadline(time.Now().Add(1 * time.Second)); err != nil {
+			return errors.Wrapf(err, "error setting slirp4netns pipe timeout")
+		}
+		if _, err := syncR.Read(b); err == nil {
+			break
+		} else {
+			if os.IsTimeout(err) {
+				// Check if the process is still running.
+				var status syscall.WaitStatus
+				pid, err := syscall.Wait4(cmd.Process.Pid, &status, syscall.WNOHANG, nil)
+				if err != nil {
+					return errors.Wrapf(err, "failed to read slirp4netns process status")
+				}
+				if pid != cmd.Process.Pid {
+					continue
+				}
+				if status.Exited() {
+					return errors.New("slirp4netns failed")
+				}
+				if status.Signaled() {
+					return errors.New("slirp4netns killed by signal")
+				}
+				continue
+			}
+			return errors.Wrapf(err, "failed to read from slirp4netns sync pipe")
+		}
+	}
+	return nil
 }
 
 // Configure the network namespace for a rootless container
@@ -172,23 +313,30 @@ func (r *Runtime) setupRootlessNetNS(ctr *Container) (err error) {
 	defer errorhandling.CloseQuiet(syncR)
 	defer errorhandling.CloseQuiet(syncW)
 
+	slirpOptions, err := ctr.slirp4netnsOptions()
+	if err != nil {
+		return err
+	}
+
 	havePortMapping := len(ctr.Config().PortMappings) > 0
+	// Ports are forwarded through the slirp4netns API socket unless the
+	// container uses the podman port forwarder
+	useSlirpHostfwd := havePortMapping && slirpOptions.portHandler == slirp4netnsPortHandler
 	apiSocket := filepath.Join(ctr.ociRuntime.tmpDir, fmt.Sprintf("%s.net", ctr.config.ID))
 
 	cmdArgs := []string{}
-	if havePortMapping {
+	if useSlirpHostfwd {
 		cmdArgs = append(cmdArgs, "--api-socket", apiSocket, fmt.Sprintf("%d", ctr.state.PID))
 	}
-	dhp, mtu, err := checkSlirpFlags(path)
+	features, err := checkSlirpFlags(path)
 	if err != nil {
 		return errors.Wrapf(err, "error checking slirp4netns binary %s", path)
 	}
-	if dhp {
-		cmdArgs = append(cmdArgs, "--disable-host-loopback")
-	}
-	if mtu {
-		cmdArgs = append(cmdArgs, "--mtu", "65520")
+	optionArgs, err := slirp4netnsArgs(path, features, slirpOptions)
+	if err != nil {
+		return err
 	}
+	cmdArgs = append(cmdArgs, optionArgs...)
 	cmdArgs = append(cmdArgs, "-c", "-e", "3", "-r", "4", fmt.Sprintf("%d", ctr.state.PID), "tap0")
 
 	cmd := exec.Command(path, cmdArgs...)
@@ -207,37 +355,21 @@ func (r *Runtime) setupRootlessNetNS(ctr *Container) (err error) {
 		}
 	}()
 
-	b := make([]byte, 16)
-	for {
-		if err := syncR.SetDeadline(time.Now().Add(1 * time.Second)); err != nil {
-			return errors.Wrapf(err, "error setting slirp4netns pipe timeout")
-		}
-		if _, err := syncR.Read(b); err == nil {
-			break
-		} else {
-			if os.IsTimeout(err) {
-				// Check if the process is still running.
-				var status syscall.WaitStatus
-				pid, err := syscall.Wait4(cmd.Process.Pid, &status, syscall.WNOHANG, nil)
-				if err != nil {
-					return errors.Wrapf(err, "failed to read slirp4netns process status")
-				}
-				if pid != cmd.Process.Pid {
-					continue
-				}
-				if status.Exited() {
-					return errors.New("slirp4netns failed")
-				}
-				if status.Signaled() {
-					return errors.New("slirp4netns killed by signal")
-				}
-				continue
-			}
-			return errors.Wrapf(err, "failed to read from slirp4netns sync pipe")
+	if err := waitForSlirp4netns(cmd, syncR); err != nil {
+		return err
+	}
+
+	if ctr.config.Bandwidth != nil {
+		if err := setupSlirp4netnsBandwidth(ctr); err != nil {
+			return err
 		}
 	}
 
-	if havePortMapping {
+	if havePortMapping && !useSlirpHostfwd {
+		return r.setupRootlessPortForwarder(ctr, fmt.Sprintf("/proc/%d/ns/net", ctr.state.PID), slirpOptions.containerIP(), slirpOptions.subnet(), slirpOptions.preserveClientAddr)
+	}
+
+	if useSlirpHostfwd {
 		const pidWaitTimeout = 60 * time.Second
 		chWait := make(chan error)
 		go func() {
@@ -281,6 +413,9 @@ func (r *Runtime) setupRootlessNetNS(ctr *Container) (err error) {
 			if hostIP == "" {
 				hostIP = "0.0.0.0"
 			}
+			if ip := net.ParseIP(hostIP); ip != nil && ip.To4() == nil {
+				return errors.Errorf("slirp4netns cannot forward ports from the IPv6 address %s", hostIP)
+			}
 			cmd := slirp4netnsCmd{
 				Execute: "add_hostfwd",
 				Args: slirp4netnsCmdArg{
@@ -321,8 +456,68 @@ func (r *Runtime) setupRootlessNetNS(ctr *Container) (err error) {
 	return nil
 }
 
+// setupRootlessPortForwarder starts the podman port forwarder for a rootless
+// container. The forwarder connects to containerIP from the network namespace
+// at netNSPath, from the address of its clients if preserveClientAddr is set,
+// and exits along with the container.
+func (r *Runtime) setupRootlessPortForwarder(ctr *Container, netNSPath string, containerIP net.IP, subnet *net.IPNet, preserveClientAddr bool) error {
+	cfg := rootlessport.Config{
+		Mappings:           ctr.config.PortMappings,
+		NetNSPath:          netNSPath,
+		ContainerIP:        containerIP.String(),
+		Subnet:             subnet.String(),
+		PreserveClientAddr: preserveClientAddr,
+	}
+	cfgJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal port forwarder configuration")
+	}
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return errors.Wrapf(err, "failed to open pipe")
+	}
+	defer errorhandling.CloseQuiet(readyR)
+	defer errorhandling.CloseQuiet(readyW)
+
+	cmd := reexec.Command(rootlessport.ReexecKey)
+	// Do not kill the forwarder when podman exits
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+	cmd.Stdin = bytes.NewReader(cfgJSON)
+	cmd.ExtraFiles = append(cmd.ExtraFiles, ctr.rootlessSlirpSyncR, readyW)
+	if err := cmd.Start(); err != nil {
+		return errors.Wrapf(err, "failed to start port forwarder process")
+	}
+	defer func() {
+		if err := cmd.Process.Release(); err != nil {
+			logrus.Errorf("unable to release port forwarder process: %q", err)
+		}
+	}()
+	errorhandling.CloseQuiet(readyW)
+
+	// The forwarder closes the pipe once all ports are bound
+	msg, err := ioutil.ReadAll(readyR)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read from port forwarder ready pipe")
+	}
+	if string(msg) != rootlessport.ReadyMessage {
+		if len(msg) == 0 {
+			return errors.New("port forwarder failed")
+		}
+		return errors.Errorf("error from port forwarder: %s", msg)
+	}
+	return nil
+}
+
 // Configure the network namespace using the container process
 func (r *Runtime) setupNetNS(ctr *Container) (err error) {
+	if ctr.usesRootlessCNI() {
+		defer errorhandling.CloseQuiet(ctr.rootlessSlirpSyncR)
+		defer errorhandling.CloseQuiet(ctr.rootlessSlirpSyncW)
+	}
+
 	nsProcess := fmt.Sprintf("/proc/%d/ns/net", ctr.state.PID)
 
 	b := make([]byte, 16)
@@ -331,7 +526,11 @@ func (r *Runtime) setupNetNS(ctr *Container) (err error) {
 		return errors.Wrapf(err, "failed to generate random netns name")
 	}
 
-	nsPath := fmt.Sprintf("/var/run/netns/cni-%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	nsRunDir, err := netns.GetNSRunDir()
+	if err != nil {
+		return err
+	}
+	nsPath := filepath.Join(nsRunDir, fmt.Sprintf("cni-%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]))
 
 	if err := os.MkdirAll(filepath.Dir(nsPath), 0711); err != nil {
 		return errors.Wrapf(err, "cannot create %s", filepath.Dir(nsPath))
@@ -358,7 +557,26 @@ func (r *Runtime) setupNetNS(ctr *Container) (err error) {
 	// Assign NetNS attributes to container
 	ctr.state.NetNS = netNS
 	ctr.state.NetworkStatus = networkStatus
-	return err
+	if err != nil {
+		return err
+	}
+
+	if ctr.usesRootlessCNI() && len(ctr.config.PortMappings) > 0 {
+		// The ports are forwarded from the host into the rootless
+		// network namespace, from which the container is reachable
+		containerIP, subnet := rootlessCNIAddress(networkStatus)
+		if containerIP == nil {
+			return errors.Errorf("cannot forward ports to container %s, it has no IPv4 address", ctr.ID())
+		}
+		rootlessNSPath, err := rootlessNetNSPath()
+		if err != nil {
+			return err
+		}
+		// Client addresses are not preserved, as they would become
+		// unreachable for every container of the shared namespace
+		return r.setupRootlessPortForwarder(ctr, rootlessNSPath, containerIP, subnet, false)
+	}
+	return nil
 }
 
 // Join an existing network namespace
@@ -402,29 +620,32 @@ func (r *Runtime) teardownNetNS(ctr *Container) error {
 	// Will not be necessary once CNI firewall plugin merges upstream.
 	// https://github.com/containernetworking/plugins/pull/75
 	for _, netStatus := range ctr.state.NetworkStatus {
-		firewallConf := &firewall.FirewallNetConf{
-			PrevResult: netStatus,
-		}
-		if err := r.firewallBackend.Del(firewallConf); err != nil {
+		if err := r.removeFirewallRules(netStatus); err != nil {
 			return errors.Wrapf(err, "error removing firewall rules for container %s", ctr.ID())
 		}
 	}
 
 	logrus.Debugf("Tearing down network namespace at %s for container %s", ctr.state.NetNS.Path(), ctr.ID())
 
-	var requestedIP net.IP
-	if ctr.requestedIP != nil {
-		requestedIP = ctr.requestedIP
-		// cancel request for a specific IP in case the container is reused later
-		ctr.requestedIP = nil
-	} else {
-		requestedIP = ctr.config.StaticIP
-	}
-
-	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctr.state.NetNS.Path(), ctr.config.Networks, ctr.config.PortMappings, requestedIP)
+	// Static addresses are not needed to tear the networks down
+	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctr.state.NetNS.Path(), ctr.networkNames(), ctr.config.PortMappings, nil, ctr.config.Bandwidth)
 
-	// The network may have already been torn down, so don't fail here, just log
-	if err := r.netPlugin.TearDownPod(podNetwork); err != nil {
+	networks := ctr.networkNames()
+	if len(networks) == len(ctr.state.NetworkStatus) {
+		// Networks may have been connected and disconnected while the
+		// container ran, so the interfaces are not necessarily numbered
+		// in the order of the networks. Tear each network down using the
+		// interface it was attached with.
+		for i, name := range networks {
+			ifName := sandboxInterfaceName(ctr.state.NetworkStatus[i], fmt.Sprintf("eth%d", i))
+			if err := r.detachNetwork(ctr, ctr.state.NetNS.Path(), name, ifName, podNetwork.RuntimeConfig[name]); err != nil {
+				return errors.Wrapf(err, "error tearing down CNI namespace configuration for container %s", ctr.ID())
+			}
+		}
+	} else if rootless.IsRootless() {
+		// OCICNI cannot reach the rootless network namespace
+		return errors.Errorf("cannot tear down the networks of container %s, its network status does not match its networks", ctr.ID())
+	} else if err := r.netPlugin.TearDownPod(podNetwork); err != nil {
 		return errors.Wrapf(err, "error tearing down CNI namespace configuration for container %s", ctr.ID())
 	}
 
@@ -484,33 +705,384 @@ func getContainerNetIO(ctr *Container) (*netlink.LinkStatistics, error) {
 
 func (c *Container) getContainerNetworkInfo(data *InspectContainerData) *InspectContainerData {
 	if c.state.NetNS != nil && len(c.state.NetworkStatus) > 0 {
+		// Set network namespace path
+		data.NetworkSettings.SandboxKey = c.state.NetNS.Path()
+
 		// Report network settings from the first pod network
 		result := c.state.NetworkStatus[0]
-		// Go through our IP addresses
-		for _, ctrIP := range result.IPs {
-			ipWithMask := ctrIP.Address.String()
-			splitIP := strings.Split(ipWithMask, "/")
-			mask, _ := strconv.Atoi(splitIP[1])
-			if ctrIP.Version == "4" {
-				data.NetworkSettings.IPAddress = splitIP[0]
-				data.NetworkSettings.IPPrefixLen = mask
-				data.NetworkSettings.Gateway = ctrIP.Gateway.String()
-			} else {
-				data.NetworkSettings.GlobalIPv6Address = splitIP[0]
-				data.NetworkSettings.GlobalIPv6PrefixLen = mask
-				data.NetworkSettings.IPv6Gateway = ctrIP.Gateway.String()
+		settings := resultToInspectNetwork(result, data.NetworkSettings.SandboxKey)
+		data.NetworkSettings.IPAddress = settings.IPAddress
+		data.NetworkSettings.IPPrefixLen = settings.IPPrefixLen
+		data.NetworkSettings.Gateway = settings.Gateway
+		data.NetworkSettings.GlobalIPv6Address = settings.GlobalIPv6Address
+		data.NetworkSettings.GlobalIPv6PrefixLen = settings.GlobalIPv6PrefixLen
+		data.NetworkSettings.IPv6Gateway = settings.IPv6Gateway
+		data.NetworkSettings.MacAddress = settings.MacAddress
+		data.NetworkSettings.SecondaryIPAddresses = settings.SecondaryIPAddresses
+		data.NetworkSettings.SecondaryIPv6Addresses = settings.SecondaryIPv6Addresses
+	}
+
+	if c.config.CreateNetNS {
+		networks := c.networkNames()
+		data.NetworkSettings.Networks = make(map[string]*InspectAdditionalNetwork, len(networks))
+		for i, name := range networks {
+			settings := &InspectAdditionalNetwork{}
+			if c.state.NetNS != nil && len(networks) == len(c.state.NetworkStatus) {
+				settings = resultToInspectNetwork(c.state.NetworkStatus[i], c.state.NetNS.Path())
 			}
+			settings.NetworkID = name
+			settings.Aliases = c.config.NetworkAliases
+			data.NetworkSettings.Networks[name] = settings
 		}
+	}
+	return data
+}
 
-		// Set network namespace path
-		data.NetworkSettings.SandboxKey = c.state.NetNS.Path()
+// resultToInspectNetwork converts the result of attaching a container to a CNI
+// network to inspect output
+func resultToInspectNetwork(result *cnitypes.Result, sandboxKey string) *InspectAdditionalNetwork {
+	settings := &InspectAdditionalNetwork{}
+	// Go through our IP addresses. The first address of each family is
+	// the primary one, further ones are reported as secondary addresses.
+	for _, ctrIP := range result.IPs {
+		ipWithMask := ctrIP.Address.String()
+		splitIP := strings.Split(ipWithMask, "/")
+		mask, _ := strconv.Atoi(splitIP[1])
+		gateway := ""
+		if ctrIP.Gateway != nil {
+			gateway = ctrIP.Gateway.String()
+		}
+		if ctrIP.Version == "4" {
+			if settings.IPAddress != "" {
+				settings.SecondaryIPAddresses = append(settings.SecondaryIPAddresses, ipWithMask)
+				continue
+			}
+			settings.IPAddress = splitIP[0]
+			settings.IPPrefixLen = mask
+			settings.Gateway = gateway
+		} else {
+			if settings.GlobalIPv6Address != "" {
+				settings.SecondaryIPv6Addresses = append(settings.SecondaryIPv6Addresses, ipWithMask)
+				continue
+			}
+			settings.GlobalIPv6Address = splitIP[0]
+			settings.GlobalIPv6PrefixLen = mask
+			settings.IPv6Gateway = gateway
+		}
+	}
+
+	// Set MAC address of interface linked with network namespace path
+	for _, i := range result.Interfaces {
+		if i.Sandbox == sandboxKey {
+			settings.MacAddress = i.Mac
+		}
+	}
+	return settings
+}
+
+// networkNames returns the CNI networks the container is attached to, in the
+// order they were set up. Containers not given any networks are attached to
+// the default network.
+func (c *Container) networkNames() []string {
+	networks := c.config.Networks
+	if c.state.Networks != nil {
+		networks = c.state.Networks
+	}
+	if len(networks) > 0 {
+		names := make([]string, len(networks))
+		copy(names, networks)
+		return names
+	}
+	if c.runtime.netPlugin == nil {
+		return nil
+	}
+	return []string{c.runtime.netPlugin.GetDefaultNetworkName()}
+}
+
+// sandboxInterfaceName returns the name of the interface the given CNI result
+// created in the container's network namespace, or def if it has none
+func sandboxInterfaceName(result *cnitypes.Result, def string) string {
+	if result == nil {
+		return def
+	}
+	for _, iface := range result.Interfaces {
+		if iface.Sandbox != "" {
+			return iface.Name
+		}
+	}
+	return def
+}
+
+// Get the CNI runtime configuration for attaching a container to a network
+// as the given interface. This matches the configuration OCICNI uses, so
+// networks set up by OCICNI can be torn down using it.
+func (r *Runtime) getCNIRuntimeConf(ctr *Container, nsPath, name, ifName string, runtimeConfig ocicni.RuntimeConfig) *libcni.RuntimeConf {
+	rt := &libcni.RuntimeConf{
+		ContainerID: ctr.ID(),
+		NetNS:       nsPath,
+		IfName:      ifName,
+		Args: [][2]string{
+			{"IgnoreUnknown", "1"},
+			{"K8S_POD_NAMESPACE", ctr.Name()},
+			{"K8S_POD_NAME", ctr.Name()},
+			{"K8S_POD_INFRA_CONTAINER_ID", ctr.ID()},
+		},
+		CapabilityArgs: map[string]interface{}{},
+		CacheDir:       cniCacheDir(),
+	}
+	if runtimeConfig.IP != "" {
+		// CNI_ARGS can only carry a single address. Further ones, such
+		// as the IPv6 address of a dual-stack container, are requested
+		// through the ips capability.
+		ips := strings.Split(runtimeConfig.IP, ",")
+		rt.Args = append(rt.Args, [2]string{"IP", ips[0]})
+		if len(ips) > 1 {
+			rt.CapabilityArgs["ips"] = ips[1:]
+		}
+	}
+	if len(runtimeConfig.PortMappings) > 0 {
+		rt.CapabilityArgs["portMappings"] = runtimeConfig.PortMappings
+	}
+	if len(ctr.config.NetworkAliases) > 0 {
+		// Used by the dnsname plugin to resolve the aliases on the
+		// network, in addition to the container name
+		rt.CapabilityArgs["aliases"] = map[string][]string{name: ctr.config.NetworkAliases}
+	}
+	if runtimeConfig.Bandwidth != nil {
+		rt.CapabilityArgs["bandwidth"] = map[string]uint64{
+			"ingressRate":  runtimeConfig.Bandwidth.IngressRate,
+			"ingressBurst": runtimeConfig.Bandwidth.IngressBurst,
+			"egressRate":   runtimeConfig.Bandwidth.EgressRate,
+			"egressBurst":  runtimeConfig.Bandwidth.EgressBurst,
+		}
+	}
+	return rt
+}
+
+// getCNIConfList returns the configuration list to pass the given runtime
+// configuration to. Networks are not expected to limit bandwidth themselves,
+// so the bandwidth plugin is added for containers with bandwidth limits.
+func getCNIConfList(n *network.Network, rt *libcni.RuntimeConf) (*libcni.NetworkConfigList, error) {
+	if _, ok := rt.CapabilityArgs["bandwidth"]; !ok {
+		return n.List, nil
+	}
+	return n.WithBandwidthPlugin()
+}
+
+// Attach the network namespace of a container to a single CNI network
+func (r *Runtime) attachNetwork(ctr *Container, nsPath, name, ifName string, runtimeConfig ocicni.RuntimeConfig) (*cnitypes.Result, error) {
+	n, err := network.LoadNetwork(r.config.CNIConfigDir, name)
+	if err != nil {
+		return nil, err
+	}
+	cniConfig := &libcni.CNIConfig{Path: r.config.CNIPluginDir}
+	rt := r.getCNIRuntimeConf(ctr, nsPath, name, ifName, runtimeConfig)
+	if _, ok := rt.CapabilityArgs["ips"]; ok && !n.HasCapability("ips") {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "network %s does not support requesting several static addresses, none of its plugins has the ips capability", name)
+	}
+	list, err := getCNIConfList(n, rt)
+	if err != nil {
+		return nil, err
+	}
+
+	detach := func() {
+		err := r.execCNI(func() error {
+			return cniConfig.DelNetworkList(context.Background(), list, rt)
+		}, true)
+		if err != nil {
+			logrus.Errorf("Error detaching container %s from network %s: %v", ctr.ID(), name, err)
+		}
+	}
+
+	logrus.Debugf("Attaching container %s to network %s as %s", ctr.ID(), name, ifName)
+	var result types.Result
+	err = r.execCNI(func() error {
+		var err error
+		result, err = cniConfig.AddNetworkList(context.Background(), list, rt)
+		return err
+	}, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error attaching container %s to network %s", ctr.ID(), name)
+	}
+	resultCurrent, err := cnitypes.GetResult(result)
+	if err != nil {
+		detach()
+		return nil, errors.Wrapf(err, "error parsing CNI plugin result %q", result.String())
+	}
+
+	// Rootless networks are only reachable from the rootless network
+	// namespace, so they need no firewall rules on the host
+	if rootless.IsRootless() {
+		return resultCurrent, nil
+	}
 
-		// Set MAC address of interface linked with network namespace path
-		for _, i := range result.Interfaces {
-			if i.Sandbox == data.NetworkSettings.SandboxKey {
-				data.NetworkSettings.MacAddress = i.Mac
+	// Add firewall rules to ensure the container has network access.
+	// Will not be necessary once CNI firewall plugin merges upstream.
+	// https://github.com/containernetworking/plugins/pull/75
+	firewallConf := &firewall.FirewallNetConf{
+		PrevResult: resultCurrent,
+	}
+	if err := r.firewallBackend.Add(firewallConf); err != nil {
+		detach()
+		return nil, errors.Wrapf(err, "error adding firewall rules for container %s", ctr.ID())
+	}
+
+	return resultCurrent, nil
+}
+
+// removeFirewallRules removes the firewall rules added when attaching a
+// container to a network
+func (r *Runtime) removeFirewallRules(result *cnitypes.Result) error {
+	if rootless.IsRootless() {
+		return nil
+	}
+	return r.firewallBackend.Del(&firewall.FirewallNetConf{PrevResult: result})
+}
+
+// Detach the network namespace of a container from a single CNI network.
+// Firewall rules are not removed.
+func (r *Runtime) detachNetwork(ctr *Container, nsPath, name, ifName string, runtimeConfig ocicni.RuntimeConfig) error {
+	n, err := network.LoadNetwork(r.config.CNIConfigDir, name)
+	if err != nil {
+		return err
+	}
+	cniConfig := &libcni.CNIConfig{Path: r.config.CNIPluginDir}
+	rt := r.getCNIRuntimeConf(ctr, nsPath, name, ifName, runtimeConfig)
+	list, err := getCNIConfList(n, rt)
+	if err != nil {
+		return err
+	}
+
+	logrus.Debugf("Detaching container %s from network %s (%s)", ctr.ID(), name, ifName)
+	err = r.execCNI(func() error {
+		return cniConfig.DelNetworkList(context.Background(), list, rt)
+	}, true)
+	if err != nil {
+		return errors.Wrapf(err, "error detaching container %s from network %s", ctr.ID(), name)
+	}
+	return nil
+}
+
+// lockNetworks takes the lock of the CNI networks, which keeps networks from
+// being removed while containers are connected to them
+func (r *Runtime) lockNetworks() (func(), error) {
+	return network.LockNetworks(r.config.CNIConfigDir)
+}
+
+// Connect a container to a CNI network. If the container is running, its
+// network namespace is attached to the network immediately.
+// Must be called with the network lock held.
+func (c *Container) networkConnect(name string) error {
+	if !c.config.CreateNetNS {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s does not use a network namespace created by libpod", c.ID())
+	}
+	// The networks of a rootless container are only set up if it does not
+	// use slirp4netns, so connecting it would silently do nothing
+	if rootless.IsRootless() && c.config.NetMode.IsSlirp4netns() {
+		return errors.Wrapf(define.ErrInvalidArg, "rootless container %s uses slirp4netns and cannot be connected to CNI networks", c.ID())
+	}
+	if _, err := network.LoadNetwork(c.runtime.config.CNIConfigDir, name); err != nil {
+		return err
+	}
+	networks := c.networkNames()
+	if util.StringInSlice(name, networks) {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s is already connected to network %s", c.ID(), name)
+	}
+
+	networkStatus := c.state.NetworkStatus
+	var result *cnitypes.Result
+	ifName := ""
+	if c.state.NetNS != nil {
+		// Pick the first interface name not used by another network
+		used := make(map[string]bool)
+		for _, result := range c.state.NetworkStatus {
+			used[sandboxInterfaceName(result, "")] = true
+		}
+		for i := 0; ; i++ {
+			ifName = fmt.Sprintf("eth%d", i)
+			if !used[ifName] {
+				break
+			}
+		}
+
+		var err error
+		result, err = c.runtime.attachNetwork(c, c.state.NetNS.Path(), name, ifName, ocicni.RuntimeConfig{Bandwidth: c.config.Bandwidth})
+		if err != nil {
+			return err
+		}
+		networkStatus = make([]*cnitypes.Result, 0, len(c.state.NetworkStatus)+1)
+		networkStatus = append(networkStatus, c.state.NetworkStatus...)
+		networkStatus = append(networkStatus, result)
+	}
+
+	if err := c.saveNetworks(append(networks, name), networkStatus); err != nil {
+		if result != nil {
+			if err2 := c.runtime.detachNetwork(c, c.state.NetNS.Path(), name, ifName, ocicni.RuntimeConfig{Bandwidth: c.config.Bandwidth}); err2 != nil {
+				logrus.Errorf("Error detaching container %s from network %s: %v", c.ID(), name, err2)
 			}
 		}
+		return err
 	}
-	return data
+	return nil
+}
+
+// Disconnect a container from a CNI network. If the container is running, its
+// network namespace is detached from the network immediately.
+// Must be called with the network lock held.
+func (c *Container) networkDisconnect(name string) error {
+	networks := c.networkNames()
+	index := -1
+	for i, n := range networks {
+		if n == name {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return errors.Wrapf(define.ErrNoSuchNetwork, "container %s is not connected to network %s", c.ID(), name)
+	}
+	if len(networks) == 1 {
+		return errors.Wrapf(define.ErrInvalidArg, "network %s is the only network of container %s and cannot be disconnected", name, c.ID())
+	}
+
+	networkStatus := c.state.NetworkStatus
+	if c.state.NetNS != nil && len(networks) == len(c.state.NetworkStatus) {
+		result := c.state.NetworkStatus[index]
+		if err := c.runtime.removeFirewallRules(result); err != nil {
+			return errors.Wrapf(err, "error removing firewall rules for container %s", c.ID())
+		}
+
+		runtimeConfig := ocicni.RuntimeConfig{Bandwidth: c.config.Bandwidth}
+		if name == c.runtime.netPlugin.GetDefaultNetworkName() {
+			runtimeConfig.PortMappings = c.config.PortMappings
+		}
+		ifName := sandboxInterfaceName(result, fmt.Sprintf("eth%d", index))
+		if err := c.runtime.detachNetwork(c, c.state.NetNS.Path(), name, ifName, runtimeConfig); err != nil {
+			return err
+		}
+		networkStatus = make([]*cnitypes.Result, 0, len(c.state.NetworkStatus)-1)
+		networkStatus = append(networkStatus, c.state.NetworkStatus[:index]...)
+		networkStatus = append(networkStatus, c.state.NetworkStatus[index+1:]...)
+	}
+
+	newNetworks := make([]string, 0, len(networks)-1)
+	newNetworks = append(newNetworks, networks[:index]...)
+	newNetworks = append(newNetworks, networks[index+1:]...)
+	return c.saveNetworks(newNetworks, networkStatus)
+}
+
+// saveNetworks saves the CNI networks the container is attached to, along
+// with the results of attaching its network namespace to them. The networks
+// are kept in the container's state rather than its configuration, which
+// must not change once the container was created. If saving fails, the
+// container's state is left as it was.
+func (c *Container) saveNetworks(networks []string, networkStatus []*cnitypes.Result) error {
+	oldNetworks, oldNetworkStatus := c.state.Networks, c.state.NetworkStatus
+	c.state.Networks = networks
+	c.state.NetworkStatus = networkStatus
+	if err := c.save(); err != nil {
+		c.state.Networks, c.state.NetworkStatus = oldNetworks, oldNetworkStatus
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,22 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkNamesPreferState(t *testing.T) {
+	ctr := &Container{config: &ContainerConfig{}, state: &ContainerState{}, batched: true}
+	ctr.config.Networks = []string{"foo"}
+	assert.Equal(t, []string{"foo"}, ctr.networkNames())
+
+	// Networks connected after the container was created are kept in its
+	// state, not its configuration
+	ctr.state.Networks = []string{"foo", "bar"}
+	assert.Equal(t, []string{"foo", "bar"}, ctr.networkNames())
+	networks, err := ctr.Networks()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, networks)
+	assert.Equal(t, []string{"foo"}, ctr.config.Networks)
+}
@@ -0,0 +1,418 @@
+// +build linux
+
+package libpod
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types/current"
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/libpod/pkg/errorhandling"
+	"github.com/containers/libpod/pkg/netns"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/containers/libpod/pkg/util"
+	"github.com/containers/storage/pkg/lockfile"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// rootlessNetNSName is the name of the network namespace shared by the
+// rootless containers of a user that are attached to CNI networks
+const rootlessNetNSName = "rootless-netns"
+
+// rootlessNetNS is the network namespace in which the CNI networks of a
+// rootless user live. The bridges of the networks are created in it, and a
+// single slirp4netns process connects it to the outside world.
+type rootlessNetNS struct {
+	ns ns.NetNS
+	// dir holds the state of the namespace: the slirp4netns PID file,
+	// the directory bind mounted over /run for the CNI plugins and the
+	// CNI cache
+	dir  string
+	lock lockfile.Locker
+}
+
+// usesRootlessCNI checks whether the container is a rootless container
+// attached to CNI networks. Its ports are forwarded by the podman port
+// forwarder rather than reserved by conmon.
+func (c *Container) usesRootlessCNI() bool {
+	return rootless.IsRootless() && c.config.CreateNetNS && !c.config.NetMode.IsSlirp4netns()
+}
+
+// rootlessNetNSDir returns the directory holding the state of the rootless
+// network namespace
+func rootlessNetNSDir() (string, error) {
+	runtimeDir, err := util.GetRootlessRuntimeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(runtimeDir, rootlessNetNSName), nil
+}
+
+// rootlessNetNSPath returns the path the rootless network namespace is
+// mounted at
+func rootlessNetNSPath() (string, error) {
+	nsRunDir, err := netns.GetNSRunDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(nsRunDir, rootlessNetNSName), nil
+}
+
+// getRootlessNetNS returns the rootless network namespace, locked. If the
+// namespace does not exist, it is created if create is set, and nil is
+// returned otherwise.
+func (r *Runtime) getRootlessNetNS(create bool) (rn *rootlessNetNS, err error) {
+	dir, err := rootlessNetNSDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "error creating %s", dir)
+	}
+	lock, err := lockfile.GetLockfile(filepath.Join(dir, "lock"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error acquiring rootless network namespace lock")
+	}
+	lock.Lock()
+	defer func() {
+		if rn == nil {
+			lock.Unlock()
+		}
+	}()
+
+	nsPath, err := rootlessNetNSPath()
+	if err != nil {
+		return nil, err
+	}
+	rn = &rootlessNetNS{dir: dir, lock: lock}
+	if rn.ns, err = ns.GetNS(nsPath); err == nil {
+		if rn.slirp4netnsRunning() {
+			return rn, nil
+		}
+		// Without slirp4netns the containers on the networks have no
+		// connectivity, so start over with a new namespace
+		logrus.Debugf("slirp4netns of the rootless network namespace exited, recreating the namespace")
+		if err := rn.remove(); err != nil {
+			return nil, err
+		}
+	}
+	if !create {
+		return nil, nil
+	}
+
+	if rn.ns, err = netns.NewNSWithName(rootlessNetNSName); err != nil {
+		return nil, errors.Wrapf(err, "error creating rootless network namespace")
+	}
+	defer func() {
+		if err != nil {
+			if err2 := rn.remove(); err2 != nil {
+				logrus.Errorf("Error removing partially created rootless network namespace: %v", err2)
+			}
+			rn = nil
+		}
+	}()
+	err = rn.ns.Do(func(_ ns.NetNS) error {
+		lo, err := netlink.LinkByName("lo")
+		if err != nil {
+			return err
+		}
+		if err := netlink.LinkSetUp(lo); err != nil {
+			return err
+		}
+		// The namespace routes between the bridges of the networks
+		// and slirp4netns
+		return ioutil.WriteFile("/proc/sys/net/ipv4/ip_forward", []byte("1"), 0644)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error configuring rootless network namespace")
+	}
+	if err := r.startRootlessNetNSSlirp4netns(rn); err != nil {
+		return nil, err
+	}
+	logrus.Debugf("Created rootless network namespace at %s", rn.ns.Path())
+	return rn, nil
+}
+
+// startRootlessNetNSSlirp4netns starts the slirp4netns process providing
+// connectivity to the rootless network namespace. The slirp4netns options
+// of the runtime configuration apply to it.
+func (r *Runtime) startRootlessNetNSSlirp4netns(rn *rootlessNetNS) error {
+	path := r.config.NetworkCmdPath
+	if path == "" {
+		var err error
+		path, err = exec.LookPath("slirp4netns")
+		if err != nil {
+			return errors.Wrapf(err, "could not find slirp4netns, required for rootless CNI networks")
+		}
+	}
+	features, err := checkSlirpFlags(path)
+	if err != nil {
+		return errors.Wrapf(err, "error checking slirp4netns binary %s", path)
+	}
+	if !features.HasNetNSType {
+		return errors.Errorf("slirp4netns binary %s does not support --netns-type, required for rootless CNI networks", path)
+	}
+	slirpOptions, err := parseSlirp4netnsOptions(r.config.NetworkCmdOptions)
+	if err != nil {
+		return err
+	}
+	cmdArgs, err := slirp4netnsArgs(path, features, slirpOptions)
+	if err != nil {
+		return err
+	}
+	cmdArgs = append(cmdArgs, "--netns-type=path", "-c", "-r", "3", rn.ns.Path(), "tap0")
+
+	syncR, syncW, err := os.Pipe()
+	if err != nil {
+		return errors.Wrapf(err, "failed to open pipe")
+	}
+	defer errorhandling.CloseQuiet(syncR)
+	defer errorhandling.CloseQuiet(syncW)
+
+	cmd := exec.Command(path, cmdArgs...)
+	// slirp4netns outlives podman, until the last container leaves the
+	// namespace
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+	cmd.ExtraFiles = append(cmd.ExtraFiles, syncW)
+	if err := cmd.Start(); err != nil {
+		return errors.Wrapf(err, "failed to start slirp4netns process")
+	}
+	defer func() {
+		if err := cmd.Process.Release(); err != nil {
+			logrus.Errorf("unable to release slirp4netns process: %q", err)
+		}
+	}()
+	if err := waitForSlirp4netns(cmd, syncR); err != nil {
+		if err2 := cmd.Process.Kill(); err2 != nil {
+			logrus.Debugf("Error killing slirp4netns: %v", err2)
+		}
+		return err
+	}
+
+	pidFile := filepath.Join(rn.dir, "slirp4netns.pid")
+	if err := ioutil.WriteFile(pidFile, []byte(strconv.Itoa(cmd.Process.Pid)), 0600); err != nil {
+		if err2 := cmd.Process.Kill(); err2 != nil {
+			logrus.Debugf("Error killing slirp4netns: %v", err2)
+		}
+		return errors.Wrapf(err, "error writing %s", pidFile)
+	}
+	return nil
+}
+
+// slirp4netnsPid returns the PID of the slirp4netns process of the namespace,
+// or 0 if it is unknown or has exited. The PID may have been reused by another
+// process since, so it is only returned if the process was started for the
+// path of the namespace.
+func (rn *rootlessNetNS) slirp4netnsPid() int {
+	data, err := ioutil.ReadFile(filepath.Join(rn.dir, "slirp4netns.pid"))
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0
+	}
+	if !processHasArg(pid, rn.ns.Path()) {
+		return 0
+	}
+	return pid
+}
+
+// processHasArg checks whether the process with the given PID was started
+// with the given argument
+func processHasArg(pid int, arg string) bool {
+	cmdline, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err != nil {
+		return false
+	}
+	for _, a := range strings.Split(string(cmdline), "\x00") {
+		if a == arg {
+			return true
+		}
+	}
+	return false
+}
+
+// slirp4netnsRunning checks whether the slirp4netns process of the namespace
+// is still alive
+func (rn *rootlessNetNS) slirp4netnsRunning() bool {
+	return rn.slirp4netnsPid() > 0
+}
+
+// remove stops slirp4netns and removes the namespace
+func (rn *rootlessNetNS) remove() error {
+	if pid := rn.slirp4netnsPid(); pid > 0 {
+		if err := unix.Kill(pid, unix.SIGTERM); err != nil && err != unix.ESRCH {
+			logrus.Errorf("Error stopping slirp4netns of the rootless network namespace: %v", err)
+		}
+	}
+	if err := os.Remove(filepath.Join(rn.dir, "slirp4netns.pid")); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := netns.UnmountNS(rn.ns); err != nil {
+		return errors.Wrapf(err, "error unmounting rootless network namespace")
+	}
+	if err := rn.ns.Close(); err != nil {
+		return errors.Wrapf(err, "error closing rootless network namespace")
+	}
+	return nil
+}
+
+// removeIfUnused removes the namespace once no container is attached to any
+// of its networks anymore
+func (rn *rootlessNetNS) removeIfUnused() error {
+	inUse := false
+	err := rn.ns.Do(func(_ ns.NetNS) error {
+		links, err := netlink.LinkList()
+		if err != nil {
+			return err
+		}
+		for _, link := range links {
+			// Each attached container has a veth pair with one
+			// end in the namespace
+			if link.Type() == "veth" {
+				inUse = true
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error listing interfaces of rootless network namespace")
+	}
+	if inUse {
+		return nil
+	}
+	logrus.Debugf("Removing unused rootless network namespace")
+	return rn.remove()
+}
+
+// unlock releases the lock of the namespace
+func (rn *rootlessNetNS) unlock() {
+	rn.lock.Unlock()
+}
+
+// do runs fn inside the namespace, on a thread with a private mount
+// namespace. The CNI plugins keep their state in /run, which is not writable
+// by rootless users, so a directory of the user is mounted over it. The
+// runtime directory of the user, holding the network namespaces of the
+// containers, stays available at its usual path.
+func (rn *rootlessNetNS) do(fn func() error) error {
+	runtimeDir, err := util.GetRootlessRuntimeDir()
+	if err != nil {
+		return err
+	}
+	runDir := filepath.Join(rn.dir, "run")
+	if err := os.MkdirAll(runDir, 0700); err != nil {
+		return errors.Wrapf(err, "error creating %s", runDir)
+	}
+
+	errCh := make(chan error)
+	go func() {
+		// The thread is never unlocked, so it exits along with the
+		// goroutine rather than being reused with the changed mount
+		// namespace
+		runtime.LockOSThread()
+		errCh <- func() error {
+			// Keep a reference to the runtime directory, which may
+			// be below /run
+			fd, err := unix.Open(runtimeDir, unix.O_PATH|unix.O_CLOEXEC, 0)
+			if err != nil {
+				return errors.Wrapf(err, "error opening %s", runtimeDir)
+			}
+			defer unix.Close(fd)
+
+			if err := unix.Unshare(unix.CLONE_NEWNS); err != nil {
+				return errors.Wrapf(err, "error creating mount namespace")
+			}
+			if err := unix.Mount("", "/", "", unix.MS_SLAVE|unix.MS_REC, ""); err != nil {
+				return errors.Wrapf(err, "error making / a slave mount")
+			}
+			if err := unix.Mount(runDir, "/run", "", unix.MS_BIND, ""); err != nil {
+				return errors.Wrapf(err, "error mounting %s on /run", runDir)
+			}
+			if strings.HasPrefix(runtimeDir, "/run/") {
+				if err := os.MkdirAll(runtimeDir, 0700); err != nil {
+					return errors.Wrapf(err, "error creating %s", runtimeDir)
+				}
+				if err := unix.Mount(fmt.Sprintf("/proc/self/fd/%d", fd), runtimeDir, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+					return errors.Wrapf(err, "error mounting %s", runtimeDir)
+				}
+			}
+			if err := rn.ns.Set(); err != nil {
+				return errors.Wrapf(err, "error joining rootless network namespace")
+			}
+			return fn()
+		}()
+	}()
+	return <-errCh
+}
+
+// execCNI runs fn, which invokes CNI plugins, in the network namespace the
+// CNI networks live in. For rootless users this is the rootless network
+// namespace, which is created if it does not exist yet. If teardown is set,
+// the rootless network namespace is not created, and is removed once no
+// container uses it anymore.
+func (r *Runtime) execCNI(fn func() error, teardown bool) error {
+	if !rootless.IsRootless() {
+		return fn()
+	}
+	rn, err := r.getRootlessNetNS(!teardown)
+	if err != nil {
+		return err
+	}
+	if rn == nil {
+		// Without the namespace there is nothing left to tear down
+		return nil
+	}
+	defer rn.unlock()
+	if err := rn.do(fn); err != nil {
+		return err
+	}
+	if teardown {
+		return rn.removeIfUnused()
+	}
+	return nil
+}
+
+// rootlessCNIAddress returns the first IPv4 address of a container on CNI
+// networks and the subnet it belongs to, the address ports are forwarded to
+func rootlessCNIAddress(networkStatus []*cnitypes.Result) (net.IP, *net.IPNet) {
+	for _, result := range networkStatus {
+		for _, ip := range result.IPs {
+			if ip.Address.IP.To4() != nil {
+				subnet := &net.IPNet{IP: ip.Address.IP.Mask(ip.Address.Mask), Mask: ip.Address.Mask}
+				return ip.Address.IP, subnet
+			}
+		}
+	}
+	return nil, nil
+}
+
+// cniCacheDir returns the directory CNI caches the results of plugins in,
+// or "" for the default
+func cniCacheDir() string {
+	if !rootless.IsRootless() {
+		return ""
+	}
+	dir, err := rootlessNetNSDir()
+	if err != nil {
+		logrus.Debugf("Error looking up rootless network namespace directory: %v", err)
+		return ""
+	}
+	return filepath.Join(dir, "cache")
+}
@@ -0,0 +1,29 @@
+package libpod
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessHasArg(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "sleep 60", "/run/user/1000/netns/rootless-netns")
+	require.NoError(t, cmd.Start())
+	defer func() {
+		assert.NoError(t, cmd.Process.Kill())
+		_ = cmd.Wait()
+	}()
+
+	assert.True(t, processHasArg(cmd.Process.Pid, "/run/user/1000/netns/rootless-netns"))
+	assert.False(t, processHasArg(cmd.Process.Pid, "/run/user/1000/netns/other"))
+	assert.False(t, processHasArg(cmd.Process.Pid, "/run/user/1000/netns"))
+}
+
+func TestProcessHasArgExited(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "true", "/run/user/1000/netns/rootless-netns")
+	require.NoError(t, cmd.Run())
+	// The PID is not running the process anymore
+	assert.False(t, processHasArg(cmd.Process.Pid, "/run/user/1000/netns/rootless-netns"))
+}
@@ -0,0 +1,138 @@
+package libpod
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultSlirp4netnsCIDR is the subnet slirp4netns uses when no cidr
+	// option is given
+	defaultSlirp4netnsCIDR = "10.0.2.0/24"
+
+	// slirp4netnsPortHandler forwards ports through the API socket of
+	// slirp4netns
+	slirp4netnsPortHandler = "slirp4netns"
+	// rootlessportPortHandler forwards ports with a port forwarder owned by
+	// podman, which can preserve the source address of connections
+	rootlessportPortHandler = "rootlessport"
+)
+
+// slirp4netnsOptions are the per-container options for slirp4netns, given as
+// --network slirp4netns:opt1=val1,opt2=val2 or network_cmd_options in
+// libpod.conf
+type slirp4netnsOptions struct {
+	allowHostLoopback bool
+	cidr              *net.IPNet
+	enableIPv6        bool
+	mtu               int
+	outboundAddr      string
+	outboundAddr6     string
+	portHandler       string
+	// preserveClientAddr makes the port forwarder connect to the
+	// container from the address of its clients
+	preserveClientAddr bool
+}
+
+// parseSlirp4netnsOptions parses a list of key=value slirp4netns options.
+// An option given more than once takes the last value.
+func parseSlirp4netnsOptions(options []string) (*slirp4netnsOptions, error) {
+	opts := &slirp4netnsOptions{
+		mtu:         65520,
+		portHandler: slirp4netnsPortHandler,
+	}
+	for _, o := range options {
+		parts := strings.SplitN(o, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "slirp4netns option %q must be in the form key=value", o)
+		}
+		value := parts[1]
+		switch parts[0] {
+		case "allow_host_loopback", "enable_ipv6", "preserve_client_addr":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errors.Wrapf(define.ErrInvalidArg, "invalid value %q for slirp4netns option %s", value, parts[0])
+			}
+			switch parts[0] {
+			case "allow_host_loopback":
+				opts.allowHostLoopback = b
+			case "enable_ipv6":
+				opts.enableIPv6 = b
+			default:
+				opts.preserveClientAddr = b
+			}
+		case "cidr":
+			ip, subnet, err := net.ParseCIDR(value)
+			if err != nil || ip.To4() == nil {
+				return nil, errors.Wrapf(define.ErrInvalidArg, "invalid IPv4 subnet %q for slirp4netns option cidr", value)
+			}
+			if ones, _ := subnet.Mask.Size(); ones > 25 {
+				return nil, errors.Wrapf(define.ErrInvalidArg, "slirp4netns subnet %s is too small, the prefix length must be at most 25", value)
+			}
+			opts.cidr = subnet
+		case "mtu":
+			mtu, err := strconv.Atoi(value)
+			if err != nil || mtu < 68 {
+				return nil, errors.Wrapf(define.ErrInvalidArg, "invalid value %q for slirp4netns option mtu", value)
+			}
+			opts.mtu = mtu
+		case "outbound_addr":
+			opts.outboundAddr = value
+		case "outbound_addr6":
+			opts.outboundAddr6 = value
+		case "port_handler":
+			if value != slirp4netnsPortHandler && value != rootlessportPortHandler {
+				return nil, errors.Wrapf(define.ErrInvalidArg, "unknown port handler %q, must be %s or %s", value, slirp4netnsPortHandler, rootlessportPortHandler)
+			}
+			opts.portHandler = value
+		default:
+			return nil, errors.Wrapf(define.ErrInvalidArg, "unknown slirp4netns option %q", parts[0])
+		}
+	}
+	if opts.preserveClientAddr && opts.portHandler != rootlessportPortHandler {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "slirp4netns option preserve_client_addr requires port_handler=%s", rootlessportPortHandler)
+	}
+	return opts, nil
+}
+
+// subnet returns the slirp4netns subnet
+func (o *slirp4netnsOptions) subnet() *net.IPNet {
+	if o.cidr != nil {
+		return o.cidr
+	}
+	_, subnet, _ := net.ParseCIDR(defaultSlirp4netnsCIDR)
+	return subnet
+}
+
+// subnetIP returns the n-th address of the slirp4netns subnet
+func (o *slirp4netnsOptions) subnetIP(n byte) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	copy(ip, o.subnet().IP.To4())
+	ip[3] += n
+	return ip
+}
+
+// containerIP returns the address slirp4netns gives to the container
+func (o *slirp4netnsOptions) containerIP() net.IP {
+	return o.subnetIP(100)
+}
+
+// dnsIP returns the address of the slirp4netns built-in DNS forwarder
+func (o *slirp4netnsOptions) dnsIP() net.IP {
+	return o.subnetIP(3)
+}
+
+// slirp4netnsOptions returns the slirp4netns options of the container. The
+// options given to the container are merged over the defaults in the runtime
+// configuration, so each of them only overrides the default of the same name.
+func (c *Container) slirp4netnsOptions() (*slirp4netnsOptions, error) {
+	ctrOptions := c.config.NetworkOptions["slirp4netns"]
+	options := make([]string, 0, len(c.runtime.config.NetworkCmdOptions)+len(ctrOptions))
+	options = append(options, c.runtime.config.NetworkCmdOptions...)
+	options = append(options, ctrOptions...)
+	return parseSlirp4netnsOptions(options)
+}
@@ -0,0 +1,71 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSlirp4netnsOptionsDefaults(t *testing.T) {
+	opts, err := parseSlirp4netnsOptions(nil)
+	assert.NoError(t, err)
+	assert.False(t, opts.allowHostLoopback)
+	assert.False(t, opts.enableIPv6)
+	assert.Equal(t, 65520, opts.mtu)
+	assert.Nil(t, opts.cidr)
+	assert.Equal(t, slirp4netnsPortHandler, opts.portHandler)
+	assert.False(t, opts.preserveClientAddr)
+	assert.Equal(t, "10.0.2.100", opts.containerIP().String())
+	assert.Equal(t, "10.0.2.3", opts.dnsIP().String())
+}
+
+func TestParseSlirp4netnsOptions(t *testing.T) {
+	opts, err := parseSlirp4netnsOptions([]string{"cidr=10.5.0.0/24", "mtu=1500", "enable_ipv6=true", "allow_host_loopback=true", "outbound_addr=eth0", "port_handler=rootlessport", "preserve_client_addr=true"})
+	assert.NoError(t, err)
+	assert.True(t, opts.allowHostLoopback)
+	assert.True(t, opts.enableIPv6)
+	assert.Equal(t, 1500, opts.mtu)
+	assert.Equal(t, "10.5.0.0/24", opts.cidr.String())
+	assert.Equal(t, "eth0", opts.outboundAddr)
+	assert.Equal(t, rootlessportPortHandler, opts.portHandler)
+	assert.True(t, opts.preserveClientAddr)
+	assert.Equal(t, "10.5.0.100", opts.containerIP().String())
+	assert.Equal(t, "10.5.0.3", opts.dnsIP().String())
+}
+
+func TestParseSlirp4netnsOptionsInvalid(t *testing.T) {
+	for _, options := range [][]string{
+		{"bogus=1"},
+		{"mtu"},
+		{"mtu=abc"},
+		{"enable_ipv6=maybe"},
+		{"cidr=10.5.0.0"},
+		{"cidr=fd00::/64"},
+		{"cidr=10.5.0.0/28"},
+		{"port_handler=bogus"},
+		{"preserve_client_addr=true"},
+		{"preserve_client_addr=true", "port_handler=slirp4netns"},
+	} {
+		_, err := parseSlirp4netnsOptions(options)
+		assert.Error(t, err, "options %v", options)
+	}
+}
+
+func TestSlirp4netnsOptionsMergeDefaults(t *testing.T) {
+	ctr := &Container{
+		config:  &ContainerConfig{},
+		runtime: &Runtime{config: &RuntimeConfig{NetworkCmdOptions: []string{"enable_ipv6=true", "mtu=1500"}}},
+	}
+	opts, err := ctr.slirp4netnsOptions()
+	assert.NoError(t, err)
+	assert.True(t, opts.enableIPv6)
+	assert.Equal(t, 1500, opts.mtu)
+
+	// The options of the container only override the defaults of the same
+	// name
+	ctr.config.NetworkOptions = map[string][]string{"slirp4netns": {"mtu=9000"}}
+	opts, err = ctr.slirp4netnsOptions()
+	assert.NoError(t, err)
+	assert.True(t, opts.enableIPv6)
+	assert.Equal(t, 9000, opts.mtu)
+}
@@ -23,3 +23,19 @@ func (r *Runtime) createNetNS(ctr *Container) (err error) {
 func (c *Container) getContainerNetworkInfo(data *InspectContainerData) *InspectContainerData {
 	return nil
 }
+
+func (c *Container) networkNames() []string {
+	return nil
+}
+
+func (r *Runtime) lockNetworks() (func(), error) {
+	return nil, define.ErrNotImplemented
+}
+
+func (c *Container) networkConnect(name string) error {
+	return define.ErrNotImplemented
+}
+
+func (c *Container) networkDisconnect(name string) error {
+	return define.ErrNotImplemented
+}
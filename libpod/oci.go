@@ -154,18 +154,34 @@ func createUnitName(prefix string, name string) string {
 	return fmt.Sprintf("%s-%s.scope", prefix, name)
 }
 
+// ipFamilySuffix returns the suffix restricting a network name such as "tcp"
+// to the address family of hostIP. Ports bound on all addresses are reserved
+// for both families.
+func ipFamilySuffix(hostIP string) string {
+	ip := net.ParseIP(hostIP)
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return "4"
+	default:
+		return "6"
+	}
+}
+
 func bindPorts(ports []ocicni.PortMapping) ([]*os.File, error) {
 	var files []*os.File
 	notifySCTP := false
 	for _, i := range ports {
 		switch i.Protocol {
 		case "udp":
-			addr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("%s:%d", i.HostIP, i.HostPort))
+			network := "udp" + ipFamilySuffix(i.HostIP)
+			addr, err := net.ResolveUDPAddr(network, net.JoinHostPort(i.HostIP, fmt.Sprintf("%d", i.HostPort)))
 			if err != nil {
 				return nil, errors.Wrapf(err, "cannot resolve the UDP address")
 			}
 
-			server, err := net.ListenUDP("udp", addr)
+			server, err := net.ListenUDP(network, addr)
 			if err != nil {
 				return nil, errors.Wrapf(err, "cannot listen on the UDP port")
 			}
@@ -176,12 +192,13 @@ func bindPorts(ports []ocicni.PortMapping) ([]*os.File, error) {
 			files = append(files, f)
 
 		case "tcp":
-			addr, err := net.ResolveTCPAddr("tcp4", fmt.Sprintf("%s:%d", i.HostIP, i.HostPort))
+			network := "tcp" + ipFamilySuffix(i.HostIP)
+			addr, err := net.ResolveTCPAddr(network, net.JoinHostPort(i.HostIP, fmt.Sprintf("%d", i.HostPort)))
 			if err != nil {
 				return nil, errors.Wrapf(err, "cannot resolve the TCP address")
 			}
 
-			server, err := net.ListenTCP("tcp4", addr)
+			server, err := net.ListenTCP(network, addr)
 			if err != nil {
 				return nil, errors.Wrapf(err, "cannot listen on the TCP port")
 			}
@@ -339,7 +356,7 @@ func (r *OCIRuntime) startContainer(ctr *Container) error {
 		return err
 	}
 	env := []string{fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir)}
-	if notify, ok := os.LookupEnv("NOTIFY_SOCKET"); ok {
+	if notify, ok := os.LookupEnv("NOTIFY_SOCKET"); ok && ctr.notifySystemd && ctr.SdNotifyMode() == define.SdNotifyModeContainer {
 		env = append(env, fmt.Sprintf("NOTIFY_SOCKET=%s", notify))
 	}
 	if err := utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, "start", ctr.ID()); err != nil {
@@ -23,7 +23,6 @@ import (
 	"github.com/containers/libpod/pkg/lookup"
 	"github.com/containers/libpod/pkg/util"
 	"github.com/containers/libpod/utils"
-	"github.com/coreos/go-systemd/activation"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/selinux/go-selinux"
 	"github.com/opencontainers/selinux/go-selinux/label"
@@ -81,6 +80,14 @@ func (r *OCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Containe
 		}
 	}
 
+	// The listening sockets of systemd socket activation are passed to the
+	// container at the file descriptors podman received them at
+	listenFiles := ctr.socketActivationFiles()
+	preserveFDs := len(listenFiles)
+	if preserveFDs > 0 {
+		args = append(args, formatRuntimeOpts("--preserve-fds", strconv.Itoa(preserveFDs))...)
+	}
+
 	if restoreOptions != nil {
 		args = append(args, "--restore", ctr.CheckpointPath())
 		if restoreOptions.TCPEstablished {
@@ -106,18 +113,19 @@ func (r *OCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Containe
 		cmd.Stderr = &stderrBuf
 	}
 
-	// 0, 1 and 2 are stdin, stdout and stderr
-	conmonEnv, envFiles, err := r.configureConmonEnv(runtimeDir)
+	// 0, 1 and 2 are stdin, stdout and stderr, followed by the preserved
+	// fds, so the pipes start at preserveFDs+3
+	conmonEnv, err := r.configureConmonEnv(ctr, runtimeDir)
 	if err != nil {
 		return err
 	}
 
-	cmd.Env = append(r.conmonEnv, fmt.Sprintf("_OCI_SYNCPIPE=%d", 3), fmt.Sprintf("_OCI_STARTPIPE=%d", 4))
+	cmd.Env = append(r.conmonEnv, fmt.Sprintf("_OCI_SYNCPIPE=%d", preserveFDs+3), fmt.Sprintf("_OCI_STARTPIPE=%d", preserveFDs+4))
 	cmd.Env = append(cmd.Env, conmonEnv...)
+	cmd.ExtraFiles = append(cmd.ExtraFiles, listenFiles...)
 	cmd.ExtraFiles = append(cmd.ExtraFiles, childSyncPipe, childStartPipe)
-	cmd.ExtraFiles = append(cmd.ExtraFiles, envFiles...)
 
-	if r.reservePorts && !ctr.config.NetMode.IsSlirp4netns() {
+	if r.reservePorts && !ctr.config.NetMode.IsSlirp4netns() && !ctr.usesRootlessCNI() {
 		ports, err := bindPorts(ctr.config.PortMappings)
 		if err != nil {
 			return err
@@ -129,12 +137,13 @@ func (r *OCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Containe
 		cmd.ExtraFiles = append(cmd.ExtraFiles, ports...)
 	}
 
-	if ctr.config.NetMode.IsSlirp4netns() {
+	if ctr.config.NetMode.IsSlirp4netns() || ctr.usesRootlessCNI() {
 		ctr.rootlessSlirpSyncR, ctr.rootlessSlirpSyncW, err = os.Pipe()
 		if err != nil {
 			return errors.Wrapf(err, "failed to create rootless network sync pipe")
 		}
 		// Leak one end in conmon, the other one will be leaked into slirp4netns
+		// or the port forwarder
 		cmd.ExtraFiles = append(cmd.ExtraFiles, ctr.rootlessSlirpSyncW)
 	}
 
@@ -231,28 +240,24 @@ func prepareProcessExec(c *Container, cmd, env []string, tty bool, cwd, user, se
 }
 
 // configureConmonEnv gets the environment values to add to conmon's exec struct
+// NOTIFY_SOCKET is only passed on if the container reports its readiness to
+// systemd itself, the OCI runtime then proxies it into the container
 // TODO this may want to be less hardcoded/more configurable in the future
-func (r *OCIRuntime) configureConmonEnv(runtimeDir string) ([]string, []*os.File, error) {
+func (r *OCIRuntime) configureConmonEnv(ctr *Container, runtimeDir string) ([]string, error) {
 	env := make([]string, 0, 6)
 	env = append(env, fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir))
 	env = append(env, fmt.Sprintf("_CONTAINERS_USERNS_CONFIGURED=%s", os.Getenv("_CONTAINERS_USERNS_CONFIGURED")))
 	env = append(env, fmt.Sprintf("_CONTAINERS_ROOTLESS_UID=%s", os.Getenv("_CONTAINERS_ROOTLESS_UID")))
 	home, err := homeDir()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	env = append(env, fmt.Sprintf("HOME=%s", home))
 
-	extraFiles := make([]*os.File, 0)
-	if notify, ok := os.LookupEnv("NOTIFY_SOCKET"); ok {
+	if notify, ok := os.LookupEnv("NOTIFY_SOCKET"); ok && ctr.notifySystemd && ctr.SdNotifyMode() == define.SdNotifyModeContainer {
 		env = append(env, fmt.Sprintf("NOTIFY_SOCKET=%s", notify))
 	}
-	if listenfds, ok := os.LookupEnv("LISTEN_FDS"); ok {
-		env = append(env, fmt.Sprintf("LISTEN_FDS=%s", listenfds), "LISTEN_PID=1")
-		fds := activation.Files(false)
-		extraFiles = append(extraFiles, fds...)
-	}
-	return env, extraFiles, nil
+	return env, nil
 }
 
 // sharedConmonArgs takes common arguments for exec and create/restore and formats them for the conmon CLI
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"syscall"
+	"time"
 
 	"github.com/containers/image/manifest"
 	"github.com/containers/libpod/libpod/define"
@@ -1010,6 +1011,112 @@ func WithStaticIP(ip net.IP) CtrCreateOption {
 	}
 }
 
+// WithStaticIPv6 indicates that the container should request a static IPv6
+// address from the CNI plugins. It can be combined with WithStaticIP to
+// request an address of each family.
+// It cannot be set unless WithNetNS has already been passed.
+// Further, it cannot be set if additional CNI networks to join have been
+// specified.
+func WithStaticIPv6(ip net.IP) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if !ctr.config.CreateNetNS {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set a static IP if the container is not creating a network namespace")
+		}
+
+		if len(ctr.config.Networks) != 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set a static IP if joining additional CNI networks")
+		}
+
+		if ip.To4() != nil {
+			return errors.Wrapf(define.ErrInvalidArg, "%s is not an IPv6 address", ip.String())
+		}
+
+		ctr.config.StaticIPv6 = ip
+
+		return nil
+	}
+}
+
+// WithNetworkAliases sets names, in addition to the container name, under
+// which other containers on the same CNI networks can resolve the container.
+// It cannot be set unless WithNetNS has already been passed.
+func WithNetworkAliases(aliases []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if !ctr.config.CreateNetNS {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set network aliases if the container is not creating a network namespace")
+		}
+
+		ctr.config.NetworkAliases = aliases
+
+		return nil
+	}
+}
+
+// WithBandwidth limits the traffic into and out of the container's network
+// namespace. Rates are in bits per second and bursts in bits. A direction
+// without a rate is not limited, and limited directions without a burst get a
+// burst of a tenth of a second of traffic.
+// It cannot be set unless WithNetNS has already been passed.
+func WithBandwidth(bandwidth ocicni.BandwidthConfig) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if !ctr.config.CreateNetNS {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set bandwidth limits if the container is not creating a network namespace")
+		}
+
+		if err := validateBandwidth(&bandwidth); err != nil {
+			return err
+		}
+
+		ctr.config.Bandwidth = &bandwidth
+
+		return nil
+	}
+}
+
+// WithNetworkOptions sets options for the program setting up the network of
+// the container, keyed by network mode. Only slirp4netns options are
+// presently supported.
+// It cannot be set unless WithNetNS has already been passed.
+func WithNetworkOptions(options map[string][]string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if !ctr.config.CreateNetNS {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set network options if the container is not creating a network namespace")
+		}
+
+		for mode, opts := range options {
+			if mode != "slirp4netns" {
+				return errors.Wrapf(define.ErrInvalidArg, "network options are not supported for network mode %q", mode)
+			}
+			if !ctr.config.NetMode.IsSlirp4netns() {
+				return errors.Wrapf(define.ErrInvalidArg, "cannot set slirp4netns options if the container is not using slirp4netns")
+			}
+			if _, err := parseSlirp4netnsOptions(opts); err != nil {
+				return err
+			}
+		}
+
+		ctr.config.NetworkOptions = options
+
+		return nil
+	}
+}
+
 // WithLogDriver sets the log driver for the container
 func WithLogDriver(driver string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1294,7 +1401,7 @@ func WithRestartPolicy(policy string) CtrCreateOption {
 		}
 
 		switch policy {
-		case RestartPolicyNone, RestartPolicyNo, RestartPolicyOnFailure, RestartPolicyAlways:
+		case RestartPolicyNone, RestartPolicyNo, RestartPolicyOnFailure, RestartPolicyAlways, RestartPolicyUnlessStopped:
 			ctr.config.RestartPolicy = policy
 		default:
 			return errors.Wrapf(define.ErrInvalidArg, "%q is not a valid restart policy", policy)
@@ -1304,6 +1411,30 @@ func WithRestartPolicy(policy string) CtrCreateOption {
 	}
 }
 
+// WithRestartBackoff sets the delay before the first restart attempted by the
+// container's restart policy, and the maximum delay between two restarts. The
+// delay is doubled after each restart, up to the maximum. 0 for either
+// selects the default.
+func WithRestartBackoff(backoff, backoffMax time.Duration) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if backoff < 0 || backoffMax < 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "restart backoff must not be negative")
+		}
+		if backoff != 0 && backoffMax != 0 && backoffMax < backoff {
+			return errors.Wrapf(define.ErrInvalidArg, "maximum restart backoff %s must not be less than restart backoff %s", backoffMax, backoff)
+		}
+
+		ctr.config.RestartBackoff = backoff
+		ctr.config.RestartBackoffMax = backoffMax
+
+		return nil
+	}
+}
+
 // WithRestartRetries sets the number of retries to use when restarting a
 // container with the "on-failure" restart policy.
 // 0 is an allowed value, and indicates infinite retries.
@@ -1333,6 +1464,54 @@ func withIsInfra() CtrCreateOption {
 	}
 }
 
+// WithInitCtr sets the container to be an init container of its pod. Init
+// containers are run to completion, in the order they were created, before the
+// other containers in the pod are started. The container must be in a pod.
+func WithInitCtr() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.IsInitCtr = true
+
+		return nil
+	}
+}
+
+// WithSdNotifyMode sets how the readiness of the container is reported to
+// systemd. With define.SdNotifyModeContainer, NOTIFY_SOCKET is passed to the
+// OCI runtime, which proxies it into the container.
+func WithSdNotifyMode(mode string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if err := define.ValidateSdNotifyMode(mode); err != nil {
+			return err
+		}
+
+		ctr.config.SdNotifyMode = mode
+
+		return nil
+	}
+}
+
+// WithCreateCommand records the command line the container was created with,
+// so it can be recreated from it later.
+func WithCreateCommand(createCommand []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.CreateCommand = createCommand
+
+		return nil
+	}
+}
+
 // WithNamedVolumes adds the given named volumes to the container.
 func WithNamedVolumes(volumes []*ContainerNamedVolume) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1362,6 +1541,42 @@ func WithNamedVolumes(volumes []*ContainerNamedVolume) CtrCreateOption {
 	}
 }
 
+// WithOverlayVolumes adds the given overlay volumes to the container.
+func WithOverlayVolumes(volumes []*ContainerOverlayVolume) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		destinations := make(map[string]bool)
+
+		for _, vol := range volumes {
+			if _, ok := destinations[vol.Dest]; ok {
+				return errors.Wrapf(define.ErrInvalidArg, "two volumes found with destination %s", vol.Dest)
+			}
+			destinations[vol.Dest] = true
+
+			if !filepath.IsAbs(vol.Source) {
+				return errors.Wrapf(define.ErrInvalidArg, "source %s of overlay volume must be an absolute path", vol.Source)
+			}
+
+			for _, opt := range vol.Options {
+				if opt != "z" && opt != "Z" {
+					return errors.Wrapf(define.ErrInvalidArg, "invalid option %q for overlay volume %s, only z and Z are supported", opt, vol.Dest)
+				}
+			}
+
+			ctr.config.OverlayVolumes = append(ctr.config.OverlayVolumes, &ContainerOverlayVolume{
+				Source:  vol.Source,
+				Dest:    vol.Dest,
+				Options: vol.Options,
+			})
+		}
+
+		return nil
+	}
+}
+
 // Volume Creation Options
 
 // WithVolumeName sets the name of the volume.
@@ -1674,6 +1889,188 @@ func WithInfraContainerPorts(bindings []ocicni.PortMapping) PodCreateOption {
 	}
 }
 
+// WithPodHostname sets the hostname of the pod's infra container, which is
+// used by all containers that share the pod's UTS namespace.
+func WithPodHostname(hostname string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		pod.config.Hostname = hostname
+
+		return nil
+	}
+}
+
+// WithPodHostNetwork tells the pod's infra container to use the host's
+// network namespace instead of creating a new one.
+func WithPodHostNetwork() PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot configure pod networking as no infra container is being created")
+		}
+
+		pod.config.InfraContainer.HostNetwork = true
+
+		return nil
+	}
+}
+
+// WithPodNetworks sets the CNI networks the pod's infra container, and with it
+// all containers that share the pod's network namespace, is attached to.
+// Without networks, the default network is used.
+func WithPodNetworks(networks []string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot configure pod networking as no infra container is being created")
+		}
+
+		if pod.config.InfraContainer.HostNetwork {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot join CNI networks if the pod uses the host network")
+		}
+
+		pod.config.InfraContainer.Networks = networks
+
+		return nil
+	}
+}
+
+// WithPodStaticIP sets the static IP address of the pod's infra container on
+// the default network.
+// It cannot be set if the pod uses the host network or additional CNI
+// networks.
+func WithPodStaticIP(ip net.IP) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot configure pod networking as no infra container is being created")
+		}
+
+		if pod.config.InfraContainer.HostNetwork {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set a static IP if the pod uses the host network")
+		}
+
+		if len(pod.config.InfraContainer.Networks) != 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set a static IP if joining additional CNI networks")
+		}
+
+		pod.config.InfraContainer.StaticIP = ip
+
+		return nil
+	}
+}
+
+// WithPodDNS sets the DNS servers of the pod's infra container, which are
+// used by all containers that share the pod's network namespace.
+func WithPodDNS(dnsServers []string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot configure pod DNS as no infra container is being created")
+		}
+
+		for _, i := range dnsServers {
+			if net.ParseIP(i) == nil {
+				return errors.Wrapf(define.ErrInvalidArg, "invalid IP address %s", i)
+			}
+		}
+		pod.config.InfraContainer.DNSServer = dnsServers
+
+		return nil
+	}
+}
+
+// WithPodDNSSearch sets the DNS search domains of the pod's infra container.
+func WithPodDNSSearch(searchDomains []string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot configure pod DNS as no infra container is being created")
+		}
+
+		pod.config.InfraContainer.DNSSearch = searchDomains
+
+		return nil
+	}
+}
+
+// WithPodDNSOption sets the DNS options of the pod's infra container.
+func WithPodDNSOption(dnsOptions []string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot configure pod DNS as no infra container is being created")
+		}
+
+		pod.config.InfraContainer.DNSOption = dnsOptions
+
+		return nil
+	}
+}
+
+// WithPodHosts adds additional entries to the /etc/hosts file of the pod's
+// infra container, which is shared by all containers in the pod.
+// Entries are in the host:ip format.
+func WithPodHosts(hosts []string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot add hosts to the pod as no infra container is being created")
+		}
+
+		pod.config.InfraContainer.HostAdd = hosts
+
+		return nil
+	}
+}
+
+// WithPodNetworkAliases sets network-scoped aliases of the pod's infra
+// container, which resolve to the pod on its CNI networks.
+// It cannot be set if the pod uses the host network.
+func WithPodNetworkAliases(aliases []string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot configure pod networking as no infra container is being created")
+		}
+
+		if pod.config.InfraContainer.HostNetwork {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set network aliases if the pod uses the host network")
+		}
+
+		pod.config.InfraContainer.NetworkAliases = aliases
+
+		return nil
+	}
+}
+
 // WithHealthCheck adds the healthcheck to the container config
 func WithHealthCheck(healthCheck *manifest.Schema2HealthConfig) CtrCreateOption {
 	return func(ctr *Container) error {
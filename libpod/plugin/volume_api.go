@@ -0,0 +1,274 @@
+// Package plugin implements the client side of the Docker volume plugin
+// protocol, which plugins serve as JSON over HTTP on a unix socket.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// volumePluginType is the plugin type volume plugins report
+	// implementing when activated
+	volumePluginType = "VolumeDriver"
+	// pluginContentType is the content type of plugin requests
+	pluginContentType = "application/vnd.docker.plugins.v1.2+json"
+	// pluginTimeout bounds the time a plugin may take to answer a request.
+	// Mounting may take a while for network filesystems.
+	pluginTimeout = 2 * time.Minute
+)
+
+const (
+	activatePath     = "/Plugin.Activate"
+	createPath       = "/VolumeDriver.Create"
+	removePath       = "/VolumeDriver.Remove"
+	mountPath        = "/VolumeDriver.Mount"
+	unmountPath      = "/VolumeDriver.Unmount"
+	hostPathPath     = "/VolumeDriver.Path"
+	getPath          = "/VolumeDriver.Get"
+	listPath         = "/VolumeDriver.List"
+	capabilitiesPath = "/VolumeDriver.Capabilities"
+)
+
+var (
+	// nameRegex matches valid plugin names
+	nameRegex = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$")
+
+	// pluginsLock protects plugins
+	pluginsLock sync.Mutex
+	// plugins caches activated plugins by socket path
+	plugins = make(map[string]*VolumePlugin)
+)
+
+// VolumePlugin is a volume plugin listening on a unix socket
+type VolumePlugin struct {
+	// Name is the name of the plugin, which is the name of its socket
+	// without the .sock extension
+	Name string
+	// SocketPath is the socket the plugin listens on
+	SocketPath string
+
+	client *http.Client
+}
+
+// Volume is a volume as reported by a volume plugin
+type Volume struct {
+	// Name is the name of the volume
+	Name string `json:"Name"`
+	// Mountpoint is where the volume is mounted on the host, if it is
+	// mounted
+	Mountpoint string `json:"Mountpoint,omitempty"`
+	// CreatedAt is when the volume was created, if the plugin tracks it
+	CreatedAt string `json:"CreatedAt,omitempty"`
+	// Status holds plugin specific information about the volume
+	Status map[string]interface{} `json:"Status,omitempty"`
+}
+
+// Capabilities are the capabilities of a volume plugin
+type Capabilities struct {
+	// Scope is "local" for plugins managing volumes of this host only,
+	// and "global" for plugins whose volumes are shared between hosts
+	Scope string `json:"Scope"`
+}
+
+// pluginRequest is the body of plugin requests. Fields not used by a request
+// are omitted.
+type pluginRequest struct {
+	Name string            `json:"Name,omitempty"`
+	ID   string            `json:"ID,omitempty"`
+	Opts map[string]string `json:"Opts,omitempty"`
+}
+
+// pluginResponse is the body of plugin responses. Fields not sent in response
+// to a request are left empty.
+type pluginResponse struct {
+	Err          string       `json:"Err"`
+	Implements   []string     `json:"Implements"`
+	Mountpoint   string       `json:"Mountpoint"`
+	Volume       *Volume      `json:"Volume"`
+	Volumes      []*Volume    `json:"Volumes"`
+	Capabilities Capabilities `json:"Capabilities"`
+}
+
+// GetVolumePlugin returns the volume plugin of the given name, listening on the
+// socket <name>.sock of the given plugin directory. The plugin is activated
+// the first time it is used.
+func GetVolumePlugin(name, pluginDir string) (*VolumePlugin, error) {
+	if !nameRegex.MatchString(name) {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "invalid volume plugin name %q", name)
+	}
+	socketPath := filepath.Join(pluginDir, name+".sock")
+
+	pluginsLock.Lock()
+	defer pluginsLock.Unlock()
+
+	if plugin, ok := plugins[socketPath]; ok {
+		return plugin, nil
+	}
+
+	if _, err := os.Stat(socketPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(define.ErrMissingPlugin, "no volume plugin %s in %s", name, pluginDir)
+		}
+		return nil, errors.Wrapf(err, "error accessing volume plugin %s", name)
+	}
+
+	plugin := &VolumePlugin{
+		Name:       name,
+		SocketPath: socketPath,
+		client: &http.Client{
+			Timeout: pluginTimeout,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socketPath)
+				},
+				DisableKeepAlives: true,
+			},
+		},
+	}
+
+	resp, err := plugin.sendRequest(activatePath, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error activating volume plugin %s", name)
+	}
+	if !util.StringInSlice(volumePluginType, resp.Implements) {
+		return nil, errors.Wrapf(define.ErrMissingPlugin, "plugin %s is not a volume plugin", name)
+	}
+	logrus.Debugf("Activated volume plugin %s at %s", name, socketPath)
+
+	plugins[socketPath] = plugin
+	return plugin, nil
+}
+
+// sendRequest posts a request to the plugin and decodes its response. Errors
+// reported by the plugin are returned as errors.
+func (p *VolumePlugin) sendRequest(path string, req *pluginRequest) (*pluginResponse, error) {
+	body := []byte("{}")
+	if req != nil {
+		var err error
+		if body, err = json.Marshal(req); err != nil {
+			return nil, errors.Wrapf(err, "error marshalling request to volume plugin %s", p.Name)
+		}
+	}
+
+	httpResp, err := p.client.Post("http://plugin"+path, pluginContentType, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error sending request %s to volume plugin %s", path, p.Name)
+	}
+	defer httpResp.Body.Close()
+
+	data, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading response to %s from volume plugin %s", path, p.Name)
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, errors.Wrapf(define.ErrNotImplemented, "volume plugin %s does not implement %s", p.Name, path)
+	}
+
+	resp := new(pluginResponse)
+	if err := json.Unmarshal(data, resp); err != nil {
+		if httpResp.StatusCode != http.StatusOK {
+			return nil, errors.Errorf("volume plugin %s returned status %d to %s: %s", p.Name, httpResp.StatusCode, path, bytes.TrimSpace(data))
+		}
+		return nil, errors.Wrapf(err, "error decoding response to %s from volume plugin %s", path, p.Name)
+	}
+	if resp.Err != "" {
+		return nil, errors.Errorf("volume plugin %s: %s", p.Name, resp.Err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("volume plugin %s returned status %d to %s", p.Name, httpResp.StatusCode, path)
+	}
+	return resp, nil
+}
+
+// CreateVolume asks the plugin to create a volume with the given options
+func (p *VolumePlugin) CreateVolume(name string, options map[string]string) error {
+	_, err := p.sendRequest(createPath, &pluginRequest{Name: name, Opts: options})
+	return err
+}
+
+// RemoveVolume asks the plugin to remove a volume
+func (p *VolumePlugin) RemoveVolume(name string) error {
+	_, err := p.sendRequest(removePath, &pluginRequest{Name: name})
+	return err
+}
+
+// MountVolume asks the plugin to mount a volume for the container of the
+// given ID, returning its mountpoint on the host. Plugins keep track of the
+// containers a volume is mounted for.
+func (p *VolumePlugin) MountVolume(name, id string) (string, error) {
+	resp, err := p.sendRequest(mountPath, &pluginRequest{Name: name, ID: id})
+	if err != nil {
+		return "", err
+	}
+	return resp.Mountpoint, nil
+}
+
+// UnmountVolume tells the plugin the container of the given ID no longer uses
+// a volume
+func (p *VolumePlugin) UnmountVolume(name, id string) error {
+	_, err := p.sendRequest(unmountPath, &pluginRequest{Name: name, ID: id})
+	return err
+}
+
+// GetVolumePath returns the mountpoint of a volume on the host
+func (p *VolumePlugin) GetVolumePath(name string) (string, error) {
+	resp, err := p.sendRequest(hostPathPath, &pluginRequest{Name: name})
+	if err != nil {
+		return "", err
+	}
+	return resp.Mountpoint, nil
+}
+
+// GetVolume returns a volume of the plugin
+func (p *VolumePlugin) GetVolume(name string) (*Volume, error) {
+	resp, err := p.sendRequest(getPath, &pluginRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Volume == nil {
+		return nil, errors.Wrapf(define.ErrNoSuchVolume, "volume plugin %s has no volume %s", p.Name, name)
+	}
+	return resp.Volume, nil
+}
+
+// ListVolumes returns the volumes of the plugin
+func (p *VolumePlugin) ListVolumes() ([]*Volume, error) {
+	resp, err := p.sendRequest(listPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Volumes, nil
+}
+
+// Capabilities returns the capabilities of the plugin. Plugins which do not
+// implement the request or report no scope manage local volumes.
+func (p *VolumePlugin) Capabilities() (Capabilities, error) {
+	resp, err := p.sendRequest(capabilitiesPath, nil)
+	if errors.Cause(err) == define.ErrNotImplemented {
+		return Capabilities{Scope: "local"}, nil
+	}
+	if err != nil {
+		return Capabilities{}, err
+	}
+	if resp.Capabilities.Scope == "" {
+		resp.Capabilities.Scope = "local"
+	}
+	return resp.Capabilities, nil
+}
@@ -0,0 +1,90 @@
+package plugin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// startPlugin serves the given responses, keyed by request path, on the
+// socket of a plugin of the given name in dir
+func startPlugin(t *testing.T, dir, name string, responses map[string]interface{}) {
+	mux := http.NewServeMux()
+	for path, response := range responses {
+		response := response
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			req := new(pluginRequest)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			assert.NoError(t, json.NewEncoder(w).Encode(response))
+		})
+	}
+
+	listener, err := net.Listen("unix", filepath.Join(dir, name+".sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go http.Serve(listener, mux) //nolint
+}
+
+func TestVolumePlugin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	startPlugin(t, dir, "test", map[string]interface{}{
+		activatePath: map[string]interface{}{"Implements": []string{volumePluginType}},
+		createPath:   map[string]interface{}{},
+		mountPath:    map[string]interface{}{"Mountpoint": "/mnt/vol"},
+		removePath:   map[string]interface{}{"Err": "volume is in use"},
+	})
+
+	p, err := GetVolumePlugin("test", dir)
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.CreateVolume("vol", map[string]string{"size": "1G"}))
+
+	mountPoint, err := p.MountVolume("vol", "ctr")
+	assert.NoError(t, err)
+	assert.Equal(t, "/mnt/vol", mountPoint)
+
+	err = p.RemoveVolume("vol")
+	assert.EqualError(t, err, "volume plugin test: volume is in use")
+
+	_, err = p.GetVolume("vol")
+	assert.Equal(t, define.ErrNotImplemented, errors.Cause(err))
+
+	capabilities, err := p.Capabilities()
+	assert.NoError(t, err)
+	assert.Equal(t, "local", capabilities.Scope)
+}
+
+func TestGetVolumePluginInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	startPlugin(t, dir, "authz", map[string]interface{}{
+		activatePath: map[string]interface{}{"Implements": []string{"authz"}},
+	})
+
+	_, err = GetVolumePlugin("missing", dir)
+	assert.Equal(t, define.ErrMissingPlugin, errors.Cause(err))
+
+	_, err = GetVolumePlugin("../test", dir)
+	assert.Equal(t, define.ErrInvalidArg, errors.Cause(err))
+
+	_, err = GetVolumePlugin("authz", dir)
+	assert.Equal(t, define.ErrMissingPlugin, errors.Cause(err))
+}
@@ -1,6 +1,7 @@
 package libpod
 
 import (
+	"net"
 	"time"
 
 	"github.com/containers/libpod/libpod/define"
@@ -55,6 +56,10 @@ type PodConfig struct {
 	UsePodUser  bool `json:"sharesUser,omitempty"`
 	UsePodUTS   bool `json:"sharesUts,omitempty"`
 
+	// Hostname is the hostname of the pod's infra container, which is
+	// shared by all containers in the pod that share its UTS namespace
+	Hostname string `json:"hostname,omitempty"`
+
 	InfraContainer *InfraContainerConfig `json:"infraConfig"`
 
 	// Time pod was created
@@ -76,9 +81,10 @@ type podState struct {
 // PodInspect represents the data we want to display for
 // podman pod inspect
 type PodInspect struct {
-	Config     *PodConfig
-	State      *PodInspectState
-	Containers []PodContainerInfo
+	Config         *PodConfig
+	State          *PodInspectState
+	Containers     []PodContainerInfo
+	InitContainers []PodContainerInfo `json:",omitempty"`
 }
 
 // PodInspectState contains inspect data on the pod's state
@@ -96,7 +102,15 @@ type PodContainerInfo struct {
 // InfraContainerConfig is the configuration for the pod's infra container
 type InfraContainerConfig struct {
 	HasInfraContainer bool                 `json:"makeInfraContainer"`
+	HostNetwork       bool                 `json:"infraHostNetwork,omitempty"`
 	PortBindings      []ocicni.PortMapping `json:"infraPortBindings"`
+	Networks          []string             `json:"networks,omitempty"`
+	StaticIP          net.IP               `json:"staticIP,omitempty"`
+	DNSServer         []string             `json:"dnsServer,omitempty"`
+	DNSSearch         []string             `json:"dnsSearch,omitempty"`
+	DNSOption         []string             `json:"dnsOption,omitempty"`
+	HostAdd           []string             `json:"hostsAdd,omitempty"`
+	NetworkAliases    []string             `json:"networkAliases,omitempty"`
 }
 
 // ID retrieves the pod's ID
@@ -130,6 +144,11 @@ func (p *Pod) CreatedTime() time.Time {
 	return p.config.CreatedTime
 }
 
+// Hostname returns the hostname of the pod's infra container
+func (p *Pod) Hostname() string {
+	return p.config.Hostname
+}
+
 // CgroupParent returns the pod's CGroup parent
 func (p *Pod) CgroupParent() string {
 	return p.config.CgroupParent
@@ -223,6 +242,31 @@ func (p *Pod) allContainers() ([]*Container, error) {
 	return p.runtime.state.PodContainers(p)
 }
 
+// ContainersByDependencies retrieves the containers in the pod ordered so
+// that every container follows all containers it depends on, which is the
+// order in which they are started.
+// Init containers are not included.
+func (p *Pod) ContainersByDependencies() ([]*Container, error) {
+	if !p.valid {
+		return nil, define.ErrPodRemoved
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	allCtrs, err := p.allContainers()
+	if err != nil {
+		return nil, err
+	}
+
+	_, ctrs := splitInitContainers(allCtrs)
+	graph, err := buildContainerGraph(ctrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error generating dependency graph for pod %s", p.ID())
+	}
+
+	return sortContainersByDependencies(graph), nil
+}
+
 // HasInfraContainer returns whether the pod will create an infra container
 func (p *Pod) HasInfraContainer() bool {
 	return p.config.InfraContainer.HasInfraContainer
@@ -23,7 +23,16 @@ import (
 // containers. The container ID is mapped to the error encountered. The error is
 // set to ErrCtrExists
 // If both error and the map are nil, all containers were started successfully
+// Init containers are run to completion, one at a time, before any other
+// container is started. If one of them fails, an error is returned and no
+// other containers are started.
 func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
+	// Init containers can run for a long time, so they are run without
+	// holding the pod lock
+	if err := p.runInitContainers(ctx); err != nil {
+		return nil, err
+	}
+
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -36,8 +45,10 @@ func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
 		return nil, err
 	}
 
+	_, ctrs := splitInitContainers(allCtrs)
+
 	// Build a dependency graph of containers in the pod
-	graph, err := buildContainerGraph(allCtrs)
+	graph, err := buildContainerGraph(ctrs)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error generating dependency graph for pod %s", p.ID())
 	}
@@ -275,6 +286,7 @@ func (p *Pod) Unpause() (map[string]error, error) {
 // containers. The container ID is mapped to the error encountered. The error is
 // set to ErrCtrExists
 // If both error and the map are nil, all containers were restarted without error
+// Init containers are not restarted.
 func (p *Pod) Restart(ctx context.Context) (map[string]error, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -287,9 +299,10 @@ func (p *Pod) Restart(ctx context.Context) (map[string]error, error) {
 	if err != nil {
 		return nil, err
 	}
+	_, ctrs := splitInitContainers(allCtrs)
 
 	// Build a dependency graph of containers in the pod
-	graph, err := buildContainerGraph(allCtrs)
+	graph, err := buildContainerGraph(ctrs)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error generating dependency graph for pod %s", p.ID())
 	}
@@ -418,7 +431,8 @@ func (p *Pod) Status() (map[string]define.ContainerStatus, error) {
 // Inspect returns a PodInspect struct to describe the pod
 func (p *Pod) Inspect() (*PodInspect, error) {
 	var (
-		podContainers []PodContainerInfo
+		podContainers  []PodContainerInfo
+		initContainers []PodContainerInfo
 	)
 
 	p.lock.Lock()
@@ -443,6 +457,10 @@ func (p *Pod) Inspect() (*PodInspect, error) {
 			ID:    c.ID(),
 			State: containerStatus,
 		}
+		if c.IsInitCtr() {
+			initContainers = append(initContainers, pc)
+			continue
+		}
 		podContainers = append(podContainers, pc)
 	}
 	infraContainerID := p.state.InfraContainerID
@@ -457,7 +475,8 @@ func (p *Pod) Inspect() (*PodInspect, error) {
 			CgroupPath:       p.state.CgroupPath,
 			InfraContainerID: infraContainerID,
 		},
-		Containers: podContainers,
+		Containers:     podContainers,
+		InitContainers: initContainers,
 	}
 	return &inspectData, nil
 }
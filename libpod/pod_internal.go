@@ -1,8 +1,10 @@
 package libpod
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/containers/libpod/libpod/define"
@@ -84,3 +86,98 @@ func (p *Pod) refresh() error {
 	// Save changes
 	return p.save()
 }
+
+// splitInitContainers separates the init containers of a pod from its other
+// containers. Init containers are returned in the order they were created.
+func splitInitContainers(allCtrs []*Container) ([]*Container, []*Container) {
+	var initCtrs, ctrs []*Container
+	for _, ctr := range allCtrs {
+		if ctr.IsInitCtr() {
+			initCtrs = append(initCtrs, ctr)
+		} else {
+			ctrs = append(ctrs, ctr)
+		}
+	}
+	sort.SliceStable(initCtrs, func(i, j int) bool {
+		return initCtrs[i].CreatedTime().Before(initCtrs[j].CreatedTime())
+	})
+	return initCtrs, ctrs
+}
+
+// initContainersToRun returns the init containers of the pod that have to be
+// run before its other containers are started, in the order they must run.
+// No init containers are returned when one of the other containers of the pod
+// is already running.
+// Takes the pod lock; must be called with the pod unlocked.
+func (p *Pod) initContainersToRun() ([]*Container, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return nil, define.ErrPodRemoved
+	}
+
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return nil, err
+	}
+
+	initCtrs, ctrs := splitInitContainers(allCtrs)
+	if len(initCtrs) == 0 {
+		return nil, nil
+	}
+	for _, ctr := range ctrs {
+		if ctr.IsInfra() {
+			continue
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return nil, err
+		}
+		if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+			logrus.Debugf("Pod %s already has running containers, not running init containers", p.ID())
+			return nil, nil
+		}
+	}
+	for _, ctr := range initCtrs {
+		state, err := ctr.State()
+		if err != nil {
+			return nil, err
+		}
+		if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+			return nil, errors.Wrapf(define.ErrCtrStateInvalid, "init container %s of pod %s is already running", ctr.ID(), p.ID())
+		}
+	}
+	return initCtrs, nil
+}
+
+// runInitContainers starts the init containers of the pod one at a time and
+// waits for each of them to exit before starting the next. An error is
+// returned as soon as one of them fails to start or exits with a non-zero
+// code.
+// Init containers are only run when none of the other containers of the pod
+// are already running.
+// The pod lock is not held while the init containers run, so callers must
+// re-check the pod's state afterwards.
+// Must be called with the pod unlocked.
+func (p *Pod) runInitContainers(ctx context.Context) error {
+	initCtrs, err := p.initContainersToRun()
+	if err != nil {
+		return err
+	}
+
+	for _, ctr := range initCtrs {
+		logrus.Debugf("Running init container %s of pod %s", ctr.ID(), p.ID())
+		if err := ctr.Start(ctx, true); err != nil {
+			return errors.Wrapf(err, "error starting init container %s of pod %s", ctr.ID(), p.ID())
+		}
+		exitCode, err := ctr.Wait()
+		if err != nil {
+			return errors.Wrapf(err, "error waiting for init container %s of pod %s", ctr.ID(), p.ID())
+		}
+		if exitCode != 0 {
+			return errors.Wrapf(define.ErrCtrStateInvalid, "init container %s of pod %s exited with code %d", ctr.ID(), p.ID(), exitCode)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package libpod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containers/libpod/libpod/lock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitInitContainersOrdersByCreation(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	if err != nil {
+		t.Fatalf("Error setting up locks: %v", err)
+	}
+
+	ctr1, err := getTestCtrN("1", manager)
+	assert.NoError(t, err)
+	ctr2, err := getTestCtrN("2", manager)
+	assert.NoError(t, err)
+	ctr3, err := getTestCtrN("3", manager)
+	assert.NoError(t, err)
+
+	ctr1.config.IsInitCtr = true
+	ctr1.config.CreatedTime = time.Now()
+	ctr2.config.IsInitCtr = true
+	ctr2.config.CreatedTime = ctr1.config.CreatedTime.Add(-time.Second)
+
+	initCtrs, ctrs := splitInitContainers([]*Container{ctr1, ctr2, ctr3})
+	assert.Equal(t, []*Container{ctr2, ctr1}, initCtrs)
+	assert.Equal(t, []*Container{ctr3}, ctrs)
+}
@@ -144,6 +144,10 @@ type RuntimeConfig struct {
 	// under. This convention is followed by the default volume driver, but
 	// may not be by other drivers.
 	VolumePath string `toml:"volume_path"`
+	// VolumePluginDir is the directory where the sockets of Docker volume
+	// plugins are found. Volumes created with a driver other than local
+	// are managed by the plugin listening on <driver>.sock in it.
+	VolumePluginDir string `toml:"volume_plugin_dir"`
 	// ImageDefaultTransport is the default transport method used to fetch
 	// images
 	ImageDefaultTransport string `toml:"image_default_transport"`
@@ -238,6 +242,9 @@ type RuntimeConfig struct {
 	EnableLabeling bool `toml:"label"`
 	// NetworkCmdPath is the path to the slirp4netns binary
 	NetworkCmdPath string `toml:"network_cmd_path"`
+	// NetworkCmdOptions are the default options passed to slirp4netns,
+	// used when a container does not set its own
+	NetworkCmdOptions []string `toml:"network_cmd_options"`
 
 	// NumLocks is the number of locks to make available for containers and
 	// pods.
@@ -273,6 +280,7 @@ type runtimeConfiguredFrom struct {
 	ociRuntimes           bool
 	runtimePath           bool
 	cniPluginDir          bool
+	cniConfigDir          bool
 	noPivotRoot           bool
 }
 
@@ -285,6 +293,7 @@ func defaultRuntimeConfig() (RuntimeConfig, error) {
 		// Leave this empty so containers/storage will use its defaults
 		StorageConfig:         storage.StoreOptions{},
 		VolumePath:            filepath.Join(storeOpts.GraphRoot, "volumes"),
+		VolumePluginDir:       "/run/docker/plugins",
 		ImageDefaultTransport: DefaultTransport,
 		StateType:             BoltDBStateStore,
 		OCIRuntime:            "runc",
@@ -523,6 +532,9 @@ func newRuntimeFromConfig(ctx context.Context, userConfigPath string, options ..
 	if err != nil {
 		return nil, err
 	}
+	// Rootless users cannot write the system CNI configuration, and their
+	// networks only exist in their rootless network namespace
+	rootlessCNIConfigDir := ""
 	if rootless.IsRootless() {
 		home, err := homeDir()
 		if err != nil {
@@ -534,6 +546,13 @@ func newRuntimeFromConfig(ctx context.Context, userConfigPath string, options ..
 				runtime.config.SignaturePolicyPath = newPath
 			}
 		}
+		rootlessCNIConfigDir = filepath.Join(home, ".config/cni/net.d")
+
+		runtimeDir, err := util.GetRootlessRuntimeDir()
+		if err != nil {
+			return nil, err
+		}
+		runtime.config.VolumePluginDir = filepath.Join(runtimeDir, "docker/plugins")
 	}
 
 	if userConfigPath != "" {
@@ -590,6 +609,9 @@ func newRuntimeFromConfig(ctx context.Context, userConfigPath string, options ..
 		if tmpConfig.CNIPluginDir != nil {
 			runtime.configuredFrom.cniPluginDir = true
 		}
+		if tmpConfig.CNIConfigDir != "" {
+			runtime.configuredFrom.cniConfigDir = true
+		}
 		if tmpConfig.NoPivotRoot {
 			runtime.configuredFrom.noPivotRoot = true
 		}
@@ -637,6 +659,11 @@ func newRuntimeFromConfig(ctx context.Context, userConfigPath string, options ..
 		}
 	}
 
+	// A CNI configuration directory the user set is always kept
+	if rootlessCNIConfigDir != "" && !runtime.configuredFrom.cniConfigDir {
+		runtime.config.CNIConfigDir = rootlessCNIConfigDir
+	}
+
 	// Overwrite config with user-given configuration options
 	for _, opt := range options {
 		if err := opt(runtime); err != nil {
@@ -1083,13 +1110,11 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (err error) {
 	}
 
 	// Set up the CNI net plugin
-	if !rootless.IsRootless() {
-		netPlugin, err := ocicni.InitCNI(runtime.config.CNIDefaultNetwork, runtime.config.CNIConfigDir, runtime.config.CNIPluginDir...)
-		if err != nil {
-			return errors.Wrapf(err, "error configuring CNI network plugin")
-		}
-		runtime.netPlugin = netPlugin
+	netPlugin, err := ocicni.InitCNI(runtime.config.CNIDefaultNetwork, runtime.config.CNIConfigDir, runtime.config.CNIPluginDir...)
+	if err != nil {
+		return errors.Wrapf(err, "error configuring CNI network plugin")
 	}
+	runtime.netPlugin = netPlugin
 
 	// Set up a firewall backend
 	backendType := ""
@@ -1183,6 +1208,15 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (err error) {
 		}
 	}
 
+	// Volumes created before volumes had locks are given one now, while
+	// the runtime alive lock keeps other processes from using them. This
+	// is only done once, as the database records it.
+	if !dbConfig.VolumeLocksAllocated {
+		if err := runtime.allocateLegacyVolumeLocks(); err != nil {
+			return err
+		}
+	}
+
 	// Mark the runtime as valid - ready to be used, cannot be modified
 	// further
 	runtime.valid = true
@@ -1306,6 +1340,19 @@ func (r *Runtime) refresh(alivePath string) error {
 			logrus.Errorf("Error refreshing pod %s: %v", pod.ID(), err)
 		}
 	}
+	vols, err := r.state.AllVolumes()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving all volumes from state")
+	}
+	for _, vol := range vols {
+		// Legacy volumes without a lock are given one afterwards
+		if vol.lock == nil {
+			continue
+		}
+		if err := vol.refresh(); err != nil {
+			logrus.Errorf("Error refreshing volume %s: %v", vol.Name(), err)
+		}
+	}
 
 	// Create a file indicating the runtime is alive and ready
 	file, err := os.OpenFile(alivePath, os.O_RDONLY|os.O_CREATE, 0644)
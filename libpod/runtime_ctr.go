@@ -164,6 +164,8 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (c *Contai
 		if err != nil {
 			return nil, errors.Wrapf(err, "cannot add container %s to pod %s", ctr.ID(), ctr.config.Pod)
 		}
+	} else if ctr.config.IsInitCtr {
+		return nil, errors.Wrapf(config2.ErrInvalidArg, "init container %s must be part of a pod", ctr.ID())
 	}
 
 	if ctr.config.Name == "" {
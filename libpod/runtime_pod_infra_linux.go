@@ -68,6 +68,9 @@ func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, imgID
 
 	g.SetRootReadonly(true)
 	g.SetProcessArgs(entryCmd)
+	if p.config.Hostname != "" {
+		g.SetHostname(p.config.Hostname)
+	}
 
 	logrus.Debugf("Using %q as infra container entrypoint", entryCmd)
 
@@ -89,13 +92,41 @@ func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, imgID
 	options = append(options, WithName(containerName))
 	options = append(options, withIsInfra())
 
-	// Since user namespace sharing is not implemented, we only need to check if it's rootless
-	networks := make([]string, 0)
-	netmode := "bridge"
-	if isRootless {
-		netmode = "slirp4netns"
+	if p.config.InfraContainer.HostNetwork {
+		// The infra container, and all containers joining its network
+		// namespace, use the host's network
+		if err := g.RemoveLinuxNamespace(string(spec.NetworkNamespace)); err != nil {
+			return nil, errors.Wrapf(err, "error removing network namespace from infra container")
+		}
+	} else {
+		// Since user namespace sharing is not implemented, we only need to check if it's rootless
+		networks := make([]string, 0)
+		netmode := "bridge"
+		if len(p.config.InfraContainer.Networks) > 0 {
+			networks = p.config.InfraContainer.Networks
+		} else if isRootless {
+			netmode = "slirp4netns"
+		}
+		options = append(options, WithNetNS(p.config.InfraContainer.PortBindings, isRootless, netmode, networks))
+		if p.config.InfraContainer.StaticIP != nil {
+			options = append(options, WithStaticIP(p.config.InfraContainer.StaticIP))
+		}
+		if len(p.config.InfraContainer.NetworkAliases) > 0 {
+			options = append(options, WithNetworkAliases(p.config.InfraContainer.NetworkAliases))
+		}
+	}
+	if len(p.config.InfraContainer.DNSServer) > 0 {
+		options = append(options, WithDNS(p.config.InfraContainer.DNSServer))
+	}
+	if len(p.config.InfraContainer.DNSSearch) > 0 {
+		options = append(options, WithDNSSearch(p.config.InfraContainer.DNSSearch))
+	}
+	if len(p.config.InfraContainer.DNSOption) > 0 {
+		options = append(options, WithDNSOption(p.config.InfraContainer.DNSOption))
+	}
+	if len(p.config.InfraContainer.HostAdd) > 0 {
+		options = append(options, WithHosts(p.config.InfraContainer.HostAdd))
 	}
-	options = append(options, WithNetNS(p.config.InfraContainer.PortBindings, isRootless, netmode, networks))
 
 	return r.newContainer(ctx, g.Config, options...)
 }
@@ -5,8 +5,8 @@ import (
 	"github.com/pkg/errors"
 )
 
-// renumberLocks reassigns lock numbers for all containers and pods in the
-// state.
+// renumberLocks reassigns lock numbers for all containers, pods, and volumes
+// in the state.
 // TODO: It would be desirable to make it impossible to call this until all
 // other libpod sessions are dead.
 // Possibly use a read-write file lock, with all non-renumber podmans owning the
@@ -54,6 +54,24 @@ func (r *Runtime) renumberLocks() error {
 		}
 	}
 
+	allVols, err := r.state.AllVolumes()
+	if err != nil {
+		return err
+	}
+	for _, vol := range allVols {
+		lock, err := r.lockManager.AllocateLock()
+		if err != nil {
+			return errors.Wrapf(err, "error allocating lock for volume %s", vol.Name())
+		}
+
+		vol.config.LockID = lock.ID()
+
+		// Write the new lock ID
+		if err := r.state.RewriteVolumeConfig(vol, vol.config); err != nil {
+			return err
+		}
+	}
+
 	r.newSystemEvent(events.Renumber)
 
 	return nil
@@ -168,3 +168,31 @@ func (r *Runtime) PruneVolumes(ctx context.Context) ([]string, []error) {
 	}
 	return prunedIDs, pruneErrors
 }
+
+// allocateLegacyVolumeLocks allocates locks for the volumes created before
+// volumes had locks, saves their lock IDs, and records in the database that
+// all volumes have a lock
+func (r *Runtime) allocateLegacyVolumeLocks() error {
+	vols, err := r.state.AllVolumes()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving all volumes from state")
+	}
+	for _, vol := range vols {
+		if vol.lock != nil {
+			continue
+		}
+		lock, err := r.lockManager.AllocateLock()
+		if err != nil {
+			return errors.Wrapf(err, "error allocating lock for volume %s", vol.Name())
+		}
+		vol.config.LockID = lock.ID()
+		if err := r.state.RewriteVolumeConfig(vol, vol.config); err != nil {
+			if err2 := lock.Free(); err2 != nil {
+				logrus.Errorf("Error freeing lock for volume %s: %v", vol.Name(), err2)
+			}
+			return err
+		}
+		logrus.Debugf("Allocated lock %d for volume %s", lock.ID(), vol.Name())
+	}
+	return r.state.SetVolumeLocksAllocated()
+}
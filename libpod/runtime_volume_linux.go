@@ -10,6 +10,7 @@ import (
 
 	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/libpod/events"
+	"github.com/containers/libpod/libpod/plugin"
 	"github.com/containers/storage/pkg/stringid"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -42,11 +43,78 @@ func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption)
 	if volume.config.Name == "" {
 		volume.config.Name = stringid.GenerateNonCryptoID()
 	}
-	// TODO: support for other volume drivers
 	if volume.config.Driver == "" {
-		volume.config.Driver = "local"
+		volume.config.Driver = LocalVolumeDriver
 	}
-	// TODO: determine when the scope is global and set it to that
+	volume.config.Plugin = volume.config.Driver != LocalVolumeDriver
+
+	// Allocate a lock for the volume
+	lock, err := r.lockManager.AllocateLock()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error allocating lock for new volume")
+	}
+	volume.lock = lock
+	volume.config.LockID = volume.lock.ID()
+
+	defer func() {
+		if !volume.valid {
+			if err := volume.lock.Free(); err != nil {
+				logrus.Errorf("Error freeing volume lock after failed creation: %v", err)
+			}
+		}
+	}()
+
+	if volume.UsesVolumeDriver() {
+		p, err := volume.getPlugin()
+		if err != nil {
+			return nil, err
+		}
+		if err := r.createPluginVolume(p, volume); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if !volume.valid {
+				if err := p.RemoveVolume(volume.Name()); err != nil {
+					logrus.Errorf("Error removing volume %s from volume plugin %s: %v", volume.Name(), p.Name, err)
+				}
+			}
+		}()
+	} else if err := r.createLocalVolume(volume); err != nil {
+		return nil, err
+	}
+
+	volume.valid = true
+
+	// Add the volume to state
+	if err := r.state.AddVolume(volume); err != nil {
+		volume.valid = false
+		return nil, errors.Wrapf(err, "error adding volume to state")
+	}
+	defer volume.newVolumeEvent(events.Create)
+	return volume, nil
+}
+
+// createPluginVolume creates a volume through its volume plugin
+func (r *Runtime) createPluginVolume(p *plugin.VolumePlugin, volume *Volume) error {
+	if volume.config.Scope == "" {
+		capabilities, err := p.Capabilities()
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving capabilities of volume plugin %s", p.Name)
+		}
+		volume.config.Scope = capabilities.Scope
+	}
+	if err := p.CreateVolume(volume.Name(), volume.config.Options); err != nil {
+		return errors.Wrapf(err, "error creating volume %s", volume.Name())
+	}
+	return nil
+}
+
+// createLocalVolume creates the directory of a volume of the local driver
+func (r *Runtime) createLocalVolume(volume *Volume) error {
+	if err := volume.validateLocalOptions(); err != nil {
+		return err
+	}
+
 	if volume.config.Scope == "" {
 		volume.config.Scope = "local"
 	}
@@ -54,31 +122,32 @@ func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption)
 	// Create the mountpoint of this volume
 	volPathRoot := filepath.Join(r.config.VolumePath, volume.config.Name)
 	if err := os.MkdirAll(volPathRoot, 0700); err != nil {
-		return nil, errors.Wrapf(err, "error creating volume directory %q", volPathRoot)
+		return errors.Wrapf(err, "error creating volume directory %q", volPathRoot)
 	}
 	if err := os.Chown(volPathRoot, volume.config.UID, volume.config.GID); err != nil {
-		return nil, errors.Wrapf(err, "error chowning volume directory %q to %d:%d", volPathRoot, volume.config.UID, volume.config.GID)
+		return errors.Wrapf(err, "error chowning volume directory %q to %d:%d", volPathRoot, volume.config.UID, volume.config.GID)
+	}
+	if volume.config.Quota > 0 {
+		if err := volume.setupQuota(volPathRoot); err != nil {
+			if err2 := os.RemoveAll(volPathRoot); err2 != nil {
+				logrus.Errorf("Error removing volume directory %q after failed creation: %v", volPathRoot, err2)
+			}
+			return err
+		}
 	}
 	fullVolPath := filepath.Join(volPathRoot, "_data")
 	if err := os.Mkdir(fullVolPath, 0755); err != nil {
-		return nil, errors.Wrapf(err, "error creating volume directory %q", fullVolPath)
+		return errors.Wrapf(err, "error creating volume directory %q", fullVolPath)
 	}
 	if err := os.Chown(fullVolPath, volume.config.UID, volume.config.GID); err != nil {
-		return nil, errors.Wrapf(err, "error chowning volume directory %q to %d:%d", fullVolPath, volume.config.UID, volume.config.GID)
+		return errors.Wrapf(err, "error chowning volume directory %q to %d:%d", fullVolPath, volume.config.UID, volume.config.GID)
 	}
 	if err := LabelVolumePath(fullVolPath, true); err != nil {
-		return nil, err
+		return err
 	}
 	volume.config.MountPoint = fullVolPath
 
-	volume.valid = true
-
-	// Add the volume to state
-	if err := r.state.AddVolume(volume); err != nil {
-		return nil, errors.Wrapf(err, "error adding volume to state")
-	}
-	defer volume.newVolumeEvent(events.Create)
-	return volume, nil
+	return nil
 }
 
 // removeVolume removes the specified volume from state as well tears down its mountpoint and storage
@@ -123,6 +192,27 @@ func (r *Runtime) removeVolume(ctx context.Context, v *Volume, force bool) error
 		}
 	}
 
+	// Volume plugins may refuse to remove a volume, so remove it from its
+	// plugin first to keep it in the state in that case
+	if v.UsesVolumeDriver() {
+		p, err := v.getPlugin()
+		if err != nil {
+			return err
+		}
+		if err := p.RemoveVolume(v.Name()); err != nil {
+			return errors.Wrapf(err, "error removing volume %s", v.Name())
+		}
+	}
+
+	// Local volumes with mount options are unmounted once no container
+	// uses them, but make sure the removal of their directory cannot reach
+	// a mounted filesystem
+	if v.needsMount() && !v.UsesVolumeDriver() {
+		if err := v.unmountLocalAll(); err != nil {
+			return err
+		}
+	}
+
 	// Set volume as invalid so it can no longer be used
 	v.valid = false
 
@@ -136,6 +226,11 @@ func (r *Runtime) removeVolume(ctx context.Context, v *Volume, force bool) error
 		return errors.Wrapf(err, "error cleaning up volume storage for %q", v.Name())
 	}
 
+	// Deallocate the volume's lock
+	if err := v.lock.Free(); err != nil {
+		return errors.Wrapf(err, "error freeing lock for volume %s", v.Name())
+	}
+
 	defer v.newVolumeEvent(events.Remove)
 	logrus.Debugf("Removed volume %s", v.Name())
 	return nil
@@ -9,6 +9,9 @@ type DBConfig struct {
 	StorageTmp  string
 	GraphDriver string
 	VolumePath  string
+	// VolumeLocksAllocated is whether all volumes in the database have a
+	// lock of their own
+	VolumeLocksAllocated bool
 }
 
 // State is a storage backend for libpod's current state.
@@ -48,6 +51,13 @@ type State interface {
 	// the program.
 	ValidateDBConfig(runtime *Runtime) error
 
+	// SetVolumeLocksAllocated records in the database that all volumes
+	// have a lock of their own, so the volumes created before volumes had
+	// locks need not be searched for again.
+	// This is not implemented by the in-memory state, which has no volumes
+	// created by earlier versions.
+	SetVolumeLocksAllocated() error
+
 	// SetNamespace() sets the namespace for the store, and will determine
 	// what containers are retrieved with container and pod retrieval calls.
 	// A namespace of "", the empty string, acts as no namespace, and
@@ -121,6 +131,12 @@ type State interface {
 	// It is subject to the same conditions as RewriteContainerConfig.
 	// Please do not use this unless you know what you're doing.
 	RewritePodConfig(pod *Pod, newCfg *PodConfig) error
+	// PLEASE READ THE DESCRIPTION OF RewriteContainerConfig BEFORE USING.
+	// This function is identical to RewriteContainerConfig, save for the
+	// fact that it is used with volumes instead.
+	// It is subject to the same conditions as RewriteContainerConfig.
+	// The volume's name cannot be altered.
+	RewriteVolumeConfig(volume *Volume, newCfg *VolumeConfig) error
 
 	// Accepts full ID of pod.
 	// If the pod given is not in the set namespace, an error will be
@@ -195,6 +211,10 @@ type State interface {
 	// RemoveVolume removes the specified volume.
 	// Only volumes that have no container dependencies can be removed
 	RemoveVolume(volume *Volume) error
+	// UpdateVolume updates the volume's state from the database.
+	UpdateVolume(volume *Volume) error
+	// SaveVolume saves the volume's state to the database.
+	SaveVolume(volume *Volume) error
 	// AllVolumes returns all the volumes available in the state
 	AllVolumes() ([]*Volume, error)
 }
@@ -3599,3 +3599,21 @@ func TestSaveAndUpdatePodSameNamespace(t *testing.T) {
 		testPodsEqual(t, testPod, statePod, false)
 	})
 }
+
+func TestSetVolumeLocksAllocated(t *testing.T) {
+	state, path, _, err := getEmptyBoltState()
+	assert.NoError(t, err)
+	defer os.RemoveAll(path)
+	defer state.Close()
+
+	dbConfig, err := state.GetDBConfig()
+	assert.NoError(t, err)
+	assert.False(t, dbConfig.VolumeLocksAllocated)
+
+	err = state.SetVolumeLocksAllocated()
+	assert.NoError(t, err)
+
+	dbConfig, err = state.GetDBConfig()
+	assert.NoError(t, err)
+	assert.True(t, dbConfig.VolumeLocksAllocated)
+}
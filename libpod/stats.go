@@ -72,6 +72,10 @@ func (c *Container) GetContainerStats(previousStats *ContainerStats) (*Container
 		stats.NetInput = 0
 		stats.NetOutput = 0
 	}
+	if c.config.Bandwidth != nil {
+		stats.NetIngressRate = c.config.Bandwidth.IngressRate
+		stats.NetEgressRate = c.config.Bandwidth.EgressRate
+	}
 
 	return stats, nil
 }
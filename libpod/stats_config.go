@@ -15,4 +15,8 @@ type ContainerStats struct {
 	BlockInput  uint64
 	BlockOutput uint64
 	PIDs        uint64
+	// NetIngressRate and NetEgressRate are the bandwidth limits of the
+	// container's network namespace in bits per second, 0 if not limited
+	NetIngressRate uint64
+	NetEgressRate  uint64
 }
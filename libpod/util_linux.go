@@ -4,6 +4,8 @@ package libpod
 
 import (
 	"fmt"
+	"net"
+	"os"
 	"strings"
 
 	"github.com/containers/libpod/libpod/define"
@@ -96,3 +98,28 @@ func LabelVolumePath(path string, shared bool) error {
 	}
 	return nil
 }
+
+// sdNotify sends the given state to systemd, if podman runs in a systemd
+// service with a notification socket
+func sdNotify(state string) error {
+	socketPath, ok := os.LookupEnv("NOTIFY_SOCKET")
+	if !ok || socketPath == "" {
+		return nil
+	}
+
+	// Abstract sockets start with @, which is handled by net
+	socketAddr := &net.UnixAddr{
+		Name: socketPath,
+		Net:  "unixgram",
+	}
+	conn, err := net.DialUnix(socketAddr.Net, nil, socketAddr)
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to systemd notification socket %s", socketPath)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return errors.Wrapf(err, "error writing to systemd notification socket %s", socketPath)
+	}
+	return nil
+}
@@ -28,3 +28,7 @@ func assembleSystemdCgroupName(baseSlice, newSlice string) (string, error) {
 func LabelVolumePath(path string, shared bool) error {
 	return define.ErrNotImplemented
 }
+
+func sdNotify(state string) error {
+	return nil
+}
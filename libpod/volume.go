@@ -1,12 +1,38 @@
 package libpod
 
+import (
+	"github.com/containers/libpod/libpod/lock"
+	"github.com/pkg/errors"
+)
+
+// LocalVolumeDriver is the driver of volumes created in the volume path of
+// the runtime. Volumes of other drivers are managed by the volume plugin of
+// the same name.
+const LocalVolumeDriver = "local"
+
+// Options of volumes of the local driver, which are mounted with them as
+// Docker's local driver does
+const (
+	// volumeOptType is the filesystem type to mount
+	volumeOptType = "type"
+	// volumeOptDevice is the device to mount
+	volumeOptDevice = "device"
+	// volumeOptMountOpts are the mount options, as a comma separated list
+	volumeOptMountOpts = "o"
+	// volumeOptSize is the size limit of the volume. It cannot be combined
+	// with the mount options.
+	volumeOptSize = "size"
+)
+
 // Volume is the type used to create named volumes
 // TODO: all volumes should be created using this and the Volume API
 type Volume struct {
 	config *VolumeConfig
+	state  *VolumeState
 
 	valid   bool
 	runtime *Runtime
+	lock    lock.Locker
 }
 
 // VolumeConfig holds the volume's config information
@@ -22,6 +48,27 @@ type VolumeConfig struct {
 	IsCtrSpecific bool              `json:"ctrSpecific"`
 	UID           int               `json:"uid"`
 	GID           int               `json:"gid"`
+	// LockID is the ID of the volume's lock
+	LockID uint32 `json:"lockID"`
+	// Quota is the size limit of the volume in bytes, 0 if it has none
+	Quota uint64 `json:"quota,omitempty"`
+	// BackingFile is the image of the loopback filesystem enforcing the
+	// quota of the volume, if the volume path does not support XFS
+	// project quotas
+	BackingFile string `json:"backingFile,omitempty"`
+	// Plugin is whether the volume is managed by the volume plugin of its
+	// driver. Volumes created before volume plugins were supported are
+	// local, whatever driver they were given.
+	Plugin bool `json:"plugin,omitempty"`
+}
+
+// VolumeState holds the volume's mutable state.
+// Legacy volumes have no state in the database and start with an empty one.
+type VolumeState struct {
+	// MountCount is the number of running containers using the volume.
+	// Local volumes with mount options are mounted by the first of them
+	// and unmounted by the last.
+	MountCount uint `json:"mountCount"`
 }
 
 // Name retrieves the volume's name
@@ -38,16 +85,61 @@ func (v *Volume) Labels() map[string]string {
 	return labels
 }
 
-// MountPoint returns the volume's mountpoint on the host
+// MountPoint returns the mountpoint of a local volume on the host. Volumes of
+// volume plugins have none; use ResolveMountPoint to ask their plugin.
 func (v *Volume) MountPoint() string {
 	return v.config.MountPoint
 }
 
+// ResolveMountPoint returns the volume's mountpoint on the host, asking the
+// volume plugin for it if the volume has one. Volumes of volume plugins may
+// only have a mountpoint while they are mounted.
+func (v *Volume) ResolveMountPoint() (string, error) {
+	if !v.UsesVolumeDriver() {
+		return v.config.MountPoint, nil
+	}
+	p, err := v.getPlugin()
+	if err != nil {
+		return "", err
+	}
+	mountPoint, err := p.GetVolumePath(v.Name())
+	if err != nil {
+		return "", errors.Wrapf(err, "error retrieving mountpoint of volume %s", v.Name())
+	}
+	return mountPoint, nil
+}
+
 // Driver returns the volume's driver
 func (v *Volume) Driver() string {
 	return v.config.Driver
 }
 
+// UsesVolumeDriver returns whether the volume is managed by a volume plugin
+// rather than the local driver
+func (v *Volume) UsesVolumeDriver() bool {
+	return v.config.Plugin
+}
+
+// Quota returns the size limit of the volume in bytes, or 0 if it has none
+func (v *Volume) Quota() uint64 {
+	return v.config.Quota
+}
+
+// needsMount returns whether the volume has to be mounted for containers to
+// use it. Volumes of volume plugins are mounted by their plugin, and local
+// volumes when they have mount options or a loopback filesystem.
+func (v *Volume) needsMount() bool {
+	if v.UsesVolumeDriver() || v.config.BackingFile != "" {
+		return true
+	}
+	for _, key := range []string{volumeOptType, volumeOptDevice, volumeOptMountOpts} {
+		if _, ok := v.config.Options[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // Options return the volume's options
 func (v *Volume) Options() map[string]string {
 	options := make(map[string]string)
@@ -0,0 +1,234 @@
+package libpod
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/libpod/events"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/containers/libpod/pkg/util"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Usage returns the disk space used by the volume in bytes
+func (v *Volume) Usage() (uint64, error) {
+	if !v.valid {
+		return 0, define.ErrVolumeRemoved
+	}
+	return v.usage()
+}
+
+// Export writes the contents of the volume to a tar archive at the given
+// path. Files are archived with the ownership they have in the user namespace
+// of the runtime.
+// Running containers able to write to the volume are paused for the duration
+// of the export if pause is set, otherwise the export is refused. The export
+// fails if a container starts writing to the volume while it is exported.
+func (v *Volume) Export(path string, pause bool) error {
+	if !v.valid {
+		return define.ErrVolumeRemoved
+	}
+
+	writers, err := v.runningContainers(true, time.Time{})
+	if err != nil {
+		return err
+	}
+	if len(writers) > 0 {
+		if !pause {
+			return errors.Wrapf(define.ErrVolumeBeingUsed, "volume %s can be written to by running container(s) %s, pause them to export it", v.Name(), containerIDs(writers))
+		}
+		for _, ctr := range writers {
+			if err := ctr.Pause(); err != nil {
+				return errors.Wrapf(err, "error pausing container %s to export volume %s", ctr.ID(), v.Name())
+			}
+			defer func(ctr *Container) {
+				if err := ctr.Unpause(); err != nil {
+					logrus.Errorf("Error unpausing container %s after exporting volume %s: %v", ctr.ID(), v.Name(), err)
+				}
+			}(ctr)
+		}
+	}
+
+	// Containers are not kept from starting while the volume is exported,
+	// so check again for writers once the others are paused
+	exportStart := time.Now()
+	if err := v.checkUnused(true, time.Time{}); err != nil {
+		return err
+	}
+
+	mountPoint, unmount, err := v.mountForTransfer()
+	if err != nil {
+		return err
+	}
+	defer unmount()
+
+	input, err := archive.TarWithOptions(mountPoint, &archive.TarOptions{
+		Compression: archive.Uncompressed,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error reading volume %s", v.Name())
+	}
+	defer input.Close()
+
+	outFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file %q", path)
+	}
+	defer outFile.Close()
+
+	if _, err := io.Copy(outFile, input); err != nil {
+		return errors.Wrapf(err, "error exporting volume %s", v.Name())
+	}
+
+	if err := v.checkUnused(true, exportStart); err != nil {
+		// Do not leave an inconsistent archive behind, unless it was
+		// written to a device such as /dev/stdout
+		if info, err2 := os.Lstat(path); err2 == nil && info.Mode().IsRegular() {
+			if err2 := os.Remove(path); err2 != nil {
+				logrus.Errorf("Error removing export %q of volume %s: %v", path, v.Name(), err2)
+			}
+		}
+		return errors.Wrapf(err, "error exporting volume %s", v.Name())
+	}
+
+	defer v.newVolumeEvent(events.Export)
+	return nil
+}
+
+// Import extracts the tar archive at the given path, which may be compressed,
+// into the volume. The volume must be empty and not used by running
+// containers. Ownership and extended attributes are restored as far as the
+// user namespace of the runtime allows. The import fails if a container using
+// the volume starts while it is imported.
+func (v *Volume) Import(path string) error {
+	if !v.valid {
+		return define.ErrVolumeRemoved
+	}
+
+	importStart := time.Now()
+	if err := v.checkUnused(false, time.Time{}); err != nil {
+		return err
+	}
+
+	mountPoint, unmount, err := v.mountForTransfer()
+	if err != nil {
+		return err
+	}
+	defer unmount()
+
+	contents, err := ioutil.ReadDir(mountPoint)
+	if err != nil {
+		return errors.Wrapf(err, "error reading volume %s", v.Name())
+	}
+	if len(contents) > 0 {
+		return errors.Wrapf(define.ErrInvalidArg, "volume %s is not empty", v.Name())
+	}
+
+	inFile, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "error opening file %q", path)
+	}
+	defer inFile.Close()
+
+	if err := archive.Untar(inFile, mountPoint, &archive.TarOptions{
+		InUserNS: rootless.IsRootless(),
+	}); err != nil {
+		return errors.Wrapf(err, "error importing into volume %s", v.Name())
+	}
+
+	if err := v.checkUnused(false, importStart); err != nil {
+		return errors.Wrapf(err, "error importing into volume %s, its contents may be incomplete", v.Name())
+	}
+
+	defer v.newVolumeEvent(events.Import)
+	return nil
+}
+
+// mountForTransfer mounts the volume, if it needs mounting, for an export or
+// import. It returns the volume's mountpoint and a function unmounting it.
+func (v *Volume) mountForTransfer() (string, func(), error) {
+	// Volume plugins track the users of a volume by ID
+	transferID := stringid.GenerateNonCryptoID()
+	mountPoint, err := v.mount(transferID)
+	if err != nil {
+		return "", nil, err
+	}
+	unmount := func() {
+		if err := v.unmount(transferID); err != nil {
+			logrus.Errorf("Error unmounting volume %s: %v", v.Name(), err)
+		}
+	}
+	return mountPoint, unmount, nil
+}
+
+// checkUnused returns an error if containers use the volume, as returned by
+// runningContainers
+func (v *Volume) checkUnused(writers bool, since time.Time) error {
+	ctrs, err := v.runningContainers(writers, since)
+	if err != nil {
+		return err
+	}
+	if len(ctrs) > 0 {
+		return errors.Wrapf(define.ErrVolumeBeingUsed, "volume %s is being used by running container(s) %s", v.Name(), containerIDs(ctrs))
+	}
+	return nil
+}
+
+// runningContainers returns the running containers using the volume, and
+// those started after since if it is set. If writers is set, only containers
+// mounting it read-write are returned.
+func (v *Volume) runningContainers(writers bool, since time.Time) ([]*Container, error) {
+	ctrIDs, err := v.runtime.state.VolumeInUse(v)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrs := make([]*Container, 0, len(ctrIDs))
+	for _, id := range ctrIDs {
+		ctr, err := v.runtime.state.Container(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error retrieving container %s using volume %s", id, v.Name())
+		}
+		ctr.lock.Lock()
+		err = ctr.syncContainer()
+		state := ctr.state.State
+		startedTime := ctr.state.StartedTime
+		ctr.lock.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		if state != define.ContainerStateRunning && (since.IsZero() || !startedTime.After(since)) {
+			continue
+		}
+		if writers {
+			readOnly := false
+			for _, namedVol := range ctr.config.NamedVolumes {
+				if namedVol.Name == v.Name() && util.StringInSlice("ro", namedVol.Options) {
+					readOnly = true
+				}
+			}
+			if readOnly {
+				continue
+			}
+		}
+		ctrs = append(ctrs, ctr)
+	}
+	return ctrs, nil
+}
+
+// containerIDs returns the IDs of the given containers as a comma separated
+// list
+func containerIDs(ctrs []*Container) string {
+	ids := make([]string, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		ids = append(ids, ctr.ID())
+	}
+	return strings.Join(ids, ", ")
+}
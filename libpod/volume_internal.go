@@ -3,12 +3,20 @@ package libpod
 import (
 	"os"
 	"path/filepath"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/libpod/plugin"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/containers/storage/pkg/directory"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
 )
 
 // Creates a new volume
 func newVolume(runtime *Runtime) (*Volume, error) {
 	volume := new(Volume)
 	volume.config = new(VolumeConfig)
+	volume.state = new(VolumeState)
 	volume.runtime = runtime
 	volume.config.Labels = make(map[string]string)
 	volume.config.Options = make(map[string]string)
@@ -16,7 +24,142 @@ func newVolume(runtime *Runtime) (*Volume, error) {
 	return volume, nil
 }
 
-// teardownStorage deletes the volume from volumePath
+// validateLocalOptions checks that the options of a volume of the local
+// driver are mount options the driver understands
+func (v *Volume) validateLocalOptions() error {
+	for key := range v.config.Options {
+		switch key {
+		case volumeOptType, volumeOptDevice, volumeOptMountOpts, volumeOptSize:
+		default:
+			return errors.Wrapf(define.ErrInvalidArg, "invalid option %q for volume driver %s", key, LocalVolumeDriver)
+		}
+	}
+	if size, ok := v.config.Options[volumeOptSize]; ok {
+		if v.needsMount() {
+			return errors.Wrapf(define.ErrInvalidArg, "the size option cannot be combined with mount options")
+		}
+		quota, err := units.RAMInBytes(size)
+		if err != nil || quota <= 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "invalid volume size %q", size)
+		}
+		if rootless.IsRootless() {
+			return errors.Wrapf(define.ErrInvalidArg, "limiting the size of volumes requires root privileges")
+		}
+		v.config.Quota = uint64(quota)
+		return nil
+	}
+	if !v.needsMount() {
+		return nil
+	}
+	if v.config.Options[volumeOptType] == "" && v.config.Options[volumeOptDevice] == "" {
+		return errors.Wrapf(define.ErrInvalidArg, "volume options require a type or a device to mount")
+	}
+	if rootless.IsRootless() {
+		return errors.Wrapf(define.ErrInvalidArg, "mounting volumes with options requires root privileges")
+	}
+	return nil
+}
+
+// refresh retrieves the lock of the volume after a reboot, when the locks
+// of all volumes have to be allocated again
+func (v *Volume) refresh() error {
+	lock, err := v.runtime.lockManager.AllocateAndRetrieveLock(v.config.LockID)
+	if err != nil {
+		return errors.Wrapf(err, "error acquiring lock %d for volume %s", v.config.LockID, v.Name())
+	}
+	v.lock = lock
+	return nil
+}
+
+// update retrieves the latest state of the volume from the database.
+// The volume must be locked.
+func (v *Volume) update() error {
+	return v.runtime.state.UpdateVolume(v)
+}
+
+// save saves the state of the volume to the database.
+// The volume must be locked.
+func (v *Volume) save() error {
+	return v.runtime.state.SaveVolume(v)
+}
+
+// getPlugin returns the volume plugin managing the volume
+func (v *Volume) getPlugin() (*plugin.VolumePlugin, error) {
+	return plugin.GetVolumePlugin(v.config.Driver, v.runtime.config.VolumePluginDir)
+}
+
+// mount mounts the volume for the container of the given ID and returns its
+// mountpoint. Only volumes of volume plugins, which keep track of the
+// containers using them, and local volumes with mount options need mounting.
+func (v *Volume) mount(ctrID string) (string, error) {
+	if !v.needsMount() {
+		return v.config.MountPoint, nil
+	}
+	if !v.UsesVolumeDriver() {
+		return v.config.MountPoint, v.mountLocal()
+	}
+	p, err := v.getPlugin()
+	if err != nil {
+		return "", err
+	}
+	mountPoint, err := p.MountVolume(v.Name(), ctrID)
+	if err != nil {
+		return "", errors.Wrapf(err, "error mounting volume %s", v.Name())
+	}
+	return mountPoint, nil
+}
+
+// unmount tells the volume plugin of the volume that the container of the
+// given ID no longer uses it. Local volumes with mount options are unmounted
+// once no container uses them.
+func (v *Volume) unmount(ctrID string) error {
+	if !v.needsMount() {
+		return nil
+	}
+	if !v.UsesVolumeDriver() {
+		return v.unmountLocal()
+	}
+	p, err := v.getPlugin()
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(p.UnmountVolume(v.Name(), ctrID), "error unmounting volume %s", v.Name())
+}
+
+// usage returns the disk space used by the volume in bytes. The usage of
+// volumes with a loopback filesystem that is not mounted is the space
+// allocated to its image.
+func (v *Volume) usage() (uint64, error) {
+	if v.config.BackingFile != "" {
+		v.lock.Lock()
+		err := v.update()
+		mounted := v.state.MountCount > 0
+		v.lock.Unlock()
+		if err != nil {
+			return 0, err
+		}
+		if !mounted {
+			return v.backingFileUsage()
+		}
+	}
+	mountPoint, err := v.ResolveMountPoint()
+	if err != nil || mountPoint == "" {
+		// Volume plugins may only provide a mountpoint while the
+		// volume is mounted
+		return 0, err
+	}
+	size, err := directory.Size(mountPoint)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error getting size of volume %s", v.Name())
+	}
+	return uint64(size), nil
+}
+
+// teardownStorage deletes the volume from volumePath. Volumes of volume
+// plugins have no storage there.
 func (v *Volume) teardownStorage() error {
+	if v.UsesVolumeDriver() {
+		return nil
+	}
 	return os.RemoveAll(filepath.Join(v.runtime.config.VolumePath, v.Name()))
 }
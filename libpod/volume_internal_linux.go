@@ -0,0 +1,227 @@
+// +build linux
+
+package libpod
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/containers/storage/drivers/quota"
+	"github.com/containers/storage/pkg/loopback"
+	"github.com/containers/storage/pkg/mount"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// mountArgs returns the device, filesystem type and options to mount the
+// volume with, as Docker's local volume driver does
+func (v *Volume) mountArgs() (string, string, string, error) {
+	mType := v.config.Options[volumeOptType]
+	device := v.config.Options[volumeOptDevice]
+	options := v.config.Options[volumeOptMountOpts]
+	if device == "" {
+		// Filesystems without a backing device, such as tmpfs, are
+		// mounted with their type as their device
+		device = mType
+	}
+
+	// The kernel NFS client cannot resolve host names
+	if mType == "nfs" {
+		for _, opt := range strings.Split(options, ",") {
+			if !strings.HasPrefix(opt, "addr=") {
+				continue
+			}
+			addr := strings.TrimPrefix(opt, "addr=")
+			if net.ParseIP(addr) != nil {
+				break
+			}
+			ipAddr, err := net.ResolveIPAddr("ip", addr)
+			if err != nil {
+				return "", "", "", errors.Wrapf(err, "error resolving NFS server %s of volume %s", addr, v.Name())
+			}
+			options = strings.Replace(options, opt, "addr="+ipAddr.String(), 1)
+			break
+		}
+	}
+	return device, mType, options, nil
+}
+
+// mountLocal mounts a local volume with mount options on its mountpoint if
+// no running container uses it yet, and counts the containers using it
+func (v *Volume) mountLocal() error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+
+	if v.state.MountCount == 0 && v.config.BackingFile != "" {
+		if err := v.mountBackingFile(v.config.MountPoint); err != nil {
+			return err
+		}
+	} else if v.state.MountCount == 0 {
+		device, mType, options, err := v.mountArgs()
+		if err != nil {
+			return err
+		}
+		logrus.Debugf("Mounting %s of type %q with options %q on volume %s", device, mType, options, v.Name())
+		if err := mount.Mount(device, v.config.MountPoint, mType, options); err != nil {
+			return errors.Wrapf(err, "error mounting volume %s", v.Name())
+		}
+	}
+
+	v.state.MountCount++
+	if err := v.save(); err != nil {
+		v.state.MountCount--
+		if v.state.MountCount == 0 {
+			if err2 := mount.Unmount(v.config.MountPoint); err2 != nil {
+				logrus.Errorf("Error unmounting volume %s: %v", v.Name(), err2)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// unmountLocal stops counting a container using a local volume with mount
+// options, and unmounts the volume once no running container uses it
+func (v *Volume) unmountLocal() error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+
+	if v.state.MountCount == 0 {
+		logrus.Debugf("Volume %s is not mounted, not unmounting it", v.Name())
+		return nil
+	}
+
+	if v.state.MountCount == 1 {
+		logrus.Debugf("Unmounting volume %s", v.Name())
+		if err := mount.Unmount(v.config.MountPoint); err != nil {
+			return errors.Wrapf(err, "error unmounting volume %s", v.Name())
+		}
+	}
+
+	v.state.MountCount--
+	return v.save()
+}
+
+// unmountLocalAll unmounts a local volume regardless of the containers
+// counted as using it, so that its directory can be removed
+func (v *Volume) unmountLocalAll() error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+
+	if err := mount.Unmount(v.config.MountPoint); err != nil {
+		return errors.Wrapf(err, "error unmounting volume %s", v.Name())
+	}
+
+	if v.state.MountCount == 0 {
+		return nil
+	}
+	v.state.MountCount = 0
+	return v.save()
+}
+
+// setupQuota limits the size of the volume stored in dir to its quota. An
+// XFS project quota is set on dir if the volume path supports them, otherwise
+// the volume gets a loopback filesystem of the size of its quota.
+func (v *Volume) setupQuota(dir string) error {
+	control, err := quota.NewControl(v.runtime.config.VolumePath)
+	if err == nil {
+		if err := control.SetQuota(dir, quota.Quota{Size: v.config.Quota}); err != nil {
+			return errors.Wrapf(err, "error setting quota of volume %s", v.Name())
+		}
+		return nil
+	}
+	logrus.Debugf("Volume path %s does not support XFS project quotas, using a loopback filesystem for volume %s: %v", v.runtime.config.VolumePath, v.Name(), err)
+
+	backingFile := filepath.Join(dir, "volume.img")
+	file, err := os.OpenFile(backingFile, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error creating backing file of volume %s", v.Name())
+	}
+	err = file.Truncate(int64(v.config.Quota))
+	file.Close()
+	if err != nil {
+		return errors.Wrapf(err, "error resizing backing file of volume %s", v.Name())
+	}
+
+	rootOwner := fmt.Sprintf("root_owner=%d:%d", v.config.UID, v.config.GID)
+	if output, err := exec.Command("mkfs.ext4", "-q", "-F", "-E", rootOwner, backingFile).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "error creating filesystem of volume %s: %s", v.Name(), strings.TrimSpace(string(output)))
+	}
+	v.config.BackingFile = backingFile
+
+	// Remove the lost+found directory created by mkfs, so that the volume
+	// starts out empty, and give the root of the filesystem the SELinux
+	// label of volumes, as the volume directory it is mounted over has
+	tmpMount, err := ioutil.TempDir(dir, "mnt")
+	if err != nil {
+		return errors.Wrapf(err, "error creating temporary mountpoint of volume %s", v.Name())
+	}
+	defer os.Remove(tmpMount)
+	if err := v.mountBackingFile(tmpMount); err != nil {
+		return err
+	}
+	err = v.prepareBackingFileRoot(tmpMount)
+	if err2 := mount.Unmount(tmpMount); err2 != nil {
+		return errors.Wrapf(err2, "error unmounting volume %s", v.Name())
+	}
+	return err
+}
+
+// prepareBackingFileRoot empties and labels the root of the new loopback
+// filesystem of the volume, mounted on mountPoint
+func (v *Volume) prepareBackingFileRoot(mountPoint string) error {
+	if err := os.Remove(filepath.Join(mountPoint, "lost+found")); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error removing lost+found directory of volume %s", v.Name())
+	}
+	return LabelVolumePath(mountPoint, true)
+}
+
+// mountBackingFile mounts the loopback filesystem of the volume on target.
+// The loop device is released when the filesystem is unmounted.
+func (v *Volume) mountBackingFile(target string) error {
+	loop, err := loopback.AttachLoopDevice(v.config.BackingFile)
+	if err != nil {
+		return errors.Wrapf(err, "error attaching backing file of volume %s to a loop device", v.Name())
+	}
+	defer loop.Close()
+
+	logrus.Debugf("Mounting %s on %s for volume %s", loop.Name(), target, v.Name())
+	if err := mount.Mount(loop.Name(), target, "ext4", ""); err != nil {
+		return errors.Wrapf(err, "error mounting volume %s", v.Name())
+	}
+	return nil
+}
+
+// backingFileUsage returns the disk space allocated to the backing file of
+// the volume, which is an upper bound of the space used by its contents
+func (v *Volume) backingFileUsage() (uint64, error) {
+	info, err := os.Stat(v.config.BackingFile)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error getting size of volume %s", v.Name())
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return uint64(info.Size()), nil
+	}
+	// Blocks are counted in units of 512 bytes regardless of the
+	// filesystem's block size
+	return uint64(stat.Blocks) * 512, nil
+}
@@ -0,0 +1,27 @@
+// +build !linux
+
+package libpod
+
+import (
+	"github.com/containers/libpod/libpod/define"
+)
+
+func (v *Volume) mountLocal() error {
+	return define.ErrNotImplemented
+}
+
+func (v *Volume) unmountLocal() error {
+	return define.ErrNotImplemented
+}
+
+func (v *Volume) unmountLocalAll() error {
+	return define.ErrNotImplemented
+}
+
+func (v *Volume) setupQuota(dir string) error {
+	return define.ErrNotImplemented
+}
+
+func (v *Volume) backingFileUsage() (uint64, error) {
+	return 0, define.ErrNotImplemented
+}
@@ -0,0 +1,24 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsesVolumeDriverLegacyConfig(t *testing.T) {
+	// Volumes created before volume plugins were supported may have any
+	// driver, but are local volumes
+	config := new(VolumeConfig)
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"legacy","driver":"foo","mountPoint":"/vol/legacy/_data"}`), config))
+	volume := &Volume{config: config}
+	assert.False(t, volume.UsesVolumeDriver())
+
+	mountPoint, err := volume.ResolveMountPoint()
+	assert.NoError(t, err)
+	assert.Equal(t, "/vol/legacy/_data", mountPoint)
+
+	config.Plugin = true
+	assert.True(t, volume.UsesVolumeDriver())
+}
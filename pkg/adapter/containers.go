@@ -586,6 +586,23 @@ func (r *LocalRuntime) Start(ctx context.Context, c *cliconfig.StartValues, sigP
 		}
 		args = append(args, lastCtr.ID())
 	}
+	if c.All {
+		filterFuncs, err := shared.GenerateContainerFilterFuncs(c.Filter, r.Runtime)
+		if err != nil {
+			return exitCode, err
+		}
+		ctrs, err := r.GetContainers(filterFuncs...)
+		if err != nil {
+			return exitCode, err
+		}
+		// Having no containers to start is not an error
+		if len(ctrs) == 0 {
+			return 0, nil
+		}
+		for _, ctr := range ctrs {
+			args = append(args, ctr.ID())
+		}
+	}
 
 	for _, container := range args {
 		ctr, err := r.LookupContainer(container)
@@ -1092,28 +1109,179 @@ func (r *LocalRuntime) Port(c *cliconfig.PortValues) ([]*Container, error) {
 	return portContainers, nil
 }
 
-// GenerateSystemd creates a unit file for a container
-func (r *LocalRuntime) GenerateSystemd(c *cliconfig.GenerateSystemdValues) (string, error) {
+// GenerateSystemd creates the systemd unit of a container, or the units of a
+// pod and all of its containers
+func (r *LocalRuntime) GenerateSystemd(c *cliconfig.GenerateSystemdValues) ([]systemdgen.Unit, error) {
 	ctr, err := r.Runtime.LookupContainer(c.InputArgs[0])
+	if err == nil {
+		unit, err := generateContainerSystemdUnit(ctr, nil, nil, c)
+		if err != nil {
+			return nil, err
+		}
+		units := []systemdgen.Unit{*unit}
+		if len(c.Sockets) > 0 {
+			info, err := systemdgen.NewSocketInfo(systemdName(ctr.ID(), ctr.Name(), c.Name), c.Sockets)
+			if err != nil {
+				return nil, err
+			}
+			content, err := systemdgen.CreateSocketSystemdUnit(info)
+			if err != nil {
+				return nil, err
+			}
+			units = append(units, systemdgen.Unit{ServiceName: unit.ServiceName, Type: systemdgen.UnitTypeSocket, Content: content})
+		}
+		return units, nil
+	}
+	if errors.Cause(err) != define.ErrNoSuchCtr {
+		return nil, err
+	}
+
+	pod, err := r.Runtime.LookupPod(c.InputArgs[0])
 	if err != nil {
-		return "", err
+		return nil, errors.Wrapf(err, "%s does not refer to a container or pod", c.InputArgs[0])
+	}
+	if c.New {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "--new is only supported for containers")
+	}
+	if len(c.Sockets) > 0 {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "--socket is only supported for containers")
 	}
+	return r.generatePodSystemdUnits(pod, c)
+}
+
+// generatePodSystemdUnits creates the unit of the pod, which controls its
+// infra container, followed by the units of the pod's containers in the order
+// of their dependencies
+func (r *LocalRuntime) generatePodSystemdUnits(pod *libpod.Pod, c *cliconfig.GenerateSystemdValues) ([]systemdgen.Unit, error) {
+	if !pod.HasInfraContainer() {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "pod %s has no infra container, which is required to generate its systemd units", pod.ID())
+	}
+	infraID, err := pod.InfraContainerID()
+	if err != nil {
+		return nil, err
+	}
+	infra, err := r.Runtime.LookupContainer(infraID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving infra container of pod %s", pod.ID())
+	}
+	ctrs, err := pod.ContainersByDependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	podService := systemdgen.PodServiceName(systemdName(pod.ID(), pod.Name(), c.Name))
+	services := make(map[string]string, len(ctrs))
+	for _, ctr := range ctrs {
+		if ctr.ID() == infraID {
+			continue
+		}
+		services[ctr.ID()] = systemdgen.ContainerServiceName(systemdName(ctr.ID(), ctr.Name(), c.Name))
+	}
+
+	units := make([]systemdgen.Unit, 0, len(ctrs))
+	ctrServices := make([]string, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		if ctr.ID() == infraID {
+			continue
+		}
+		var requires []string
+		for _, dep := range ctr.Dependencies() {
+			// The infra container is covered by the pod's unit
+			if service, ok := services[dep]; ok {
+				requires = append(requires, service)
+			}
+		}
+		unit, err := generateContainerSystemdUnit(ctr, []string{podService}, requires, c)
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, *unit)
+		ctrServices = append(ctrServices, unit.ServiceName)
+	}
+
+	pidFile, timeout, err := systemdContainerSettings(infra, c)
+	if err != nil {
+		return nil, err
+	}
+	info := systemdgen.PodInfo{
+		PodName:          systemdName(pod.ID(), pod.Name(), c.Name),
+		InfraName:        systemdName(infra.ID(), infra.Name(), c.Name),
+		RestartPolicy:    c.RestartPolicy,
+		PIDFile:          pidFile,
+		StopTimeout:      timeout,
+		RequiredServices: ctrServices,
+	}
+	content, err := systemdgen.CreatePodSystemdUnit(&info)
+	if err != nil {
+		return nil, err
+	}
+	podUnit := systemdgen.Unit{ServiceName: podService, Type: systemdgen.UnitTypeService, Content: content}
+
+	return append([]systemdgen.Unit{podUnit}, units...), nil
+}
+
+// generateContainerSystemdUnit creates the unit of a container, bound to and
+// requiring the given services
+func generateContainerSystemdUnit(ctr *libpod.Container, boundTo, requires []string, c *cliconfig.GenerateSystemdValues) (*systemdgen.Unit, error) {
+	name := systemdName(ctr.ID(), ctr.Name(), c.Name)
+	info := systemdgen.ContainerInfo{
+		ContainerName:    name,
+		RestartPolicy:    c.RestartPolicy,
+		BoundToServices:  boundTo,
+		RequiredServices: requires,
+		Notify:           ctr.SdNotifyMode() == define.SdNotifyModeContainer,
+	}
+	if len(c.Sockets) > 0 {
+		info.SocketName = systemdgen.ContainerServiceName(name)
+	}
+	if c.New {
+		// The ID changes with every new container, only the name is kept
+		createCommand := ctr.CreateCommand()
+		if len(createCommand) == 0 {
+			return nil, errors.Errorf("container %s was not created from the command line and cannot be recreated by a unit", ctr.ID())
+		}
+		info.ContainerName = ctr.Name()
+		info.CreateCommand = createCommand
+		info.StopTimeout = int(ctr.StopTimeout())
+		if c.StopTimeout >= 0 {
+			info.StopTimeout = c.StopTimeout
+		}
+	} else {
+		pidFile, timeout, err := systemdContainerSettings(ctr, c)
+		if err != nil {
+			return nil, err
+		}
+		info.PIDFile = pidFile
+		info.StopTimeout = timeout
+	}
+	content, err := systemdgen.CreateContainerSystemdUnit(&info)
+	if err != nil {
+		return nil, err
+	}
+	return &systemdgen.Unit{ServiceName: systemdgen.ContainerServiceName(name), Type: systemdgen.UnitTypeService, Content: content}, nil
+}
+
+// systemdContainerSettings returns the conmon PID file and the stop timeout
+// to use in the systemd unit of a container
+func systemdContainerSettings(ctr *libpod.Container, c *cliconfig.GenerateSystemdValues) (string, int, error) {
 	timeout := int(ctr.StopTimeout())
 	if c.StopTimeout >= 0 {
 		timeout = c.StopTimeout
 	}
-	name := ctr.ID()
-	if c.Name {
-		name = ctr.Name()
-	}
-
-	config := ctr.Config()
-	conmonPidFile := config.ConmonPidFile
+	conmonPidFile := ctr.Config().ConmonPidFile
 	if conmonPidFile == "" {
-		return "", errors.Errorf("conmon PID file path is empty, try to recreate the container with --conmon-pidfile flag")
+		return "", 0, errors.Errorf("conmon PID file path of container %s is empty, try to recreate the container with --conmon-pidfile flag", ctr.ID())
 	}
+	return conmonPidFile, timeout, nil
+}
 
-	return systemdgen.CreateSystemdUnitAsString(name, ctr.ID(), c.RestartPolicy, conmonPidFile, timeout)
+// systemdName returns the name to refer to a container or pod with in its
+// systemd unit
+func systemdName(id, name string, useName bool) string {
+	if useName {
+		return name
+	}
+	return id
 }
 
 // GetNamespaces returns namespace information about a container for PS
@@ -20,6 +20,7 @@ import (
 	"github.com/containers/libpod/libpod"
 	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/libpod/logs"
+	"github.com/containers/libpod/pkg/systemdgen"
 	"github.com/containers/libpod/pkg/varlinkapi/virtwriter"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/docker/docker/pkg/term"
@@ -516,6 +517,10 @@ func (r *LocalRuntime) Ps(c *cliconfig.PsValues, opts shared.PsOptions) ([]share
 		if err != nil {
 			return nil, err
 		}
+		lastRestart, err := time.Parse(time.RFC3339Nano, ctr.LastRestart)
+		if err != nil {
+			return nil, err
+		}
 		containerSize := shared.ContainerSize{
 			RootFsSize: ctr.RootFsSize,
 			RwSize:     ctr.RwSize,
@@ -525,31 +530,33 @@ func (r *LocalRuntime) Ps(c *cliconfig.PsValues, opts shared.PsOptions) ([]share
 			return nil, err
 		}
 		psc := shared.PsContainerOutput{
-			ID:        ctr.Id,
-			Image:     ctr.Image,
-			Command:   ctr.Command,
-			Created:   ctr.Created,
-			Ports:     ctr.Ports,
-			Names:     ctr.Names,
-			IsInfra:   ctr.IsInfra,
-			Status:    ctr.Status,
-			State:     state,
-			Pid:       int(ctr.PidNum),
-			Size:      &containerSize,
-			Pod:       ctr.Pod,
-			CreatedAt: createdAt,
-			ExitedAt:  exitedAt,
-			StartedAt: startedAt,
-			Labels:    ctr.Labels,
-			PID:       ctr.NsPid,
-			Cgroup:    ctr.Cgroup,
-			IPC:       ctr.Ipc,
-			MNT:       ctr.Mnt,
-			NET:       ctr.Net,
-			PIDNS:     ctr.PidNs,
-			User:      ctr.User,
-			UTS:       ctr.Uts,
-			Mounts:    ctr.Mounts,
+			ID:          ctr.Id,
+			Image:       ctr.Image,
+			Command:     ctr.Command,
+			Created:     ctr.Created,
+			Ports:       ctr.Ports,
+			Names:       ctr.Names,
+			IsInfra:     ctr.IsInfra,
+			Status:      ctr.Status,
+			State:       state,
+			Pid:         int(ctr.PidNum),
+			Size:        &containerSize,
+			Pod:         ctr.Pod,
+			CreatedAt:   createdAt,
+			ExitedAt:    exitedAt,
+			StartedAt:   startedAt,
+			Labels:      ctr.Labels,
+			PID:         ctr.NsPid,
+			Cgroup:      ctr.Cgroup,
+			IPC:         ctr.Ipc,
+			MNT:         ctr.Mnt,
+			NET:         ctr.Net,
+			PIDNS:       ctr.PidNs,
+			User:        ctr.User,
+			UTS:         ctr.Uts,
+			Mounts:      ctr.Mounts,
+			Restarts:    uint(ctr.Restarts),
+			LastRestart: lastRestart,
 		}
 		psContainers = append(psContainers, psc)
 	}
@@ -671,6 +678,9 @@ func (r *LocalRuntime) Start(ctx context.Context, c *cliconfig.StartValues, sigP
 		finalErr error
 		exitCode = 125
 	)
+	if c.All || len(c.Filter) > 0 {
+		return exitCode, errors.Wrapf(define.ErrNotImplemented, "starting all containers is not supported by the remote client")
+	}
 	// TODO Figure out how to deal with exit codes
 	inputStream := os.Stdin
 	if !c.Interactive {
@@ -950,8 +960,22 @@ func (r *LocalRuntime) Port(c *cliconfig.PortValues) ([]*Container, error) {
 }
 
 // GenerateSystemd creates a systemd until for a container
-func (r *LocalRuntime) GenerateSystemd(c *cliconfig.GenerateSystemdValues) (string, error) {
-	return iopodman.GenerateSystemd().Call(r.Conn, c.InputArgs[0], c.RestartPolicy, int64(c.StopTimeout), c.Name)
+func (r *LocalRuntime) GenerateSystemd(c *cliconfig.GenerateSystemdValues) ([]systemdgen.Unit, error) {
+	if c.New {
+		return nil, errors.Wrapf(define.ErrNotImplemented, "--new")
+	}
+	if len(c.Sockets) > 0 {
+		return nil, errors.Wrapf(define.ErrNotImplemented, "--socket")
+	}
+	content, err := iopodman.GenerateSystemd().Call(r.Conn, c.InputArgs[0], c.RestartPolicy, int64(c.StopTimeout), c.Name)
+	if err != nil {
+		// the varlink call only knows about containers
+		if _, podErr := r.LookupPod(c.InputArgs[0]); podErr == nil {
+			return nil, errors.Wrapf(define.ErrNotImplemented, "generating systemd units for pod %s is not supported by the remote client", c.InputArgs[0])
+		}
+		return nil, err
+	}
+	return []systemdgen.Unit{{ServiceName: systemdgen.ContainerServiceName(c.InputArgs[0]), Type: systemdgen.UnitTypeService, Content: content}}, nil
 }
 
 // GetNamespaces returns namespace information about a container for PS
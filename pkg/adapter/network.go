@@ -0,0 +1,195 @@
+// +build !remoteclient
+
+package adapter
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/pkg/network"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/containers/libpod/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// NetworkList returns the CNI networks known to podman
+func (r *LocalRuntime) NetworkList(c *cliconfig.NetworkListValues) ([]*network.Network, error) {
+	config, err := r.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return network.LoadNetworks(config.CNIConfigDir)
+}
+
+// NetworkCreate creates a CNI network
+func (r *LocalRuntime) NetworkCreate(c *cliconfig.NetworkCreateValues) (*network.Network, error) {
+	config, err := r.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	if len(c.InputArgs) > 1 {
+		return nil, errors.Errorf("too many arguments, create takes at most 1 argument")
+	}
+
+	options := network.CreateOptions{
+		Driver:  c.Driver,
+		Gateway: c.Gateway,
+		Options: make(map[string]string),
+	}
+	if len(c.InputArgs) > 0 {
+		options.Name = c.InputArgs[0]
+	}
+	if c.Network.IP != nil {
+		options.Subnet = &c.Network
+	}
+	if c.IPRange.IP != nil {
+		options.IPRange = &c.IPRange
+	}
+	if !c.DisableDNS && options.Driver != network.MacVLANDriver {
+		if network.HasDNSNamePlugin(config.CNIPluginDir) {
+			options.DNS = true
+		} else {
+			logrus.Warnf("dnsname CNI plugin not found in %s, containers on network cannot resolve each other by name", strings.Join(config.CNIPluginDir, ", "))
+		}
+	}
+	for _, opt := range c.Opt {
+		split := strings.SplitN(opt, "=", 2)
+		if len(split) != 2 {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "options must be in the form of key=value: %s is invalid", opt)
+		}
+		options.Options[split[0]] = split[1]
+	}
+	if rootless.IsRootless() {
+		// Rootless networks live in the rootless network namespace, which
+		// has no access to the interfaces of the host
+		if options.Driver == network.MacVLANDriver {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "the macvlan driver is not supported in rootless mode")
+		}
+		// The default IPAM directory is not writable by the user
+		runtimeDir, err := util.GetRootlessRuntimeDir()
+		if err != nil {
+			return nil, err
+		}
+		options.IPAMDataDir = filepath.Join(runtimeDir, "cni", "networks")
+	}
+	return network.CreateNetwork(config.CNIConfigDir, options)
+}
+
+// NetworkConnect connects a container to a CNI network
+func (r *LocalRuntime) NetworkConnect(c *cliconfig.NetworkConnectValues) error {
+	if len(c.InputArgs) != 2 {
+		return errors.Errorf("network connect requires a network and a container")
+	}
+	ctr, err := r.LookupContainer(c.InputArgs[1])
+	if err != nil {
+		return err
+	}
+	return ctr.NetworkConnect(c.InputArgs[0])
+}
+
+// NetworkDisconnect disconnects a container from a CNI network
+func (r *LocalRuntime) NetworkDisconnect(c *cliconfig.NetworkDisconnectValues) error {
+	if len(c.InputArgs) != 2 {
+		return errors.Errorf("network disconnect requires a network and a container")
+	}
+	ctr, err := r.LookupContainer(c.InputArgs[1])
+	if err != nil {
+		return err
+	}
+	return ctr.NetworkDisconnect(c.InputArgs[0])
+}
+
+// NetworkInspect returns the configurations of the given CNI networks
+func (r *LocalRuntime) NetworkInspect(c *cliconfig.NetworkInspectValues) ([]map[string]interface{}, error) {
+	config, err := r.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	rawConfigs := make([]map[string]interface{}, 0, len(c.InputArgs))
+	for _, name := range c.InputArgs {
+		n, err := network.LoadNetwork(config.CNIConfigDir, name)
+		if err != nil {
+			return nil, err
+		}
+		rawConfig, err := n.RawConfig()
+		if err != nil {
+			return nil, err
+		}
+		rawConfigs = append(rawConfigs, rawConfig)
+	}
+	return rawConfigs, nil
+}
+
+// NetworkRemove removes the given CNI networks. Networks in use by containers
+// and the default network are not removed.
+func (r *LocalRuntime) NetworkRemove(c *cliconfig.NetworkRmValues) ([]string, map[string]error, error) {
+	var (
+		ok       = []string{}
+		failures = map[string]error{}
+	)
+
+	config, err := r.GetConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Keep containers from being connected to the networks between
+	// checking that they are unused and removing them
+	unlock, err := network.LockNetworks(config.CNIConfigDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+	networks, err := network.LoadNetworks(config.CNIConfigDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	defaultNetwork := config.CNIDefaultNetwork
+	if defaultNetwork == "" && len(networks) > 0 && !rootless.IsRootless() {
+		// Without a configured default, CNI uses the first network.
+		// Rootless containers use slirp4netns by default, so rootless
+		// users have no default network.
+		defaultNetwork = networks[0].Name
+	}
+	ctrs, err := r.GetAllContainers()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, name := range c.InputArgs {
+		if name == defaultNetwork {
+			failures[name] = errors.Wrapf(define.ErrInvalidArg, "%s is the default network and cannot be removed", name)
+			continue
+		}
+		var (
+			users    []string
+			usersErr error
+		)
+		for _, ctr := range ctrs {
+			ctrNetworks, err := ctr.Networks()
+			if err != nil {
+				usersErr = errors.Wrapf(err, "error getting the networks of container %s", ctr.ID())
+				break
+			}
+			if util.StringInSlice(name, ctrNetworks) {
+				users = append(users, ctr.ID())
+			}
+		}
+		if usersErr != nil {
+			failures[name] = usersErr
+			continue
+		}
+		if len(users) > 0 {
+			failures[name] = errors.Wrapf(define.ErrNetworkBeingUsed, "network %s is used by containers %s", name, strings.Join(users, ", "))
+			continue
+		}
+		if err := network.RemoveNetwork(config.CNIConfigDir, name); err != nil {
+			failures[name] = err
+			continue
+		}
+		ok = append(ok, name)
+	}
+	return ok, failures, nil
+}
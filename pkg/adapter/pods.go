@@ -4,10 +4,13 @@ package adapter
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/containers/buildah/pkg/parse"
@@ -15,6 +18,7 @@ import (
 	"github.com/containers/libpod/cmd/podman/cliconfig"
 	"github.com/containers/libpod/cmd/podman/shared"
 	"github.com/containers/libpod/libpod"
+	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/libpod/image"
 	"github.com/containers/libpod/pkg/adapter/shortcuts"
 	ns "github.com/containers/libpod/pkg/namespaces"
@@ -32,6 +36,16 @@ const (
 	createDirectoryPermission = 0755
 	// https://kubernetes.io/docs/concepts/storage/volumes/#hostpath
 	createFilePermission = 0644
+
+	// kubePlayNameLabel is set on pods created by play kube and holds the
+	// name of the pod in the YAML they were created from
+	kubePlayNameLabel = "io.podman.play-kube.name"
+	// kubePlayFileLabel holds the absolute path of the YAML file the pod was
+	// created from, so pods of the same name from other files are left alone
+	kubePlayFileLabel = "io.podman.play-kube.file"
+	// kubePlayCreatedPathsLabel holds a JSON encoded list of the HostPath
+	// volumes that play kube created, so they can be removed on teardown
+	kubePlayCreatedPathsLabel = "io.podman.play-kube.created-paths"
 )
 
 // PodContainerStats is struct containing an adapter Pod and a libpod
@@ -262,6 +276,19 @@ func (r *LocalRuntime) CreatePod(ctx context.Context, cli *cliconfig.PodCreateVa
 			return "", err
 		}
 		options = append(options, nsOptions...)
+		netOptions, err := shared.GetPodNetworkOptions(shared.PodNetworkOptions{
+			Network:      cli.Network,
+			IP:           cli.IP,
+			Hostname:     cli.Hostname,
+			DNS:          cli.DNS,
+			DNSSearch:    cli.DNSSearch,
+			AddHost:      cli.AddHost,
+			NetworkAlias: cli.NetworkAlias,
+		})
+		if err != nil {
+			return "", err
+		}
+		options = append(options, netOptions...)
 	}
 
 	if len(cli.Publish) > 0 {
@@ -446,6 +473,7 @@ func (r *LocalRuntime) GetStatPods(c *cliconfig.PodStatsValues) ([]*Pod, error)
 func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayValues, yamlFile string) (*Pod, error) {
 	var (
 		containers    []*libpod.Container
+		createdPaths  []string
 		pod           *libpod.Pod
 		podOptions    []libpod.PodCreateOption
 		podYAML       v1.Pod
@@ -453,81 +481,29 @@ func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayVa
 		writer        io.Writer
 	)
 
-	content, err := ioutil.ReadFile(yamlFile)
-	if err != nil {
+	if err := readKubeYAML(yamlFile, &podYAML); err != nil {
 		return nil, err
 	}
-
-	if err := yaml.Unmarshal(content, &podYAML); err != nil {
-		return nil, errors.Wrapf(err, "unable to read %s as YAML", yamlFile)
+	yamlPath, err := filepath.Abs(yamlFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to resolve the path of %s", yamlFile)
 	}
 
+	// init containers are created first, in the order they are listed,
+	// so the pod runs them in that order
+	kubeContainers := make([]v1.Container, 0, len(podYAML.Spec.InitContainers)+len(podYAML.Spec.Containers))
+	kubeContainers = append(kubeContainers, podYAML.Spec.InitContainers...)
+	kubeContainers = append(kubeContainers, podYAML.Spec.Containers...)
+
 	// check for name collision between pod and container
 	podName := podYAML.ObjectMeta.Name
-	for _, n := range podYAML.Spec.Containers {
+	for _, n := range kubeContainers {
 		if n.Name == podName {
 			fmt.Printf("a container exists with the same name (%s) as the pod in your YAML file; changing pod name to %s_pod\n", podName, podName)
 			podName = fmt.Sprintf("%s_pod", podName)
 		}
 	}
 
-	podOptions = append(podOptions, libpod.WithInfraContainer())
-	podOptions = append(podOptions, libpod.WithPodName(podName))
-	// TODO for now we just used the default kernel namespaces; we need to add/subtract this from yaml
-
-	nsOptions, err := shared.GetNamespaceOptions(strings.Split(shared.DefaultKernelNamespaces, ","))
-	if err != nil {
-		return nil, err
-	}
-	podOptions = append(podOptions, nsOptions...)
-	podPorts := getPodPorts(podYAML.Spec.Containers)
-	podOptions = append(podOptions, libpod.WithInfraContainerPorts(podPorts))
-
-	// Create the Pod
-	pod, err = r.NewPod(ctx, podOptions...)
-	if err != nil {
-		return nil, err
-	}
-
-	podInfraID, err := pod.InfraContainerID()
-	if err != nil {
-		return nil, err
-	}
-	hasUserns := false
-	if podInfraID != "" {
-		podCtr, err := r.GetContainer(podInfraID)
-		if err != nil {
-			return nil, err
-		}
-		mappings, err := podCtr.IDMappings()
-		if err != nil {
-			return nil, err
-		}
-		hasUserns = len(mappings.UIDMap) > 0
-	}
-
-	namespaces := map[string]string{
-		// Disabled during code review per mheon
-		//"pid":  fmt.Sprintf("container:%s", podInfraID),
-		"net": fmt.Sprintf("container:%s", podInfraID),
-		"ipc": fmt.Sprintf("container:%s", podInfraID),
-		"uts": fmt.Sprintf("container:%s", podInfraID),
-	}
-	if hasUserns {
-		namespaces["user"] = fmt.Sprintf("container:%s", podInfraID)
-	}
-	if !c.Quiet {
-		writer = os.Stderr
-	}
-
-	dockerRegistryOptions := image.DockerRegistryOptions{
-		DockerRegistryCreds: registryCreds,
-		DockerCertPath:      c.CertDir,
-	}
-	if c.Flag("tls-verify").Changed {
-		dockerRegistryOptions.DockerInsecureSkipTLSVerify = types.NewOptionalBool(!c.TlsVerify)
-	}
-
 	// map from name to mount point
 	volumes := make(map[string]string)
 	for _, volume := range podYAML.Spec.Volumes {
@@ -542,6 +518,7 @@ func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayVa
 					if err := os.Mkdir(hostPath.Path, createDirectoryPermission); err != nil {
 						return nil, errors.Errorf("Error creating HostPath %s at %s", volume.Name, hostPath.Path)
 					}
+					createdPaths = append(createdPaths, hostPath.Path)
 				}
 				// unconditionally label a newly created volume as private
 				if err := libpod.LabelVolumePath(hostPath.Path, false); err != nil {
@@ -556,6 +533,7 @@ func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayVa
 					if err := f.Close(); err != nil {
 						logrus.Warnf("Error in closing newly created HostPath file: %v", err)
 					}
+					createdPaths = append(createdPaths, hostPath.Path)
 				}
 				// unconditionally label a newly created volume as private
 				if err := libpod.LabelVolumePath(hostPath.Path, false); err != nil {
@@ -577,7 +555,94 @@ func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayVa
 		volumes[volume.Name] = hostPath.Path
 	}
 
-	for _, container := range podYAML.Spec.Containers {
+	// label the pod so that play kube --down can find it and the volumes
+	// it created again
+	podLabels := map[string]string{
+		kubePlayNameLabel: podYAML.ObjectMeta.Name,
+		kubePlayFileLabel: yamlPath,
+	}
+	if len(createdPaths) > 0 {
+		paths, err := json.Marshal(createdPaths)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to encode the created HostPath volumes")
+		}
+		podLabels[kubePlayCreatedPathsLabel] = string(paths)
+	}
+
+	podOptions = append(podOptions, libpod.WithInfraContainer())
+	podOptions = append(podOptions, libpod.WithPodName(podName))
+	podOptions = append(podOptions, libpod.WithPodLabels(podLabels))
+
+	sharedNamespaces, err := kubePodSharedNamespaces(podYAML.Spec)
+	if err != nil {
+		return nil, err
+	}
+	nsOptions, err := shared.GetNamespaceOptions(sharedNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	podOptions = append(podOptions, nsOptions...)
+	podOptions = append(podOptions, kubePodInfraOptions(podYAML.Spec)...)
+	if podYAML.Spec.HostNetwork {
+		logrus.Debug("Port bindings are ignored for pods using the host network")
+	} else {
+		podPorts := getPodPorts(podYAML.Spec.Containers)
+		podOptions = append(podOptions, libpod.WithInfraContainerPorts(podPorts))
+	}
+
+	// Create the Pod
+	pod, err = r.NewPod(ctx, podOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	podInfraID, err := pod.InfraContainerID()
+	if err != nil {
+		return nil, err
+	}
+	hasUserns := false
+	if podInfraID != "" {
+		podCtr, err := r.GetContainer(podInfraID)
+		if err != nil {
+			return nil, err
+		}
+		mappings, err := podCtr.IDMappings()
+		if err != nil {
+			return nil, err
+		}
+		hasUserns = len(mappings.UIDMap) > 0
+	}
+
+	namespaces := make(map[string]string)
+	for _, nsType := range sharedNamespaces {
+		if nsType == "cgroup" {
+			continue
+		}
+		namespaces[nsType] = fmt.Sprintf("container:%s", podInfraID)
+	}
+	if podYAML.Spec.HostPID {
+		namespaces["pid"] = "host"
+	}
+	if podYAML.Spec.HostIPC {
+		namespaces["ipc"] = "host"
+	}
+	if hasUserns {
+		namespaces["user"] = fmt.Sprintf("container:%s", podInfraID)
+	}
+	if !c.Quiet {
+		writer = os.Stderr
+	}
+
+	dockerRegistryOptions := image.DockerRegistryOptions{
+		DockerRegistryCreds: registryCreds,
+		DockerCertPath:      c.CertDir,
+	}
+	if c.Flag("tls-verify").Changed {
+		dockerRegistryOptions.DockerInsecureSkipTLSVerify = types.NewOptionalBool(!c.TlsVerify)
+	}
+
+	numInitCtrs := len(podYAML.Spec.InitContainers)
+	for i, container := range kubeContainers {
 		newImage, err := r.ImageRuntime().New(ctx, container.Image, c.SignaturePolicy, c.Authfile, writer, &dockerRegistryOptions, image.SigningOptions{}, false, nil)
 		if err != nil {
 			return nil, err
@@ -586,6 +651,7 @@ func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayVa
 		if err != nil {
 			return nil, err
 		}
+		createConfig.IsInitCtr = i < numInitCtrs
 		ctr, err := shared.CreateContainerFromCreateConfig(r.Runtime, createConfig, ctx, pod)
 		if err != nil {
 			return nil, err
@@ -593,13 +659,16 @@ func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayVa
 		containers = append(containers, ctr)
 	}
 
-	// start the containers
-	for _, ctr := range containers {
-		if err := ctr.Start(ctx, true); err != nil {
-			// Making this a hard failure here to avoid a mess
-			// the other containers are in created status
-			return nil, err
+	// start the pod, which runs the init containers to completion before
+	// starting the other containers
+	ctrErrs, err := pod.Start(ctx)
+	if err != nil {
+		// Making this a hard failure here to avoid a mess
+		// the other containers are in created status
+		for id, ctrErr := range ctrErrs {
+			logrus.Errorf("error starting container %s: %v", id, ctrErr)
 		}
+		return nil, err
 	}
 
 	// We've now successfully converted this YAML into a pod
@@ -621,6 +690,85 @@ func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayVa
 	return nil, nil
 }
 
+// PlayKubeDown stops and removes the pods created by a previous play kube of
+// the given YAML file, along with any HostPath volumes that were created for them
+func (r *LocalRuntime) PlayKubeDown(ctx context.Context, c *cliconfig.KubePlayValues, yamlFile string) ([]string, error) {
+	var (
+		podYAML v1.Pod
+		podIDs  []string
+		lastErr error
+	)
+
+	if err := readKubeYAML(yamlFile, &podYAML); err != nil {
+		return nil, err
+	}
+	yamlPath, err := filepath.Abs(yamlFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to resolve the path of %s", yamlFile)
+	}
+
+	pods, err := r.Runtime.Pods(func(p *libpod.Pod) bool {
+		labels := p.Labels()
+		return labels[kubePlayNameLabel] == podYAML.ObjectMeta.Name && labels[kubePlayFileLabel] == yamlPath
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, errors.Wrapf(define.ErrNoSuchPod, "no pods created from %s were found", yamlFile)
+	}
+
+	for _, pod := range pods {
+		// the created paths are only removed along with the pod that
+		// recorded them
+		var createdPaths []string
+		if paths, ok := pod.Labels()[kubePlayCreatedPathsLabel]; ok {
+			if err := json.Unmarshal([]byte(paths), &createdPaths); err != nil {
+				logrus.Errorf("unable to decode the HostPath volumes created for pod %s: %v", pod.ID(), err)
+			}
+		}
+		ctrErrs, err := pod.Stop(ctx, true)
+		if err != nil && ctrErrs == nil {
+			if lastErr != nil {
+				logrus.Error(lastErr)
+			}
+			lastErr = errors.Wrapf(err, "error stopping pod %s", pod.ID())
+			continue
+		}
+		// containers that failed to stop are killed by the forced removal
+		for ctr, ctrErr := range ctrErrs {
+			logrus.Errorf("error stopping container %s: %v", ctr, ctrErr)
+		}
+		if err := r.Runtime.RemovePod(ctx, pod, true, true); err != nil {
+			if lastErr != nil {
+				logrus.Error(lastErr)
+			}
+			lastErr = errors.Wrapf(err, "error removing pod %s", pod.ID())
+			continue
+		}
+		podIDs = append(podIDs, pod.ID())
+
+		for _, path := range createdPaths {
+			if err := os.RemoveAll(path); err != nil {
+				logrus.Errorf("unable to remove HostPath %s: %v", path, err)
+			}
+		}
+	}
+	return podIDs, lastErr
+}
+
+// readKubeYAML reads a kube YAML file and unmarshals it into podYAML
+func readKubeYAML(yamlFile string, podYAML *v1.Pod) error {
+	content, err := ioutil.ReadFile(yamlFile)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(content, podYAML); err != nil {
+		return errors.Wrapf(err, "unable to read %s as YAML", yamlFile)
+	}
+	return nil
+}
+
 func playcleanup(ctx context.Context, runtime *LocalRuntime, pod *libpod.Pod, err error) error {
 	if err != nil && pod != nil {
 		return runtime.RemovePod(ctx, pod, true, true)
@@ -672,28 +820,42 @@ func kubeContainerToCreateConfig(ctx context.Context, containerYAML v1.Container
 		containerConfig.User = imageData.Config.User
 	}
 
-	if containerConfig.SecurityOpts != nil {
-		if containerYAML.SecurityContext.ReadOnlyRootFilesystem != nil {
-			containerConfig.ReadOnlyRootfs = *containerYAML.SecurityContext.ReadOnlyRootFilesystem
+	if sc := containerYAML.SecurityContext; sc != nil {
+		if sc.ReadOnlyRootFilesystem != nil {
+			containerConfig.ReadOnlyRootfs = *sc.ReadOnlyRootFilesystem
 		}
-		if containerYAML.SecurityContext.Privileged != nil {
-			containerConfig.Privileged = *containerYAML.SecurityContext.Privileged
+		if sc.Privileged != nil {
+			containerConfig.Privileged = *sc.Privileged
 		}
-
-		if containerYAML.SecurityContext.AllowPrivilegeEscalation != nil {
-			containerConfig.NoNewPrivs = !*containerYAML.SecurityContext.AllowPrivilegeEscalation
+		if sc.AllowPrivilegeEscalation != nil {
+			containerConfig.NoNewPrivs = !*sc.AllowPrivilegeEscalation
 		}
-
-	}
-	if caps := containerYAML.SecurityContext.Capabilities; caps != nil {
-		for _, capability := range caps.Add {
-			containerConfig.CapAdd = append(containerConfig.CapAdd, string(capability))
+		if sc.RunAsUser != nil {
+			containerConfig.User = strconv.FormatInt(*sc.RunAsUser, 10)
 		}
-		for _, capability := range caps.Drop {
-			containerConfig.CapDrop = append(containerConfig.CapDrop, string(capability))
+		if sc.RunAsGroup != nil {
+			// keep the user from the image or runAsUser, only replace the group
+			user := strings.SplitN(containerConfig.User, ":", 2)[0]
+			if user == "" {
+				user = "0"
+			}
+			containerConfig.User = fmt.Sprintf("%s:%d", user, *sc.RunAsGroup)
+		}
+		if seopt := sc.SELinuxOptions; seopt != nil {
+			containerConfig.LabelOpts = append(containerConfig.LabelOpts, kubeSELinuxOptionsToLabelOpts(seopt)...)
+		}
+		if caps := sc.Capabilities; caps != nil {
+			for _, capability := range caps.Add {
+				containerConfig.CapAdd = append(containerConfig.CapAdd, string(capability))
+			}
+			for _, capability := range caps.Drop {
+				containerConfig.CapDrop = append(containerConfig.CapDrop, string(capability))
+			}
 		}
 	}
 
+	setKubeResources(containerYAML.Resources, &containerConfig.Resources)
+
 	containerConfig.Command = []string{}
 	if imageData != nil && imageData.Config != nil {
 		containerConfig.Command = append(containerConfig.Command, imageData.Config.Entrypoint...)
@@ -717,8 +879,7 @@ func kubeContainerToCreateConfig(ctx context.Context, containerYAML v1.Container
 	containerConfig.NetMode = ns.NetworkMode(namespaces["net"])
 	containerConfig.IpcMode = ns.IpcMode(namespaces["ipc"])
 	containerConfig.UtsMode = ns.UTSMode(namespaces["uts"])
-	// disabled in code review per mheon
-	//containerConfig.PidMode = ns.PidMode(namespaces["pid"])
+	containerConfig.PidMode = ns.PidMode(namespaces["pid"])
 	containerConfig.UsernsMode = ns.UsernsMode(namespaces["user"])
 	if len(containerConfig.WorkDir) == 0 {
 		containerConfig.WorkDir = "/"
@@ -745,3 +906,107 @@ func kubeContainerToCreateConfig(ctx context.Context, containerYAML v1.Container
 	}
 	return &containerConfig, nil
 }
+
+// kubePodSharedNamespaces returns the kernel namespaces the pod's containers
+// share through the infra container. The network namespace is always shared,
+// with hostNetwork the infra container itself uses the host's network.
+func kubePodSharedNamespaces(podSpec v1.PodSpec) ([]string, error) {
+	shareProcessNamespace := podSpec.ShareProcessNamespace != nil && *podSpec.ShareProcessNamespace
+	if shareProcessNamespace && podSpec.HostPID {
+		return nil, errors.Errorf("shareProcessNamespace and hostPID cannot both be set")
+	}
+
+	var sharedNamespaces []string
+	for _, nsType := range strings.Split(shared.DefaultKernelNamespaces, ",") {
+		if nsType == "ipc" && podSpec.HostIPC {
+			continue
+		}
+		sharedNamespaces = append(sharedNamespaces, nsType)
+	}
+	if shareProcessNamespace {
+		sharedNamespaces = append(sharedNamespaces, "pid")
+	}
+	return sharedNamespaces, nil
+}
+
+// kubePodInfraOptions converts the networking settings of a kube pod into
+// options for the pod's infra container
+func kubePodInfraOptions(podSpec v1.PodSpec) []libpod.PodCreateOption {
+	var options []libpod.PodCreateOption
+	if podSpec.HostNetwork {
+		options = append(options, libpod.WithPodHostNetwork())
+	}
+	if podSpec.Hostname != "" {
+		options = append(options, libpod.WithPodHostname(podSpec.Hostname))
+	}
+	if dnsConfig := podSpec.DNSConfig; dnsConfig != nil {
+		if len(dnsConfig.Nameservers) > 0 {
+			options = append(options, libpod.WithPodDNS(dnsConfig.Nameservers))
+		}
+		if len(dnsConfig.Searches) > 0 {
+			options = append(options, libpod.WithPodDNSSearch(dnsConfig.Searches))
+		}
+		if len(dnsConfig.Options) > 0 {
+			var dnsOptions []string
+			for _, opt := range dnsConfig.Options {
+				if opt.Value != nil {
+					dnsOptions = append(dnsOptions, fmt.Sprintf("%s:%s", opt.Name, *opt.Value))
+				} else {
+					dnsOptions = append(dnsOptions, opt.Name)
+				}
+			}
+			options = append(options, libpod.WithPodDNSOption(dnsOptions))
+		}
+	}
+	if len(podSpec.HostAliases) > 0 {
+		var hosts []string
+		for _, alias := range podSpec.HostAliases {
+			for _, hostname := range alias.Hostnames {
+				hosts = append(hosts, fmt.Sprintf("%s:%s", hostname, alias.IP))
+			}
+		}
+		options = append(options, libpod.WithPodHosts(hosts))
+	}
+	return options
+}
+
+// kubeSELinuxOptionsToLabelOpts converts kube SELinux options into the label
+// options used by --security-opt label=
+func kubeSELinuxOptionsToLabelOpts(seopt *v1.SELinuxOptions) []string {
+	var labelOpts []string
+	if seopt.User != "" {
+		labelOpts = append(labelOpts, fmt.Sprintf("user:%s", seopt.User))
+	}
+	if seopt.Role != "" {
+		labelOpts = append(labelOpts, fmt.Sprintf("role:%s", seopt.Role))
+	}
+	if seopt.Type != "" {
+		labelOpts = append(labelOpts, fmt.Sprintf("type:%s", seopt.Type))
+	}
+	if seopt.Level != "" {
+		labelOpts = append(labelOpts, fmt.Sprintf("level:%s", seopt.Level))
+	}
+	return labelOpts
+}
+
+// setKubeResources converts the cpu and memory limits and requests of a kube
+// container into cgroup settings, the same way the kubelet does
+func setKubeResources(resources v1.ResourceRequirements, config *createconfig.CreateResourceConfig) {
+	if cpu, ok := resources.Limits[v1.ResourceCPU]; ok && !cpu.IsZero() {
+		config.CPUs = float64(cpu.MilliValue()) / 1000
+	}
+	if memory, ok := resources.Limits[v1.ResourceMemory]; ok && !memory.IsZero() {
+		config.Memory = memory.Value()
+	}
+	if cpu, ok := resources.Requests[v1.ResourceCPU]; ok && !cpu.IsZero() {
+		shares := uint64(cpu.MilliValue()) * 1024 / 1000
+		// the kernel does not accept less than two shares
+		if shares < 2 {
+			shares = 2
+		}
+		config.CPUShares = shares
+	}
+	if memory, ok := resources.Requests[v1.ResourceMemory]; ok && !memory.IsZero() {
+		config.MemoryReservation = memory.Value()
+	}
+}
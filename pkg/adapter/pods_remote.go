@@ -186,6 +186,13 @@ func (r *LocalRuntime) CreatePod(ctx context.Context, cli *cliconfig.PodCreateVa
 		InfraCommand: cli.InfraCommand,
 		InfraImage:   cli.InfraCommand,
 		Publish:      cli.Publish,
+		Network:      cli.Network,
+		Ip:           cli.IP,
+		Hostname:     cli.Hostname,
+		Dns:          cli.DNS,
+		DnsSearch:    cli.DNSSearch,
+		AddHost:      cli.AddHost,
+		NetworkAlias: cli.NetworkAlias,
 	}
 
 	return iopodman.CreatePod().Call(r.Conn, pc)
@@ -489,19 +496,21 @@ func (p *Pod) GetPodStats(previousContainerStats map[string]*libpod.ContainerSta
 			prevStat = &libpod.ContainerStats{ContainerID: c.ID()}
 		}
 		cStats := iopodman.ContainerStats{
-			Id:           prevStat.ContainerID,
-			Name:         prevStat.Name,
-			Cpu:          prevStat.CPU,
-			Cpu_nano:     int64(prevStat.CPUNano),
-			System_nano:  int64(prevStat.SystemNano),
-			Mem_usage:    int64(prevStat.MemUsage),
-			Mem_limit:    int64(prevStat.MemLimit),
-			Mem_perc:     prevStat.MemPerc,
-			Net_input:    int64(prevStat.NetInput),
-			Net_output:   int64(prevStat.NetOutput),
-			Block_input:  int64(prevStat.BlockInput),
-			Block_output: int64(prevStat.BlockOutput),
-			Pids:         int64(prevStat.PIDs),
+			Id:               prevStat.ContainerID,
+			Name:             prevStat.Name,
+			Cpu:              prevStat.CPU,
+			Cpu_nano:         int64(prevStat.CPUNano),
+			System_nano:      int64(prevStat.SystemNano),
+			Mem_usage:        int64(prevStat.MemUsage),
+			Mem_limit:        int64(prevStat.MemLimit),
+			Mem_perc:         prevStat.MemPerc,
+			Net_input:        int64(prevStat.NetInput),
+			Net_output:       int64(prevStat.NetOutput),
+			Block_input:      int64(prevStat.BlockInput),
+			Block_output:     int64(prevStat.BlockOutput),
+			Pids:             int64(prevStat.PIDs),
+			Net_ingress_rate: int64(prevStat.NetIngressRate),
+			Net_egress_rate:  int64(prevStat.NetEgressRate),
 		}
 		stats, err := iopodman.GetContainerStatsWithHistory().Call(p.Runtime.Conn, cStats)
 		if err != nil {
@@ -569,3 +578,8 @@ func (r *LocalRuntime) PrunePods(ctx context.Context, cli *cliconfig.PodPruneVal
 func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayValues, yamlFile string) (*Pod, error) {
 	return nil, define.ErrNotImplemented
 }
+
+// PlayKubeDown removes the pods and volumes created from a kube YAML file
+func (r *LocalRuntime) PlayKubeDown(ctx context.Context, c *cliconfig.KubePlayValues, yamlFile string) ([]string, error) {
+	return nil, define.ErrNotImplemented
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/containers/libpod/pkg/rootless"
 	"github.com/containers/storage/pkg/archive"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"k8s.io/api/core/v1"
 )
 
@@ -194,6 +195,38 @@ func (r *LocalRuntime) CreateVolume(ctx context.Context, c *cliconfig.VolumeCrea
 	return newVolume.Name(), nil
 }
 
+// ExportVolume is a wrapper to volume export to a tarfile
+func (r *LocalRuntime) ExportVolume(name, path string, pause bool) error {
+	vol, err := r.GetVolume(name)
+	if err != nil {
+		return err
+	}
+	return vol.Export(path, pause)
+}
+
+// ImportVolume is a wrapper to volume import from a tarfile. The volume is
+// created if it does not exist.
+func (r *LocalRuntime) ImportVolume(ctx context.Context, name, path string) error {
+	created := false
+	vol, err := r.GetVolume(name)
+	if errors.Cause(err) == define.ErrNoSuchVolume {
+		vol, err = r.NewVolume(ctx, libpod.WithVolumeName(name))
+		created = true
+	}
+	if err != nil {
+		return err
+	}
+	if err := vol.Import(path); err != nil {
+		if created {
+			if err := r.RemoveVolume(ctx, vol, false); err != nil {
+				logrus.Errorf("Error removing volume %s after failing to import into it: %v", vol.Name(), err)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
 // RemoveVolumes is a wrapper to remove volumes
 func (r *LocalRuntime) RemoveVolumes(ctx context.Context, c *cliconfig.VolumeRmValues) ([]string, error) {
 	return r.Runtime.RemoveVolumes(ctx, c.InputArgs, c.All, c.Force)
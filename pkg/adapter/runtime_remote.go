@@ -166,6 +166,7 @@ type Volume struct {
 type remoteVolume struct {
 	Runtime *LocalRuntime
 	config  *libpod.VolumeConfig
+	usage   uint64
 }
 
 // GetImages returns a slice of containerimages over a varlink connection
@@ -601,6 +602,26 @@ func (r *LocalRuntime) CreateVolume(ctx context.Context, c *cliconfig.VolumeCrea
 	return iopodman.VolumeCreate().Call(r.Conn, cvOpts)
 }
 
+// ExportVolume exports a volume over a varlink connection for the remote client
+func (r *LocalRuntime) ExportVolume(name, path string, pause bool) error {
+	tempPath, err := iopodman.ExportVolume().Call(r.Conn, name, "", pause)
+	if err != nil {
+		return err
+	}
+	return r.GetFileFromRemoteHost(tempPath, path, true)
+}
+
+// ImportVolume imports a tarfile into a volume over a varlink connection for
+// the remote client
+func (r *LocalRuntime) ImportVolume(ctx context.Context, name, path string) error {
+	tempFile, err := r.SendFileOverVarlink(path)
+	if err != nil {
+		return err
+	}
+	_, err = iopodman.ImportVolume().Call(r.Conn, name, strings.TrimRight(tempFile, ":"), true)
+	return err
+}
+
 // RemoveVolumes removes volumes over a varlink connection for the remote client
 func (r *LocalRuntime) RemoveVolumes(ctx context.Context, c *cliconfig.VolumeRmValues) ([]string, error) {
 	rmOpts := iopodman.VolumeRemoveOpts{
@@ -662,10 +683,12 @@ func varlinkVolumeToVolume(r *LocalRuntime, volumes []iopodman.Volume) []*Volume
 			Driver:     v.Driver,
 			Options:    v.Options,
 			Scope:      v.Scope,
+			Quota:      uint64(v.Quota),
 		}
 		n := remoteVolume{
 			Runtime: r,
 			config:  &volumeConfig,
+			usage:   uint64(v.Usage),
 		}
 		newVol := Volume{
 			n,
@@ -27,7 +27,23 @@ func (v *Volume) MountPoint() string {
 	return v.config.MountPoint
 }
 
+// ResolveMountPoint returns the path the volume is mounted to, which the
+// service has already asked the volume plugin for
+func (v *Volume) ResolveMountPoint() (string, error) {
+	return v.config.MountPoint, nil
+}
+
 // Scope returns the scope for an adapter.volume
 func (v *Volume) Scope() string {
 	return v.config.Scope
 }
+
+// Quota returns the size limit of the volume in bytes, or 0 if it has none
+func (v *Volume) Quota() uint64 {
+	return v.config.Quota
+}
+
+// Usage returns the disk space used by a volume with a quota in bytes
+func (v *Volume) Usage() (uint64, error) {
+	return v.usage, nil
+}
@@ -18,19 +18,24 @@ package firewall
 
 import (
 	"fmt"
+
+	"github.com/sirupsen/logrus"
 )
 
 // GetBackend retrieves a firewall backend for adding or removing firewall rules
 // on the system.
-// Valid backend names are firewalld, iptables, and none.
+// Valid backend names are firewalld, iptables, nftables, and none.
 // If the empty string is given, a firewalld backend will be returned if
 // firewalld is running, and an iptables backend will be returned otherwise.
+// If iptables is not available, an nftables backend is used instead.
 func GetBackend(backend string) (FirewallBackend, error) {
 	switch backend {
 	case "firewalld":
 		return newFirewalldBackend()
 	case "iptables":
 		return newIptablesBackend()
+	case "nftables":
+		return newNftablesBackend()
 	case "none":
 		return newNoneBackend()
 	case "":
@@ -40,7 +45,18 @@ func GetBackend(backend string) (FirewallBackend, error) {
 		}
 
 		// Otherwise iptables
-		return newIptablesBackend()
+		backend, err := newIptablesBackend()
+		if err == nil {
+			return backend, nil
+		}
+
+		// Fall back to nftables on hosts without iptables
+		nftBackend, nftErr := newNftablesBackend()
+		if nftErr != nil {
+			return nil, err
+		}
+		logrus.Debugf("Using nftables firewall backend as iptables is not available: %v", err)
+		return nftBackend, nil
 	default:
 		return nil, fmt.Errorf("unrecognized firewall backend %q", backend)
 	}
@@ -165,20 +165,36 @@ func newIptablesBackend() (FirewallBackend, error) {
 		protos:         make(map[iptables.Protocol]*iptables.IPTables),
 	}
 
-	for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
-		ipt, err := iptables.NewWithProtocol(proto)
-		if err != nil {
-			return nil, fmt.Errorf("could not initialize iptables protocol %v: %v", proto, err)
-		}
-		backend.protos[proto] = ipt
+	ipt, err := iptables.NewWithProtocol(iptables.ProtocolIPv4)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize iptables protocol %v: %v", iptables.ProtocolIPv4, err)
+	}
+	backend.protos[iptables.ProtocolIPv4] = ipt
+
+	// IPv6 is optional, hosts without ip6tables can still run IPv4-only
+	// networks
+	ip6t, err := iptables.NewWithProtocol(iptables.ProtocolIPv6)
+	if err != nil {
+		logrus.Warnf("could not initialize iptables protocol %v, IPv6 firewall rules will not be created: %v", iptables.ProtocolIPv6, err)
+	} else {
+		backend.protos[iptables.ProtocolIPv6] = ip6t
 	}
 
 	return backend, nil
 }
 
 func (ib *iptablesBackend) Add(conf *FirewallNetConf) error {
+	for _, ip := range conf.PrevResult.IPs {
+		if _, ok := ib.protos[protoForIP(ip.Address)]; !ok {
+			return fmt.Errorf("cannot add firewall rules for %s: iptables protocol %v is not available", ipString(ip.Address), protoForIP(ip.Address))
+		}
+	}
 	for proto, ipt := range ib.protos {
 		if err := ib.addRules(conf, ipt, proto); err != nil {
+			// Do not leave the rules of the other family behind
+			if delErr := ib.Del(conf); delErr != nil {
+				logrus.Errorf("failed to roll back iptables rules: %v", delErr)
+			}
 			return err
 		}
 	}
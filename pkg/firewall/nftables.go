@@ -0,0 +1,160 @@
+// +build linux
+
+package firewall
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// nftTableFamily is the family of the table holding podman's rules.
+	// The inet family handles both IPv4 and IPv6 traffic.
+	nftTableFamily = "inet"
+	// nftTableName is the name of the table holding podman's rules
+	nftTableName = "podman"
+	// nftChainName is the name of the forward chain in podman's table
+	nftChainName = "forward"
+)
+
+// nftRuleRegex matches a rule in the output of `nft -a list chain`,
+// capturing the rule comment and the rule handle
+var nftRuleRegex = regexp.MustCompile(`comment "([^"]*)".*# handle ([0-9]+)\s*$`)
+
+type nftablesBackend struct {
+	// nft runs the nft binary with the given arguments and standard input
+	nft func(stdin string, args ...string) (string, error)
+}
+
+// nftablesBackend implements the FirewallBackend interface
+var _ FirewallBackend = &nftablesBackend{}
+
+func newNftablesBackend() (FirewallBackend, error) {
+	path, err := exec.LookPath("nft")
+	if err != nil {
+		return nil, fmt.Errorf("could not find nft binary: %v", err)
+	}
+
+	nft := func(stdin string, args ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command(path, args...)
+		cmd.Stdin = strings.NewReader(stdin)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("error running nft %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.String(), nil
+	}
+
+	return &nftablesBackend{nft: nft}, nil
+}
+
+// nftRuleComment returns the comment marking the rules podman adds for the
+// given address
+func nftRuleComment(ip string) string {
+	return "podman " + ip
+}
+
+// nftRules returns the rules allowing traffic to and from the given address
+func nftRules(ip string, isIPv4 bool) []string {
+	family := "ip6"
+	if isIPv4 {
+		family = "ip"
+	}
+	comment := nftRuleComment(ip)
+	return []string{
+		fmt.Sprintf("%s daddr %s ct state related,established accept comment %q", family, ip, comment),
+		fmt.Sprintf("%s saddr %s accept comment %q", family, ip, comment),
+	}
+}
+
+// parseNftRuleHandles returns the handles of the rules in the output of
+// `nft -a list chain` whose comment is one of the given comments
+func parseNftRuleHandles(output string, comments map[string]bool) []int {
+	var handles []int
+	for _, line := range strings.Split(output, "\n") {
+		match := nftRuleRegex.FindStringSubmatch(line)
+		if match == nil || !comments[match[1]] {
+			continue
+		}
+		handle, err := strconv.Atoi(match[2])
+		if err != nil {
+			continue
+		}
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+// tableExists checks whether podman's table is present
+func (nb *nftablesBackend) tableExists() bool {
+	_, err := nb.nft("", "list", "table", nftTableFamily, nftTableName)
+	return err == nil
+}
+
+// Add adds the rules for the addresses of the given CNI result to the
+// forward chain of podman's own table, creating the table if it does not
+// exist. Tables of the host or of other tools such as firewalld are never
+// changed. As an accept verdict only ends the evaluation of the chain it is
+// issued in, forward chains of other tables dropping traffic still apply to
+// the containers, and have to allow their traffic themselves.
+func (nb *nftablesBackend) Add(conf *FirewallNetConf) error {
+	// Remove any rules left behind for the same addresses so adding is
+	// idempotent
+	if err := nb.Del(conf); err != nil {
+		return err
+	}
+
+	var script strings.Builder
+	fmt.Fprintf(&script, "add table %s %s\n", nftTableFamily, nftTableName)
+	fmt.Fprintf(&script, "add chain %s %s %s { type filter hook forward priority 0 ; policy accept ; }\n", nftTableFamily, nftTableName, nftChainName)
+	for _, ip := range conf.PrevResult.IPs {
+		for _, rule := range nftRules(ipString(ip.Address), ip.Address.IP.To4() != nil) {
+			fmt.Fprintf(&script, "add rule %s %s %s %s\n", nftTableFamily, nftTableName, nftChainName, rule)
+		}
+	}
+
+	// The script is applied atomically, so nothing needs to be cleaned up
+	// on failure
+	_, err := nb.nft(script.String(), "-f", "-")
+	return err
+}
+
+// Del removes the rules for the addresses of the given CNI result from
+// podman's table. Removing rules that do not exist is not an error.
+// The table itself is kept, as other containers may be adding rules to it
+// concurrently.
+func (nb *nftablesBackend) Del(conf *FirewallNetConf) error {
+	if !nb.tableExists() {
+		return nil
+	}
+
+	output, err := nb.nft("", "-a", "list", "chain", nftTableFamily, nftTableName, nftChainName)
+	if err != nil {
+		// Without our chain there can be no rules to remove
+		logrus.Debugf("Error listing nftables chain %s: %v", nftChainName, err)
+		return nil
+	}
+
+	comments := make(map[string]bool)
+	for _, ip := range conf.PrevResult.IPs {
+		comments[nftRuleComment(ipString(ip.Address))] = true
+	}
+
+	var script strings.Builder
+	for _, handle := range parseNftRuleHandles(output, comments) {
+		fmt.Fprintf(&script, "delete rule %s %s %s handle %d\n", nftTableFamily, nftTableName, nftChainName, handle)
+	}
+	if script.Len() == 0 {
+		return nil
+	}
+	_, err = nb.nft(script.String(), "-f", "-")
+	return err
+}
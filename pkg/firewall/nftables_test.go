@@ -0,0 +1,190 @@
+// +build linux
+
+package firewall
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/cni/pkg/types/current"
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/libpod/pkg/netns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+)
+
+func TestNftRules(t *testing.T) {
+	assert.Equal(t, []string{
+		`ip daddr 10.88.0.2/32 ct state related,established accept comment "podman 10.88.0.2/32"`,
+		`ip saddr 10.88.0.2/32 accept comment "podman 10.88.0.2/32"`,
+	}, nftRules("10.88.0.2/32", true))
+	assert.Equal(t, []string{
+		`ip6 daddr fd00::2/128 ct state related,established accept comment "podman fd00::2/128"`,
+		`ip6 saddr fd00::2/128 accept comment "podman fd00::2/128"`,
+	}, nftRules("fd00::2/128", false))
+}
+
+func TestParseNftRuleHandles(t *testing.T) {
+	output := `table inet podman {
+	chain forward { # handle 1
+		type filter hook forward priority filter; policy accept;
+		ip daddr 10.88.0.2 ct state established,related accept comment "podman 10.88.0.2/32" # handle 2
+		ip saddr 10.88.0.2 accept comment "podman 10.88.0.2/32" # handle 3
+		ip daddr 10.88.0.3 ct state established,related accept comment "podman 10.88.0.3/32" # handle 4
+		ip6 saddr fd00::2 accept comment "podman fd00::2/128" # handle 5
+		iifname "eth0" accept comment "10.88.0.2/32" # handle 6
+	}
+}
+`
+	comments := map[string]bool{"podman 10.88.0.2/32": true, "podman fd00::2/128": true}
+	assert.Equal(t, []int{2, 3, 5}, parseNftRuleHandles(output, comments))
+	assert.Empty(t, parseNftRuleHandles(output, map[string]bool{"podman 10.88.0.9/32": true}))
+}
+
+// TestNftablesBackend routes traffic between a container and an outside
+// network namespace through a router namespace, and checks that the backend
+// keeps its rules in podman's own table, leaving the tables of the host alone.
+func TestNftablesBackend(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("creating network namespaces requires root")
+	}
+	backend, err := newNftablesBackend()
+	if err != nil {
+		t.Skip(err)
+	}
+	nft := backend.(*nftablesBackend).nft
+
+	var namespaces []ns.NetNS
+	defer func() {
+		for _, netNS := range namespaces {
+			assert.NoError(t, netns.UnmountNS(netNS))
+			netNS.Close()
+		}
+	}()
+	for i := 0; i < 3; i++ {
+		netNS, err := netns.NewNS()
+		require.NoError(t, err)
+		namespaces = append(namespaces, netNS)
+	}
+	ctrNS, routerNS, outsideNS := namespaces[0], namespaces[1], namespaces[2]
+	linkTestNS(t, "fwtest0", ctrNS, "10.88.0.2/24", routerNS, "10.88.0.1/24")
+	linkTestNS(t, "fwtest1", outsideNS, "10.99.0.2/24", routerNS, "10.99.0.1/24")
+	err = routerNS.Do(func(ns.NetNS) error {
+		return ioutil.WriteFile("/proc/sys/net/ipv4/ip_forward", []byte("1"), 0644)
+	})
+	require.NoError(t, err)
+	defer listenTestNS(t, ctrNS, "10.88.0.2:8080").Close()
+	defer listenTestNS(t, outsideNS, "10.99.0.2:8080").Close()
+
+	// A forward chain of the host, which must not be changed
+	err = routerNS.Do(func(ns.NetNS) error {
+		_, err := nft("add table inet filter\nadd chain inet filter forward { type filter hook forward priority 0 ; policy accept ; }\n", "-f", "-")
+		return err
+	})
+	require.NoError(t, err)
+
+	_, ipNet, err := net.ParseCIDR("10.88.0.2/32")
+	require.NoError(t, err)
+	conf := &FirewallNetConf{
+		PrevResult: &current.Result{
+			IPs: []*current.IPConfig{{Version: "4", Address: *ipNet}},
+		},
+	}
+	comments := map[string]bool{nftRuleComment("10.88.0.2/32"): true}
+	// Adding the rules twice must not add them twice
+	for i := 0; i < 2; i++ {
+		err = routerNS.Do(func(ns.NetNS) error {
+			return backend.Add(conf)
+		})
+		require.NoError(t, err)
+	}
+	err = routerNS.Do(func(ns.NetNS) error {
+		output, err := nft("", "-a", "list", "chain", nftTableFamily, nftTableName, nftChainName)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, parseNftRuleHandles(output, comments), 2)
+		output, err = nft("", "-a", "list", "chain", "inet", "filter", "forward")
+		assert.Empty(t, parseNftRuleHandles(output, comments))
+		return err
+	})
+	require.NoError(t, err)
+	assert.NoError(t, dialTestNS(ctrNS, "10.99.0.2:8080"))
+
+	err = routerNS.Do(func(ns.NetNS) error {
+		return backend.Del(conf)
+	})
+	require.NoError(t, err)
+	err = routerNS.Do(func(ns.NetNS) error {
+		// The table is kept for other containers
+		output, err := nft("", "-a", "list", "chain", nftTableFamily, nftTableName, nftChainName)
+		assert.Empty(t, parseNftRuleHandles(output, comments))
+		return err
+	})
+	require.NoError(t, err)
+}
+
+// linkTestNS connects two network namespaces with a veth pair of the given
+// name, routing the traffic of the first namespace through the second
+func linkTestNS(t *testing.T, name string, ns1 ns.NetNS, addr1 string, ns2 ns.NetNS, addr2 string) {
+	veth := &netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: name, Namespace: netlink.NsFd(int(ns1.Fd()))},
+		PeerName:  name + "p",
+	}
+	require.NoError(t, netlink.LinkAdd(veth))
+	peer, err := netlink.LinkByName(name + "p")
+	require.NoError(t, err)
+	require.NoError(t, netlink.LinkSetNsFd(peer, int(ns2.Fd())))
+
+	setup := func(name, addr, gateway string) func(ns.NetNS) error {
+		return func(ns.NetNS) error {
+			link, err := netlink.LinkByName(name)
+			if err != nil {
+				return err
+			}
+			ipNet, err := netlink.ParseAddr(addr)
+			if err != nil {
+				return err
+			}
+			if err := netlink.AddrAdd(link, ipNet); err != nil {
+				return err
+			}
+			if err := netlink.LinkSetUp(link); err != nil {
+				return err
+			}
+			if gateway == "" {
+				return nil
+			}
+			return netlink.RouteAdd(&netlink.Route{LinkIndex: link.Attrs().Index, Gw: net.ParseIP(gateway)})
+		}
+	}
+	gateway, _, err := net.ParseCIDR(addr2)
+	require.NoError(t, err)
+	require.NoError(t, ns1.Do(setup(name, addr1, gateway.String())))
+	require.NoError(t, ns2.Do(setup(name+"p", addr2, "")))
+}
+
+func listenTestNS(t *testing.T, netNS ns.NetNS, addr string) net.Listener {
+	var listener net.Listener
+	err := netNS.Do(func(ns.NetNS) error {
+		var err error
+		listener, err = net.Listen("tcp", addr)
+		return err
+	})
+	require.NoError(t, err)
+	return listener
+}
+
+func dialTestNS(netNS ns.NetNS, addr string) error {
+	return netNS.Do(func(ns.NetNS) error {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+}
@@ -323,7 +323,17 @@ func (n NetworkMode) IsBridge() bool {
 
 // IsSlirp4netns indicates if we are running a rootless network stack
 func (n NetworkMode) IsSlirp4netns() bool {
-	return n == slirpType
+	return n == slirpType || strings.HasPrefix(string(n), slirpType+":")
+}
+
+// Slirp4netnsOptions gets the options given with a
+// slirp4netns:opt1=val1,opt2=val2 network mode
+func (n NetworkMode) Slirp4netnsOptions() []string {
+	parts := strings.SplitN(string(n), ":", 2)
+	if len(parts) > 1 && parts[0] == slirpType && parts[1] != "" {
+		return strings.Split(parts[1], ",")
+	}
+	return nil
 }
 
 // IsNS indicates a network namespace passed in by path (ns:<path>)
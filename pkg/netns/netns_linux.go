@@ -23,17 +23,34 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
 
 	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/containers/libpod/pkg/util"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 )
 
 const nsRunDir = "/var/run/netns"
 
+// GetNSRunDir returns the directory network namespaces are mounted in.
+// Rootless users cannot write to /var/run/netns, so their network namespaces
+// are mounted in their runtime directory.
+func GetNSRunDir() (string, error) {
+	if rootless.IsRootless() {
+		runtimeDir, err := util.GetRootlessRuntimeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(runtimeDir, "netns"), nil
+	}
+	return nsRunDir, nil
+}
+
 // NewNS creates a new persistent (bind-mounted) network namespace and returns
 // an object representing that namespace, without switching to it.
 func NewNS() (ns.NetNS, error) {
@@ -44,6 +61,18 @@ func NewNS() (ns.NetNS, error) {
 		return nil, fmt.Errorf("failed to generate random netns name: %v", err)
 	}
 
+	return NewNSWithName(fmt.Sprintf("cni-%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]))
+}
+
+// NewNSWithName creates a new persistent (bind-mounted) network namespace
+// with the given name and returns an object representing that namespace,
+// without switching to it.
+func NewNSWithName(nsName string) (ns.NetNS, error) {
+	nsRunDir, err := GetNSRunDir()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the directory for mounting network namespaces
 	// This needs to be a shared mountpoint in case it is mounted in to
 	// other namespaces (containers)
@@ -76,8 +105,6 @@ func NewNS() (ns.NetNS, error) {
 
 	}
 
-	nsName := fmt.Sprintf("cni-%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
-
 	// create an empty file at the mount point
 	nsPath := path.Join(nsRunDir, nsName)
 	mountPointFd, err := os.Create(nsPath)
@@ -151,6 +178,10 @@ func NewNS() (ns.NetNS, error) {
 // UnmountNS unmounts the NS held by the netns object
 func UnmountNS(ns ns.NetNS) error {
 	nsPath := ns.Path()
+	nsRunDir, err := GetNSRunDir()
+	if err != nil {
+		return err
+	}
 	// Only unmount if it's been bind-mounted (don't touch namespaces in /proc...)
 	if strings.HasPrefix(nsPath, nsRunDir) {
 		if err := unix.Unmount(nsPath, unix.MNT_DETACH); err != nil {
@@ -0,0 +1,179 @@
+package network
+
+// CNIVersion is the CNI specification version of the network configurations
+// created by Podman
+const CNIVersion = "0.4.0"
+
+const (
+	// BridgeDriver creates networks backed by a Linux bridge on the host
+	BridgeDriver = "bridge"
+	// MacVLANDriver creates networks attached directly to a host interface
+	MacVLANDriver = "macvlan"
+)
+
+// DefaultDNSDomain is the domain under which the dnsname plugin resolves
+// containers
+const DefaultDNSDomain = "dns.podman"
+
+// SupportedDrivers lists the network drivers Podman can create networks for
+var SupportedDrivers = []string{BridgeDriver, MacVLANDriver}
+
+// NcList describes a CNI network configuration list, as written to a
+// .conflist file
+type NcList struct {
+	CNIVersion string        `json:"cniVersion"`
+	Name       string        `json:"name"`
+	Plugins    []interface{} `json:"plugins"`
+}
+
+// HostLocalBridge describes the configuration of the CNI bridge plugin
+type HostLocalBridge struct {
+	PluginType   string            `json:"type"`
+	BrName       string            `json:"bridge,omitempty"`
+	IsGW         bool              `json:"isGateway"`
+	IsDefaultGW  bool              `json:"isDefaultGateway,omitempty"`
+	IPMasq       bool              `json:"ipMasq,omitempty"`
+	MTU          int               `json:"mtu,omitempty"`
+	HairpinMode  bool              `json:"hairpinMode,omitempty"`
+	IPAM         IPAMHostLocalConf `json:"ipam"`
+	Capabilities map[string]bool   `json:"capabilities,omitempty"`
+}
+
+// MacVLANConfig describes the configuration of the CNI macvlan plugin
+type MacVLANConfig struct {
+	PluginType string      `json:"type"`
+	Master     string      `json:"master"`
+	MTU        int         `json:"mtu,omitempty"`
+	IPAM       interface{} `json:"ipam"`
+}
+
+// IPAMHostLocalConf describes the configuration of the host-local IPAM plugin
+type IPAMHostLocalConf struct {
+	PluginType  string                     `json:"type"`
+	Routes      []IPAMRoute                `json:"routes,omitempty"`
+	ResolveConf string                     `json:"resolveConf,omitempty"`
+	DataDir     string                     `json:"dataDir,omitempty"`
+	Ranges      [][]IPAMLocalHostRangeConf `json:"ranges,omitempty"`
+}
+
+// IPAMLocalHostRangeConf describes a range of addresses handed out by the
+// host-local IPAM plugin
+type IPAMLocalHostRangeConf struct {
+	Subnet     string `json:"subnet"`
+	RangeStart string `json:"rangeStart,omitempty"`
+	RangeEnd   string `json:"rangeEnd,omitempty"`
+	Gateway    string `json:"gateway,omitempty"`
+}
+
+// IPAMRoute describes a route added by an IPAM plugin
+type IPAMRoute struct {
+	Dest string `json:"dst"`
+}
+
+// IPAMDHCP describes the configuration of the DHCP IPAM plugin
+type IPAMDHCP struct {
+	PluginType string `json:"type"`
+}
+
+// PortMapConfig describes the configuration of the CNI portmap plugin
+type PortMapConfig struct {
+	PluginType   string          `json:"type"`
+	Capabilities map[string]bool `json:"capabilities"`
+}
+
+// BandwidthConfig describes the configuration of the CNI bandwidth plugin,
+// which limits the traffic of containers requesting it through the bandwidth
+// capability
+type BandwidthConfig struct {
+	PluginType   string          `json:"type"`
+	Capabilities map[string]bool `json:"capabilities"`
+}
+
+// DNSNameConfig describes the configuration of the dnsname plugin, which
+// resolves the names of the containers on a network
+type DNSNameConfig struct {
+	PluginType   string          `json:"type"`
+	DomainName   string          `json:"domainName"`
+	Capabilities map[string]bool `json:"capabilities"`
+}
+
+// NewNcList creates an empty network configuration list
+func NewNcList(name, version string) NcList {
+	return NcList{
+		CNIVersion: version,
+		Name:       name,
+		Plugins:    []interface{}{},
+	}
+}
+
+// NewHostLocalBridge creates the configuration of a bridge plugin using the
+// host-local IPAM plugin
+func NewHostLocalBridge(name string, isGateWay, isDefaultGW, ipMasq bool, mtu int, ipamConf IPAMHostLocalConf) *HostLocalBridge {
+	return &HostLocalBridge{
+		PluginType:  "bridge",
+		BrName:      name,
+		IsGW:        isGateWay,
+		IsDefaultGW: isDefaultGW,
+		IPMasq:      ipMasq,
+		MTU:         mtu,
+		HairpinMode: true,
+		IPAM:        ipamConf,
+		// Lets containers request a static address of each family
+		Capabilities: map[string]bool{"ips": true},
+	}
+}
+
+// NewIPAMHostLocalConf creates the configuration of the host-local IPAM
+// plugin
+func NewIPAMHostLocalConf(routes []IPAMRoute, ipamRanges [][]IPAMLocalHostRangeConf) IPAMHostLocalConf {
+	return IPAMHostLocalConf{
+		PluginType: "host-local",
+		Routes:     routes,
+		Ranges:     ipamRanges,
+	}
+}
+
+// NewIPAMDefaultRoute creates a default route for the given address family
+func NewIPAMDefaultRoute(isIPv6 bool) IPAMRoute {
+	if isIPv6 {
+		return IPAMRoute{Dest: "::/0"}
+	}
+	return IPAMRoute{Dest: "0.0.0.0/0"}
+}
+
+// NewPortMapPlugin creates the configuration of the portmap plugin
+func NewPortMapPlugin() PortMapConfig {
+	return PortMapConfig{
+		PluginType:   "portmap",
+		Capabilities: map[string]bool{"portMappings": true},
+	}
+}
+
+// NewBandwidthPlugin creates the configuration of the bandwidth plugin
+func NewBandwidthPlugin() BandwidthConfig {
+	return BandwidthConfig{
+		PluginType:   "bandwidth",
+		Capabilities: map[string]bool{"bandwidth": true},
+	}
+}
+
+// NewMacVLANPlugin creates the configuration of a macvlan plugin attached to
+// the given host interface. Addresses are assigned by ipam.
+func NewMacVLANPlugin(master string, mtu int, ipam interface{}) MacVLANConfig {
+	return MacVLANConfig{
+		PluginType: "macvlan",
+		Master:     master,
+		MTU:        mtu,
+		IPAM:       ipam,
+	}
+}
+
+// NewDNSNamePlugin creates the configuration of a dnsname plugin resolving
+// containers, and their aliases, under the given domain
+func NewDNSNamePlugin(domainName string) DNSNameConfig {
+	return DNSNameConfig{
+		PluginType:   "dnsname",
+		DomainName:   domainName,
+		Capabilities: map[string]bool{"aliases": true},
+	}
+}
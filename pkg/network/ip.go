@@ -0,0 +1,71 @@
+package network
+
+import (
+	"math/big"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// FirstIPInSubnet returns the first usable address of the given subnet,
+// skipping the network address
+func FirstIPInSubnet(subnet *net.IPNet) net.IP {
+	return addToIP(subnet.IP.Mask(subnet.Mask), big.NewInt(1))
+}
+
+// LastIPInSubnet returns the last usable address of the given subnet,
+// skipping the broadcast address of IPv4 subnets
+func LastIPInSubnet(subnet *net.IPNet) net.IP {
+	last := lastAddress(subnet)
+	if last.To4() != nil {
+		return addToIP(last, big.NewInt(-1))
+	}
+	return last
+}
+
+// lastAddress returns the last address of the given subnet
+func lastAddress(subnet *net.IPNet) net.IP {
+	ones, bits := subnet.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	return addToIP(subnet.IP.Mask(subnet.Mask), size.Sub(size, big.NewInt(1)))
+}
+
+// CalcGatewayIP returns the conventional gateway address of the given subnet,
+// its first usable address
+func CalcGatewayIP(subnet *net.IPNet) net.IP {
+	return FirstIPInSubnet(subnet)
+}
+
+// NextSubnet returns the subnet of the same size that directly follows the
+// given one
+func NextSubnet(subnet *net.IPNet) (*net.IPNet, error) {
+	ones, bits := subnet.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	next := addToIP(subnet.IP.Mask(subnet.Mask), size)
+	if next == nil {
+		return nil, errors.Errorf("%s is the last subnet of its size", subnet.String())
+	}
+	return &net.IPNet{IP: next, Mask: subnet.Mask}, nil
+}
+
+// networkIntersect returns whether two subnets overlap
+func networkIntersect(n1, n2 *net.IPNet) bool {
+	return n2.Contains(n1.IP) || n1.Contains(n2.IP)
+}
+
+// addToIP adds offset to the given address. It returns nil if the result
+// does not fit in the address family of ip.
+func addToIP(ip net.IP, offset *big.Int) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	sum := new(big.Int).SetBytes(ip)
+	sum.Add(sum, offset)
+	if sum.Sign() < 0 || sum.BitLen() > 8*len(ip) {
+		return nil
+	}
+	result := make(net.IP, len(ip))
+	b := sum.Bytes()
+	copy(result[len(result)-len(b):], b)
+	return result
+}
@@ -0,0 +1,52 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseCIDR(t *testing.T, cidr string) *net.IPNet {
+	_, subnet, err := net.ParseCIDR(cidr)
+	assert.NoError(t, err)
+	return subnet
+}
+
+func TestFirstAndLastIPInSubnet(t *testing.T) {
+	for _, tt := range []struct {
+		subnet, first, last string
+	}{
+		{"10.89.0.0/24", "10.89.0.1", "10.89.0.254"},
+		{"192.168.1.77/16", "192.168.0.1", "192.168.255.254"},
+		{"fd00::/64", "fd00::1", "fd00::ffff:ffff:ffff:ffff"},
+	} {
+		subnet := parseCIDR(t, tt.subnet)
+		assert.Equal(t, tt.first, FirstIPInSubnet(subnet).String())
+		assert.Equal(t, tt.last, LastIPInSubnet(subnet).String())
+	}
+}
+
+func TestNextSubnet(t *testing.T) {
+	for _, tt := range []struct {
+		subnet, next string
+	}{
+		{"10.89.0.0/24", "10.89.1.0/24"},
+		{"10.89.255.0/24", "10.90.0.0/24"},
+		{"10.0.0.0/8", "11.0.0.0/8"},
+		{"fd00::/64", "fd00:0:0:1::/64"},
+	} {
+		next, err := NextSubnet(parseCIDR(t, tt.subnet))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.next, next.String())
+	}
+
+	_, err := NextSubnet(parseCIDR(t, "255.255.255.0/24"))
+	assert.Error(t, err)
+}
+
+func TestNetworkIntersect(t *testing.T) {
+	assert.True(t, networkIntersect(parseCIDR(t, "10.88.0.0/16"), parseCIDR(t, "10.88.3.0/24")))
+	assert.True(t, networkIntersect(parseCIDR(t, "10.88.3.0/24"), parseCIDR(t, "10.88.0.0/16")))
+	assert.False(t, networkIntersect(parseCIDR(t, "10.88.0.0/16"), parseCIDR(t, "10.89.0.0/24")))
+}
@@ -0,0 +1,562 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/pkg/util"
+	"github.com/containers/storage/pkg/lockfile"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// lockFileName is the name of the lock serializing changes to the
+	// networks and their users, kept in the CNI configuration directory
+	lockFileName = ".podman-network.lock"
+	// deviceNamePrefix is the prefix of the bridge devices and default
+	// names of networks created by Podman
+	deviceNamePrefix = "cni-podman"
+)
+
+var (
+	// nameRegex matches valid network names
+	nameRegex = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$")
+	// firstFreeSubnet is where the search for a free subnet starts, the
+	// default network using 10.88.0.0/16
+	firstFreeSubnet = net.IPNet{IP: net.IPv4(10, 89, 0, 0), Mask: net.CIDRMask(24, 32)}
+)
+
+// Network is a CNI network configuration list, loaded from the CNI
+// configuration directory
+type Network struct {
+	// Name is the name of the network
+	Name string
+	// Path is the configuration file of the network
+	Path string
+	// List is the parsed configuration list
+	List *libcni.NetworkConfigList
+}
+
+// pluginConf holds the fields of CNI plugin configurations which Podman
+// needs to know about
+type pluginConf struct {
+	Type   string `json:"type"`
+	Bridge string `json:"bridge"`
+	Master string `json:"master"`
+	IPAM   struct {
+		Subnet string `json:"subnet"`
+		Ranges [][]struct {
+			Subnet string `json:"subnet"`
+		} `json:"ranges"`
+	} `json:"ipam"`
+}
+
+// CreateOptions describes a network to be created
+type CreateOptions struct {
+	// Name is the name of the network. If empty, a name is generated.
+	Name string
+	// Driver is one of SupportedDrivers. The default is BridgeDriver.
+	Driver string
+	// Subnet is the subnet of the network. If nil, a free subnet is
+	// picked for bridge networks, and macvlan networks use DHCP.
+	Subnet *net.IPNet
+	// Gateway is the gateway of the network. If nil, the first address of
+	// the subnet is used.
+	Gateway net.IP
+	// IPRange restricts the addresses handed out to containers to a part
+	// of the subnet
+	IPRange *net.IPNet
+	// Options are driver specific options: "mtu" for all drivers and
+	// "parent" for the macvlan driver
+	Options map[string]string
+	// DNS adds the dnsname plugin to bridge networks, so containers on the
+	// network can resolve each other by name
+	DNS bool
+	// IPAMDataDir is the directory in which the host-local IPAM plugin
+	// records the addresses it hands out. If empty, the plugin default is
+	// used.
+	IPAMDataDir string
+}
+
+// LoadNetworks loads all networks of the given CNI configuration directory,
+// sorted by configuration file name. Unreadable configurations are skipped.
+func LoadNetworks(configDir string) ([]*Network, error) {
+	files, err := libcni.ConfFiles(configDir, []string{".conf", ".conflist", ".json"})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading CNI configuration directory %s", configDir)
+	}
+	sort.Strings(files)
+
+	networks := make([]*Network, 0, len(files))
+	for _, file := range files {
+		list, err := loadConfList(file)
+		if err != nil {
+			logrus.Warnf("Error loading CNI configuration %s: %v", file, err)
+			continue
+		}
+		networks = append(networks, &Network{
+			Name: list.Name,
+			Path: file,
+			List: list,
+		})
+	}
+	return networks, nil
+}
+
+// LoadNetwork loads the network of the given name from the given CNI
+// configuration directory
+func LoadNetwork(configDir, name string) (*Network, error) {
+	networks, err := LoadNetworks(configDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range networks {
+		if n.Name == name {
+			return n, nil
+		}
+	}
+	return nil, errors.Wrapf(define.ErrNoSuchNetwork, "unable to find network %s", name)
+}
+
+// loadConfList loads a CNI configuration file, converting a single plugin
+// configuration into a list
+func loadConfList(file string) (*libcni.NetworkConfigList, error) {
+	if filepath.Ext(file) == ".conflist" {
+		return libcni.ConfListFromFile(file)
+	}
+	conf, err := libcni.ConfFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return libcni.ConfListFromConf(conf)
+}
+
+// Plugins returns the types of the plugins of the network
+func (n *Network) Plugins() []string {
+	plugins := make([]string, 0, len(n.List.Plugins))
+	for _, plugin := range n.List.Plugins {
+		plugins = append(plugins, plugin.Network.Type)
+	}
+	return plugins
+}
+
+// HasCapability returns whether a plugin of the network declares the given
+// CNI capability
+func (n *Network) HasCapability(capability string) bool {
+	for _, plugin := range n.List.Plugins {
+		if plugin.Network.Capabilities[capability] {
+			return true
+		}
+	}
+	return false
+}
+
+// WithBandwidthPlugin returns the configuration list of the network with the
+// bandwidth plugin appended, unless one of its plugins already has the
+// bandwidth capability. The network itself is not modified.
+func (n *Network) WithBandwidthPlugin() (*libcni.NetworkConfigList, error) {
+	if n.HasCapability("bandwidth") {
+		return n.List, nil
+	}
+	data, err := json.Marshal(NewBandwidthPlugin())
+	if err != nil {
+		return nil, err
+	}
+	plugin, err := libcni.ConfFromBytes(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing bandwidth plugin configuration")
+	}
+	list := *n.List
+	list.Plugins = append(append([]*libcni.NetworkConfig{}, n.List.Plugins...), plugin)
+	return &list, nil
+}
+
+// Subnets returns the subnets of the network, as configured for its IPAM
+// plugins
+func (n *Network) Subnets() []*net.IPNet {
+	var subnets []*net.IPNet
+	for _, conf := range n.pluginConfs() {
+		cidrs := []string{}
+		if conf.IPAM.Subnet != "" {
+			cidrs = append(cidrs, conf.IPAM.Subnet)
+		}
+		for _, rangeSet := range conf.IPAM.Ranges {
+			for _, r := range rangeSet {
+				cidrs = append(cidrs, r.Subnet)
+			}
+		}
+		for _, cidr := range cidrs {
+			_, subnet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				logrus.Debugf("Ignoring invalid subnet %q of network %s", cidr, n.Name)
+				continue
+			}
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets
+}
+
+// Devices returns the host interfaces the network creates or attaches to
+func (n *Network) Devices() []string {
+	var devices []string
+	for _, conf := range n.pluginConfs() {
+		switch {
+		case conf.Bridge != "":
+			devices = append(devices, conf.Bridge)
+		case conf.Type == "bridge":
+			// The bridge plugin defaults to cni0
+			devices = append(devices, "cni0")
+		}
+	}
+	return devices
+}
+
+// pluginConfs parses the plugin configurations of the network
+func (n *Network) pluginConfs() []pluginConf {
+	confs := make([]pluginConf, 0, len(n.List.Plugins))
+	for _, plugin := range n.List.Plugins {
+		var conf pluginConf
+		if err := json.Unmarshal(plugin.Bytes, &conf); err != nil {
+			logrus.Debugf("Error parsing plugin %s of network %s: %v", plugin.Network.Type, n.Name, err)
+			continue
+		}
+		confs = append(confs, conf)
+	}
+	return confs
+}
+
+// HasDNSNamePlugin returns whether the dnsname plugin is installed in one of
+// the given CNI plugin directories
+func HasDNSNamePlugin(pluginDirs []string) bool {
+	for _, dir := range pluginDirs {
+		if _, err := os.Stat(filepath.Join(dir, "dnsname")); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateNetwork writes the configuration of a new network into the given CNI
+// configuration directory
+func CreateNetwork(configDir string, options CreateOptions) (*Network, error) {
+	if options.Driver == "" {
+		options.Driver = BridgeDriver
+	}
+	if !util.StringInSlice(options.Driver, SupportedDrivers) {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "unsupported network driver %q", options.Driver)
+	}
+	if options.Name != "" && !nameRegex.MatchString(options.Name) {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "network names must match [a-zA-Z0-9][a-zA-Z0-9_.-]*")
+	}
+
+	mtu := 0
+	for key, value := range options.Options {
+		switch {
+		case key == "mtu":
+			var err error
+			if mtu, err = strconv.Atoi(value); err != nil || mtu < 0 {
+				return nil, errors.Wrapf(define.ErrInvalidArg, "invalid mtu %q", value)
+			}
+		case key == "parent" && options.Driver == MacVLANDriver:
+		default:
+			return nil, errors.Wrapf(define.ErrInvalidArg, "unsupported option %q for the %s driver", key, options.Driver)
+		}
+	}
+
+	unlock, err := LockNetworks(configDir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	networks, err := LoadNetworks(configDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range networks {
+		if n.Name == options.Name {
+			return nil, errors.Wrapf(define.ErrNetworkExists, "network %s", options.Name)
+		}
+	}
+
+	devices, err := usedDeviceNames(networks)
+	if err != nil {
+		return nil, err
+	}
+	device := freeDeviceName(devices)
+	if options.Name == "" {
+		options.Name = freeNetworkName(networks, device)
+	}
+
+	if options.Subnet == nil && options.Driver == BridgeDriver {
+		if options.Subnet, err = freeSubnet(networks); err != nil {
+			return nil, err
+		}
+	} else if options.Subnet != nil {
+		if err := validateSubnetIsAvailable(options.Subnet, networks); err != nil {
+			return nil, err
+		}
+	}
+
+	var ipam interface{} = IPAMDHCP{PluginType: "dhcp"}
+	if options.Subnet != nil {
+		hostLocal, err := newIPAMHostLocal(options.Subnet, options.Gateway, options.IPRange)
+		if err != nil {
+			return nil, err
+		}
+		hostLocal.DataDir = options.IPAMDataDir
+		ipam = hostLocal
+	} else if options.Gateway != nil || options.IPRange != nil {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "a gateway or ip range requires a subnet")
+	}
+
+	ncList := NewNcList(options.Name, CNIVersion)
+	switch options.Driver {
+	case BridgeDriver:
+		bridge := NewHostLocalBridge(device, true, false, true, mtu, ipam.(IPAMHostLocalConf))
+		ncList.Plugins = append(ncList.Plugins, bridge, NewPortMapPlugin())
+		if options.DNS {
+			ncList.Plugins = append(ncList.Plugins, NewDNSNamePlugin(DefaultDNSDomain))
+		}
+	case MacVLANDriver:
+		parent := options.Options["parent"]
+		if parent == "" {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "the macvlan driver requires a parent interface (--opt parent=<interface>)")
+		}
+		if _, err := net.InterfaceByName(parent); err != nil {
+			return nil, errors.Wrapf(err, "unable to find parent interface %s", parent)
+		}
+		ncList.Plugins = append(ncList.Plugins, NewMacVLANPlugin(parent, mtu, ipam))
+	}
+
+	data, err := json.MarshalIndent(ncList, "", "   ")
+	if err != nil {
+		return nil, errors.Wrapf(err, "error encoding configuration of network %s", options.Name)
+	}
+	path := filepath.Join(configDir, options.Name+".conflist")
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, errors.Wrapf(define.ErrNetworkExists, "configuration file %s", path)
+		}
+		return nil, errors.Wrapf(err, "error creating configuration of network %s", options.Name)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(path)
+		return nil, errors.Wrapf(err, "error writing configuration of network %s", options.Name)
+	}
+	if err := file.Close(); err != nil {
+		return nil, errors.Wrapf(err, "error writing configuration of network %s", options.Name)
+	}
+
+	list, err := loadConfList(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading configuration of network %s", options.Name)
+	}
+	return &Network{Name: options.Name, Path: path, List: list}, nil
+}
+
+// LockNetworks acquires the lock serializing changes to the networks in the
+// given CNI configuration directory and to the containers connected to them.
+// It returns a function releasing the lock.
+func LockNetworks(configDir string) (func(), error) {
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "error creating CNI configuration directory %s", configDir)
+	}
+	lock, err := lockfile.GetLockfile(filepath.Join(configDir, lockFileName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error acquiring network lock")
+	}
+	lock.Lock()
+	return lock.Unlock, nil
+}
+
+// RemoveNetwork removes the configuration of the given network from the given
+// CNI configuration directory, along with the bridge it created. The caller
+// must hold the network lock, so that no container is connected to the
+// network after checking that it is unused.
+func RemoveNetwork(configDir, name string) error {
+	n, err := LoadNetwork(configDir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(n.Path); err != nil {
+		return errors.Wrapf(err, "error removing configuration of network %s", name)
+	}
+	for _, conf := range n.pluginConfs() {
+		if conf.Type == "bridge" && conf.Bridge != "" {
+			if err := removeDevice(conf.Bridge); err != nil {
+				logrus.Warnf("Error removing bridge %s of network %s: %v", conf.Bridge, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RawConfig returns the configuration file of the network, decoded for
+// display
+func (n *Network) RawConfig() (map[string]interface{}, error) {
+	data, err := ioutil.ReadFile(n.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading configuration of network %s", n.Name)
+	}
+	config := make(map[string]interface{})
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrapf(err, "error decoding configuration of network %s", n.Name)
+	}
+	return config, nil
+}
+
+// newIPAMHostLocal creates a host-local IPAM configuration for the given
+// subnet, gateway and address range
+func newIPAMHostLocal(subnet *net.IPNet, gateway net.IP, ipRange *net.IPNet) (IPAMHostLocalConf, error) {
+	if gateway == nil {
+		gateway = CalcGatewayIP(subnet)
+	} else if !subnet.Contains(gateway) {
+		return IPAMHostLocalConf{}, errors.Wrapf(define.ErrInvalidArg, "gateway %s is not in subnet %s", gateway.String(), subnet.String())
+	}
+	hostRange := IPAMLocalHostRangeConf{
+		Subnet:  subnet.String(),
+		Gateway: gateway.String(),
+	}
+	if ipRange != nil {
+		rangeOnes, _ := ipRange.Mask.Size()
+		subnetOnes, _ := subnet.Mask.Size()
+		if !subnet.Contains(ipRange.IP) || rangeOnes < subnetOnes {
+			return IPAMHostLocalConf{}, errors.Wrapf(define.ErrInvalidArg, "ip range %s is not in subnet %s", ipRange.String(), subnet.String())
+		}
+		start := ipRange.IP.Mask(ipRange.Mask)
+		if start.Equal(subnet.IP.Mask(subnet.Mask)) {
+			start = FirstIPInSubnet(subnet)
+		}
+		end := lastAddress(ipRange)
+		if end.Equal(lastAddress(subnet)) {
+			// Skip the broadcast address of the subnet
+			end = LastIPInSubnet(subnet)
+		}
+		hostRange.RangeStart = start.String()
+		hostRange.RangeEnd = end.String()
+	}
+	routes := []IPAMRoute{NewIPAMDefaultRoute(subnet.IP.To4() == nil)}
+	return NewIPAMHostLocalConf(routes, [][]IPAMLocalHostRangeConf{{hostRange}}), nil
+}
+
+// usedDeviceNames returns the names of the host interfaces and of the devices
+// of the given networks
+func usedDeviceNames(networks []*Network) (map[string]bool, error) {
+	used := make(map[string]bool)
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing host interfaces")
+	}
+	for _, iface := range interfaces {
+		used[iface.Name] = true
+	}
+	for _, n := range networks {
+		for _, device := range n.Devices() {
+			used[device] = true
+		}
+	}
+	return used, nil
+}
+
+// freeDeviceName returns the first device name not in use
+func freeDeviceName(used map[string]bool) string {
+	for i := 0; ; i++ {
+		name := fmt.Sprintf("%s%d", deviceNamePrefix, i)
+		if !used[name] {
+			return name
+		}
+	}
+}
+
+// freeNetworkName returns a name for a new network, preferring the name of
+// its device
+func freeNetworkName(networks []*Network, device string) string {
+	used := make(map[string]bool, len(networks))
+	for _, n := range networks {
+		used[n.Name] = true
+	}
+	if !used[device] {
+		return device
+	}
+	for i := 0; ; i++ {
+		name := fmt.Sprintf("%s%d", deviceNamePrefix, i)
+		if !used[name] {
+			return name
+		}
+	}
+}
+
+// usedSubnets returns the subnets of the given networks and of the host
+// interfaces
+func usedSubnets(networks []*Network) ([]*net.IPNet, error) {
+	var subnets []*net.IPNet
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing host addresses")
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			subnets = append(subnets, ipNet)
+		}
+	}
+	for _, n := range networks {
+		subnets = append(subnets, n.Subnets()...)
+	}
+	return subnets, nil
+}
+
+// freeSubnet returns the first /24 subnet starting at 10.89.0.0 which does not
+// overlap with any network or host interface
+func freeSubnet(networks []*Network) (*net.IPNet, error) {
+	used, err := usedSubnets(networks)
+	if err != nil {
+		return nil, err
+	}
+	limit := net.IPNet{IP: net.IPv4(10, 0, 0, 0), Mask: net.CIDRMask(8, 32)}
+	candidate := &net.IPNet{IP: firstFreeSubnet.IP.To4(), Mask: firstFreeSubnet.Mask}
+	for limit.Contains(candidate.IP) {
+		free := true
+		for _, subnet := range used {
+			if networkIntersect(candidate, subnet) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return candidate, nil
+		}
+		if candidate, err = NextSubnet(candidate); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.Errorf("unable to find a free subnet for the network, please specify one with --subnet")
+}
+
+// validateSubnetIsAvailable checks that the given subnet does not overlap
+// with any network or host interface
+func validateSubnetIsAvailable(subnet *net.IPNet, networks []*Network) error {
+	used, err := usedSubnets(networks)
+	if err != nil {
+		return err
+	}
+	for _, u := range used {
+		if networkIntersect(subnet, u) {
+			return errors.Wrapf(define.ErrInvalidArg, "subnet %s is already used on the host or by another network (%s)", subnet.String(), u.String())
+		}
+	}
+	return nil
+}
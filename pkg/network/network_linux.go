@@ -0,0 +1,21 @@
+package network
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+// removeDevice removes the given network device from the host, if it exists
+func removeDevice(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return nil
+		}
+		return errors.Wrapf(err, "error looking up device %s", name)
+	}
+	if err := netlink.LinkDel(link); err != nil {
+		return errors.Wrapf(err, "error removing device %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package network
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const defaultConflist = `{
+    "cniVersion": "0.3.0",
+    "name": "podman",
+    "plugins": [
+      {
+        "type": "bridge",
+        "bridge": "cni0",
+        "isGateway": true,
+        "ipMasq": true,
+        "ipam": {
+            "type": "host-local",
+            "subnet": "10.88.0.0/16",
+            "routes": [
+                { "dst": "0.0.0.0/0" }
+            ]
+        }
+      },
+      {
+        "type": "portmap",
+        "capabilities": {
+          "portMappings": true
+        }
+      }
+    ]
+}`
+
+func setupConfigDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "cni-config")
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "87-podman-bridge.conflist"), []byte(defaultConflist), 0644)
+	assert.NoError(t, err)
+	return dir
+}
+
+func TestLoadNetworks(t *testing.T) {
+	dir := setupConfigDir(t)
+	defer os.RemoveAll(dir)
+
+	networks, err := LoadNetworks(dir)
+	assert.NoError(t, err)
+	assert.Len(t, networks, 1)
+	assert.Equal(t, "podman", networks[0].Name)
+	assert.Equal(t, []string{"bridge", "portmap"}, networks[0].Plugins())
+	assert.Equal(t, []string{"cni0"}, networks[0].Devices())
+	assert.Len(t, networks[0].Subnets(), 1)
+	assert.Equal(t, "10.88.0.0/16", networks[0].Subnets()[0].String())
+
+	_, err = LoadNetwork(dir, "nonexistent")
+	assert.Equal(t, define.ErrNoSuchNetwork, errors.Cause(err))
+}
+
+func TestCreateAndRemoveNetwork(t *testing.T) {
+	dir := setupConfigDir(t)
+	defer os.RemoveAll(dir)
+
+	n, err := CreateNetwork(dir, CreateOptions{
+		Name:    "test",
+		Subnet:  parseCIDR(t, "10.250.3.0/24"),
+		IPRange: parseCIDR(t, "10.250.3.128/25"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "test.conflist"), n.Path)
+	assert.Equal(t, []string{"bridge", "portmap"}, n.Plugins())
+	assert.True(t, n.HasCapability("ips"))
+	assert.True(t, n.HasCapability("portMappings"))
+	assert.False(t, n.HasCapability("bandwidth"))
+
+	list, err := n.WithBandwidthPlugin()
+	assert.NoError(t, err)
+	assert.Len(t, list.Plugins, 3)
+	assert.Equal(t, "bandwidth", list.Plugins[2].Network.Type)
+	assert.True(t, list.Plugins[2].Network.Capabilities["bandwidth"])
+	assert.Equal(t, []string{"bridge", "portmap"}, n.Plugins())
+
+	config, err := n.RawConfig()
+	assert.NoError(t, err)
+	bridge := config["plugins"].([]interface{})[0].(map[string]interface{})
+	hostRange := bridge["ipam"].(map[string]interface{})["ranges"].([]interface{})[0].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "10.250.3.0/24", hostRange["subnet"])
+	assert.Equal(t, "10.250.3.1", hostRange["gateway"])
+	assert.Equal(t, "10.250.3.128", hostRange["rangeStart"])
+	assert.Equal(t, "10.250.3.254", hostRange["rangeEnd"])
+
+	_, err = CreateNetwork(dir, CreateOptions{Name: "test"})
+	assert.Equal(t, define.ErrNetworkExists, errors.Cause(err))
+
+	_, err = CreateNetwork(dir, CreateOptions{Name: "overlap", Subnet: parseCIDR(t, "10.250.3.0/25")})
+	assert.Equal(t, define.ErrInvalidArg, errors.Cause(err))
+
+	unlock, err := LockNetworks(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, RemoveNetwork(dir, "test"))
+	_, err = LoadNetwork(dir, "test")
+	assert.Equal(t, define.ErrNoSuchNetwork, errors.Cause(err))
+	assert.Equal(t, define.ErrNoSuchNetwork, errors.Cause(RemoveNetwork(dir, "test")))
+	unlock()
+}
+
+func TestCreateNetworkPicksFreeSubnet(t *testing.T) {
+	dir := setupConfigDir(t)
+	defer os.RemoveAll(dir)
+
+	first, err := CreateNetwork(dir, CreateOptions{})
+	assert.NoError(t, err)
+	second, err := CreateNetwork(dir, CreateOptions{})
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first.Name, second.Name)
+	assert.NotEqual(t, first.Devices(), second.Devices())
+	assert.Len(t, first.Subnets(), 1)
+	assert.Len(t, second.Subnets(), 1)
+	assert.False(t, networkIntersect(first.Subnets()[0], second.Subnets()[0]))
+	assert.False(t, networkIntersect(first.Subnets()[0], parseCIDR(t, "10.88.0.0/16")))
+}
+
+func TestCreateNetworkDNS(t *testing.T) {
+	dir := setupConfigDir(t)
+	defer os.RemoveAll(dir)
+
+	n, err := CreateNetwork(dir, CreateOptions{Name: "test", DNS: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bridge", "portmap", "dnsname"}, n.Plugins())
+
+	config, err := n.RawConfig()
+	assert.NoError(t, err)
+	dnsname := config["plugins"].([]interface{})[2].(map[string]interface{})
+	assert.Equal(t, DefaultDNSDomain, dnsname["domainName"])
+	assert.Equal(t, map[string]interface{}{"aliases": true}, dnsname["capabilities"])
+
+	assert.False(t, HasDNSNamePlugin([]string{dir}))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "dnsname"), nil, 0755))
+	assert.True(t, HasDNSNamePlugin([]string{"/nonexistent", dir}))
+}
+
+func TestCreateNetworkInvalidOptions(t *testing.T) {
+	dir := setupConfigDir(t)
+	defer os.RemoveAll(dir)
+
+	for _, options := range []CreateOptions{
+		{Driver: "overlay"},
+		{Name: "-invalid"},
+		{Options: map[string]string{"parent": "eth0"}},
+		{Options: map[string]string{"mtu": "big"}},
+		{Driver: MacVLANDriver},
+		{Subnet: parseCIDR(t, "10.250.3.0/24"), IPRange: parseCIDR(t, "10.250.4.0/25")},
+		{Subnet: parseCIDR(t, "10.250.3.0/24"), Gateway: parseCIDR(t, "10.250.4.1/32").IP},
+	} {
+		_, err := CreateNetwork(dir, options)
+		assert.Error(t, err)
+	}
+}
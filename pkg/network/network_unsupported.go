@@ -0,0 +1,11 @@
+// +build !linux
+
+package network
+
+import (
+	"github.com/containers/libpod/libpod/define"
+)
+
+func removeDevice(name string) error {
+	return define.ErrOSNotSupported
+}
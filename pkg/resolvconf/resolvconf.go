@@ -216,6 +216,23 @@ func GetOptions(resolvConf []byte) []string {
 	return options
 }
 
+// PrependUnique returns the given entries followed by the existing ones, with
+// duplicates removed. It is used to put the nameservers and search domains of
+// container networks in front of those of the host.
+func PrependUnique(entries []string, existing []string) []string {
+	result := make([]string, 0, len(entries)+len(existing))
+	seen := make(map[string]bool)
+	for _, list := range [][]string{entries, existing} {
+		for _, entry := range list {
+			if !seen[entry] {
+				seen[entry] = true
+				result = append(result, entry)
+			}
+		}
+	}
+	return result
+}
+
 // Build writes a configuration file to path containing a "nameserver" entry
 // for every element in dns, a "search" entry for every element in
 // dnsSearch, and an "options" entry for every element in dnsOptions.
@@ -498,6 +498,9 @@ reexec_userns_join (int userns, int mountns, char *pause_pid_file_path)
   char **argv;
   int pid;
   char *cwd = getcwd (NULL, 0);
+  char *listen_fds = NULL;
+  char *listen_pid = NULL;
+  bool do_socket_activation = false;
   sigset_t sigset, oldsigset;
 
   if (cwd == NULL)
@@ -516,6 +519,15 @@ reexec_userns_join (int userns, int mountns, char *pause_pid_file_path)
       _exit (EXIT_FAILURE);
     }
 
+  listen_pid = getenv ("LISTEN_PID");
+  listen_fds = getenv ("LISTEN_FDS");
+
+  if (listen_pid != NULL && listen_fds != NULL)
+    {
+      if (strtol (listen_pid, NULL, 10) == ppid)
+        do_socket_activation = true;
+    }
+
   pid = fork ();
   if (pid < 0)
     fprintf (stderr, "cannot fork: %s\n", strerror (errno));
@@ -553,6 +565,14 @@ reexec_userns_join (int userns, int mountns, char *pause_pid_file_path)
       _exit (EXIT_FAILURE);
     }
 
+  /* The listening sockets are passed down, make them ours.  */
+  if (do_socket_activation)
+    {
+      char s[32];
+      sprintf (s, "%d", getpid ());
+      setenv ("LISTEN_PID", s, true);
+    }
+
   setenv ("_CONTAINERS_USERNS_CONFIGURED", "init", 1);
   setenv ("_CONTAINERS_ROOTLESS_UID", uid, 1);
   setenv ("_CONTAINERS_ROOTLESS_GID", gid, 1);
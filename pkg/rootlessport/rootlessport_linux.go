@@ -0,0 +1,462 @@
+// +build linux
+
+// Package rootlessport forwards ports from the host into the network
+// namespace of a rootless container.
+//
+// The forwarder runs as a separate process, started by re-executing podman,
+// so it outlives the podman command that started the container. It listens
+// on the host with the privileges of the rootless user and dials the
+// container from inside its network namespace.
+//
+// To let the container see the real address of its clients, the address of
+// each client can be made local to the network namespace for as long as the
+// client is connected, and connections to the container made from it. As the
+// container cannot reach the client through the network meanwhile, this is
+// only done if PreserveClientAddr is set.
+package rootlessport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/containers/storage/pkg/reexec"
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// ReexecKey is the name podman is re-executed with to run the
+	// port forwarder
+	ReexecKey = "rootlessport"
+	// ReadyMessage is written to the ready pipe once all ports are bound
+	ReadyMessage = "ready"
+
+	dialTimeout    = 5 * time.Second
+	udpIdleTimeout = 60 * time.Second
+)
+
+// Config is the configuration of the port forwarder, passed as JSON on its
+// standard input
+type Config struct {
+	// Mappings are the ports to forward
+	Mappings []ocicni.PortMapping `json:"mappings"`
+	// NetNSPath is the path of the network namespace connections to the
+	// container are made from: the namespace of the container itself, or
+	// the rootless network namespace for containers on CNI networks
+	NetNSPath string `json:"netNSPath"`
+	// ContainerIP is the address connections to the container are made to
+	ContainerIP string `json:"containerIP"`
+	// Subnet is the subnet of the container network. Addresses of clients
+	// in it are never made local to the network namespace, as that would
+	// shadow the gateway and the DNS forwarder.
+	Subnet string `json:"subnet"`
+	// PreserveClientAddr makes connections to the container come from the
+	// address of the client when possible
+	PreserveClientAddr bool `json:"preserveClientAddr"`
+}
+
+func init() {
+	reexec.Register(ReexecKey, func() {
+		if err := child(); err != nil {
+			logrus.Errorf("%v", err)
+			os.Exit(1)
+		}
+	})
+}
+
+// child is the entry point of the port forwarder process. The exit pipe is
+// inherited as fd 3, and the ready pipe as fd 4. The forwarder runs until the
+// exit pipe is closed by the container exiting.
+func child() error {
+	exitR := os.NewFile(3, "exit-fd")
+	readyW := os.NewFile(4, "ready-fd")
+	defer exitR.Close()
+
+	err := func() error {
+		defer readyW.Close()
+		var cfg Config
+		if err := json.NewDecoder(os.Stdin).Decode(&cfg); err != nil {
+			err = errors.Wrapf(err, "error decoding port forwarder configuration")
+			fmt.Fprintf(readyW, "%v", err)
+			return err
+		}
+		f, err := newForwarder(&cfg)
+		if err == nil {
+			err = f.listen(cfg.Mappings)
+		}
+		if err != nil {
+			fmt.Fprintf(readyW, "%v", err)
+			return err
+		}
+		_, err = readyW.Write([]byte(ReadyMessage))
+		return err
+	}()
+	if err != nil {
+		return err
+	}
+
+	// Wait for the container to exit
+	_, err = ioutil.ReadAll(exitR)
+	return err
+}
+
+// netNS runs functions on a thread in the network namespace of the
+// container. Sockets created there belong to that namespace.
+type netNS struct {
+	reqs chan func()
+}
+
+func joinNetNS(path string) (*netNS, error) {
+	nsFile, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening network namespace %s", path)
+	}
+	defer nsFile.Close()
+
+	ns := &netNS{reqs: make(chan func())}
+	errCh := make(chan error)
+	go func() {
+		// The thread is never unlocked, so it is never reused outside
+		// of the namespace
+		runtime.LockOSThread()
+		if err := unix.Setns(int(nsFile.Fd()), unix.CLONE_NEWNET); err != nil {
+			errCh <- errors.Wrapf(err, "error joining network namespace %s", path)
+			return
+		}
+		errCh <- nil
+		for fn := range ns.reqs {
+			fn()
+		}
+	}()
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+func (ns *netNS) do(fn func() error) error {
+	errCh := make(chan error, 1)
+	ns.reqs <- func() {
+		errCh <- fn()
+	}
+	return <-errCh
+}
+
+type forwarder struct {
+	ns          *netNS
+	containerIP net.IP
+	subnet      *net.IPNet
+	// containerLocal is set if the container address is an address of
+	// the network namespace, that is if the forwarder shares the network
+	// namespace of the container rather than reaching it through a bridge
+	containerLocal bool
+	// preserveClientAddr is set if connections to the container are
+	// made from the address of the client
+	preserveClientAddr bool
+
+	lock sync.Mutex
+	// sources counts the connections made from each client address that
+	// was made local to the network namespace
+	sources map[string]int
+}
+
+func newForwarder(cfg *Config) (*forwarder, error) {
+	containerIP := net.ParseIP(cfg.ContainerIP)
+	if containerIP == nil {
+		return nil, errors.Errorf("invalid container IP address %q", cfg.ContainerIP)
+	}
+	_, subnet, err := net.ParseCIDR(cfg.Subnet)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid container subnet %q", cfg.Subnet)
+	}
+	ns, err := joinNetNS(cfg.NetNSPath)
+	if err != nil {
+		return nil, err
+	}
+	f := &forwarder{
+		ns:                 ns,
+		containerIP:        containerIP,
+		subnet:             subnet,
+		preserveClientAddr: cfg.PreserveClientAddr,
+		sources:            make(map[string]int),
+	}
+	err = ns.do(func() error {
+		addrs, err := netlink.AddrList(nil, netlink.FAMILY_ALL)
+		if err != nil {
+			return err
+		}
+		for _, addr := range addrs {
+			if addr.IP.Equal(containerIP) {
+				f.containerLocal = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing addresses of network namespace %s", cfg.NetNSPath)
+	}
+	return f, nil
+}
+
+// listenNetwork returns the network to listen on for the given port mapping
+func listenNetwork(m ocicni.PortMapping) (string, error) {
+	if m.Protocol != "tcp" && m.Protocol != "udp" {
+		return "", errors.Errorf("cannot forward %s ports", m.Protocol)
+	}
+	if m.HostIP == "" {
+		return m.Protocol, nil
+	}
+	ip := net.ParseIP(m.HostIP)
+	if ip == nil {
+		return "", errors.Errorf("invalid host IP address %q", m.HostIP)
+	}
+	if ip.To4() != nil {
+		return m.Protocol + "4", nil
+	}
+	return m.Protocol + "6", nil
+}
+
+// listen binds all ports and starts forwarding them
+func (f *forwarder) listen(mappings []ocicni.PortMapping) error {
+	for _, m := range mappings {
+		network, err := listenNetwork(m)
+		if err != nil {
+			return err
+		}
+		addr := net.JoinHostPort(m.HostIP, strconv.Itoa(int(m.HostPort)))
+		if m.Protocol == "tcp" {
+			l, err := net.Listen(network, addr)
+			if err != nil {
+				return errors.Wrapf(err, "cannot listen on the TCP port %s", addr)
+			}
+			go f.serveTCP(l, m.ContainerPort)
+			continue
+		}
+		udpAddr, err := net.ResolveUDPAddr(network, addr)
+		if err != nil {
+			return err
+		}
+		l, err := net.ListenUDP(network, udpAddr)
+		if err != nil {
+			return errors.Wrapf(err, "cannot listen on the UDP port %s", addr)
+		}
+		go f.serveUDP(l, m.ContainerPort)
+	}
+	return nil
+}
+
+// canUseSource checks whether connections to the container can be made from
+// the address of a client
+func (f *forwarder) canUseSource(ip net.IP) bool {
+	return f.preserveClientAddr && ip.To4() != nil && f.containerIP.To4() != nil && !ip.IsLoopback() && !ip.IsUnspecified() && !f.subnet.Contains(ip)
+}
+
+// sourceRoute returns the route making the address of a client local to the
+// network namespace. When the container shares the namespace, replies to the
+// client are sent from the container address, as sockets that are not bound
+// to an address would otherwise reply from the client address itself.
+func (f *forwarder) sourceRoute(ip net.IP) (*netlink.Route, error) {
+	lo, err := netlink.LinkByName("lo")
+	if err != nil {
+		return nil, err
+	}
+	route := &netlink.Route{
+		LinkIndex: lo.Attrs().Index,
+		Dst:       &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)},
+		Table:     unix.RT_TABLE_LOCAL,
+		Type:      unix.RTN_LOCAL,
+		Scope:     netlink.SCOPE_HOST,
+	}
+	if f.containerLocal {
+		route.Src = f.containerIP.To4()
+	}
+	return route, nil
+}
+
+// acquireSource makes the address of a client local to the network
+// namespace, so connections can be made from it. It returns false if the
+// address cannot be used.
+func (f *forwarder) acquireSource(ip net.IP) bool {
+	if !f.canUseSource(ip) {
+		return false
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	key := ip.String()
+	if f.sources[key] == 0 {
+		err := f.ns.do(func() error {
+			route, err := f.sourceRoute(ip)
+			if err != nil {
+				return err
+			}
+			return netlink.RouteAdd(route)
+		})
+		if err != nil {
+			logrus.Debugf("cannot use %s as source address, the container will not see the client address: %v", key, err)
+			return false
+		}
+	}
+	f.sources[key]++
+	return true
+}
+
+// releaseSource removes the address of a client from the network namespace
+// once no connections are made from it
+func (f *forwarder) releaseSource(ip net.IP) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	key := ip.String()
+	f.sources[key]--
+	if f.sources[key] > 0 {
+		return
+	}
+	delete(f.sources, key)
+	err := f.ns.do(func() error {
+		route, err := f.sourceRoute(ip)
+		if err != nil {
+			return err
+		}
+		return netlink.RouteDel(route)
+	})
+	if err != nil {
+		logrus.Errorf("error removing source address %s: %v", key, err)
+	}
+}
+
+// dial connects to the container port from within its network namespace,
+// from the client address when possible. The returned function must be
+// called once the connection is closed.
+func (f *forwarder) dial(network string, client net.IP, port int32) (net.Conn, func(), error) {
+	useSource := f.acquireSource(client)
+	release := func() {
+		if useSource {
+			f.releaseSource(client)
+		}
+	}
+
+	dialer := net.Dialer{Timeout: dialTimeout}
+	if useSource {
+		if network == "tcp" {
+			dialer.LocalAddr = &net.TCPAddr{IP: client}
+		} else {
+			dialer.LocalAddr = &net.UDPAddr{IP: client}
+		}
+	}
+	target := net.JoinHostPort(f.containerIP.String(), strconv.Itoa(int(port)))
+
+	var conn net.Conn
+	err := f.ns.do(func() error {
+		var err error
+		conn, err = dialer.Dial(network, target)
+		return err
+	})
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return conn, release, nil
+}
+
+func (f *forwarder) serveTCP(l net.Listener, port int32) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			logrus.Errorf("error accepting connection on %s: %v", l.Addr(), err)
+			return
+		}
+		go f.handleTCP(conn, port)
+	}
+}
+
+func (f *forwarder) handleTCP(conn net.Conn, port int32) {
+	defer conn.Close()
+	client := conn.RemoteAddr().(*net.TCPAddr)
+	ctrConn, release, err := f.dial("tcp", client.IP, port)
+	if err != nil {
+		logrus.Errorf("error connecting to container port %d: %v", port, err)
+		return
+	}
+	defer release()
+	defer ctrConn.Close()
+
+	var wg sync.WaitGroup
+	copyHalf := func(dst, src net.Conn) {
+		defer wg.Done()
+		if _, err := io.Copy(dst, src); err != nil {
+			logrus.Debugf("error forwarding container port %d: %v", port, err)
+		}
+		if tcpConn, ok := dst.(*net.TCPConn); ok {
+			_ = tcpConn.CloseWrite()
+		}
+	}
+	wg.Add(2)
+	go copyHalf(ctrConn, conn)
+	go copyHalf(conn, ctrConn)
+	wg.Wait()
+}
+
+func (f *forwarder) serveUDP(l *net.UDPConn, port int32) {
+	var lock sync.Mutex
+	sessions := make(map[string]net.Conn)
+
+	buf := make([]byte, 65535)
+	for {
+		n, client, err := l.ReadFromUDP(buf)
+		if err != nil {
+			logrus.Errorf("error reading from %s: %v", l.LocalAddr(), err)
+			return
+		}
+
+		lock.Lock()
+		ctrConn, ok := sessions[client.String()]
+		if !ok {
+			var release func()
+			ctrConn, release, err = f.dial("udp", client.IP, port)
+			if err != nil {
+				lock.Unlock()
+				logrus.Errorf("error connecting to container port %d: %v", port, err)
+				continue
+			}
+			sessions[client.String()] = ctrConn
+
+			// Forward replies until the session is idle
+			go func(ctrConn net.Conn, client *net.UDPAddr) {
+				defer func() {
+					lock.Lock()
+					delete(sessions, client.String())
+					lock.Unlock()
+					ctrConn.Close()
+					release()
+				}()
+				reply := make([]byte, 65535)
+				for {
+					_ = ctrConn.SetReadDeadline(time.Now().Add(udpIdleTimeout))
+					n, err := ctrConn.Read(reply)
+					if err != nil {
+						return
+					}
+					if _, err := l.WriteToUDP(reply[:n], client); err != nil {
+						return
+					}
+				}
+			}(ctrConn, client)
+		}
+		lock.Unlock()
+
+		_ = ctrConn.SetReadDeadline(time.Now().Add(udpIdleTimeout))
+		if _, err := ctrConn.Write(buf[:n]); err != nil {
+			logrus.Debugf("error forwarding to container port %d: %v", port, err)
+		}
+	}
+}
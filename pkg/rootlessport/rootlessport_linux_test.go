@@ -0,0 +1,129 @@
+// +build linux
+
+package rootlessport
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/libpod/pkg/netns"
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func TestListenNetwork(t *testing.T) {
+	for _, tc := range []struct {
+		mapping ocicni.PortMapping
+		network string
+	}{
+		{ocicni.PortMapping{Protocol: "tcp"}, "tcp"},
+		{ocicni.PortMapping{Protocol: "udp", HostIP: "127.0.0.1"}, "udp4"},
+		{ocicni.PortMapping{Protocol: "tcp", HostIP: "::1"}, "tcp6"},
+	} {
+		network, err := listenNetwork(tc.mapping)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.network, network)
+	}
+
+	_, err := listenNetwork(ocicni.PortMapping{Protocol: "sctp"})
+	assert.Error(t, err)
+	_, err = listenNetwork(ocicni.PortMapping{Protocol: "tcp", HostIP: "bogus"})
+	assert.Error(t, err)
+}
+
+func TestCanUseSource(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.0.2.0/24")
+	f := &forwarder{containerIP: net.ParseIP("10.0.2.100"), subnet: subnet, preserveClientAddr: true}
+	assert.True(t, f.canUseSource(net.ParseIP("192.168.1.10")))
+	assert.False(t, f.canUseSource(net.ParseIP("127.0.0.1")))
+	assert.False(t, f.canUseSource(net.ParseIP("10.0.2.2")))
+	assert.False(t, f.canUseSource(net.ParseIP("fd00::1")))
+
+	f.preserveClientAddr = false
+	assert.False(t, f.canUseSource(net.ParseIP("192.168.1.10")))
+}
+
+// TestDialClientAddr checks that the address of a client is only routed to
+// the forwarder while it is connected, and only if client addresses are
+// preserved
+func TestDialClientAddr(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("creating network namespaces requires root")
+	}
+
+	ctrNS, err := netns.NewNS()
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, netns.UnmountNS(ctrNS))
+		ctrNS.Close()
+	}()
+	var listener net.Listener
+	err = ctrNS.Do(func(ns.NetNS) error {
+		lo, err := netlink.LinkByName("lo")
+		if err != nil {
+			return err
+		}
+		addr, err := netlink.ParseAddr("10.0.2.100/24")
+		if err != nil {
+			return err
+		}
+		if err := netlink.AddrAdd(lo, addr); err != nil {
+			return err
+		}
+		if err := netlink.LinkSetUp(lo); err != nil {
+			return err
+		}
+		listener, err = net.Listen("tcp", "10.0.2.100:8080")
+		return err
+	})
+	require.NoError(t, err)
+	defer listener.Close()
+
+	client := net.ParseIP("192.0.2.10")
+	clientRouted := func() bool {
+		var routed bool
+		err := ctrNS.Do(func(ns.NetNS) error {
+			routes, err := netlink.RouteListFiltered(netlink.FAMILY_V4, &netlink.Route{Table: unix.RT_TABLE_LOCAL}, netlink.RT_FILTER_TABLE)
+			for _, route := range routes {
+				if route.Dst != nil && route.Dst.IP.Equal(client) {
+					routed = true
+				}
+			}
+			return err
+		})
+		require.NoError(t, err)
+		return routed
+	}
+
+	for _, preserve := range []bool{false, true} {
+		f, err := newForwarder(&Config{
+			NetNSPath:          ctrNS.Path(),
+			ContainerIP:        "10.0.2.100",
+			Subnet:             "10.0.2.0/24",
+			PreserveClientAddr: preserve,
+		})
+		require.NoError(t, err)
+
+		conn, release, err := f.dial("tcp", client, 8080)
+		require.NoError(t, err)
+		ctrConn, err := listener.Accept()
+		require.NoError(t, err)
+		remote := ctrConn.RemoteAddr().(*net.TCPAddr).IP
+		if preserve {
+			assert.True(t, remote.Equal(client), "container sees %s", remote)
+		} else {
+			assert.False(t, remote.Equal(client), "container sees %s", remote)
+		}
+		assert.Equal(t, preserve, clientRouted())
+
+		ctrConn.Close()
+		conn.Close()
+		release()
+		assert.False(t, clientRouted())
+	}
+}
@@ -17,10 +17,11 @@ func (config *CreateConfig) MakeContainerConfig(runtime *libpod.Runtime, pod *li
 		return nil, nil, errors.Wrapf(define.ErrInvalidArg, "pod was given but no pod is specified")
 	}
 
-	// Parse volumes flag into OCI spec mounts and libpod Named Volumes.
+	// Parse volumes flag into OCI spec mounts, libpod Named Volumes and
+	// libpod Overlay Volumes.
 	// If there is an identical mount in the OCI spec, we will replace it
 	// with a mount generated here.
-	mounts, namedVolumes, err := config.parseVolumes(runtime)
+	mounts, namedVolumes, overlayVolumes, err := config.parseVolumes(runtime)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -30,7 +31,7 @@ func (config *CreateConfig) MakeContainerConfig(runtime *libpod.Runtime, pod *li
 		return nil, nil, err
 	}
 
-	options, err := config.getContainerCreateOptions(runtime, pod, mounts, namedVolumes)
+	options, err := config.getContainerCreateOptions(runtime, pod, mounts, namedVolumes, overlayVolumes)
 	if err != nil {
 		return nil, nil, err
 	}
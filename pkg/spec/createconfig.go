@@ -6,11 +6,13 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/containers/image/manifest"
 	"github.com/containers/libpod/libpod"
 	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/pkg/namespaces"
+	"github.com/containers/libpod/pkg/rootless"
 	"github.com/containers/storage"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/docker/go-connections/nat"
@@ -66,6 +68,7 @@ type CreateConfig struct {
 	Cgroupns           string
 	CgroupParent       string            // cgroup-parent
 	Command            []string          // Full command that will be used
+	CreateCommand      []string          // Full command line the container is created with
 	UserCommand        []string          // User-entered command (or image CMD)
 	Detach             bool              // detach
 	Devices            []string          // device
@@ -90,7 +93,9 @@ type CreateConfig struct {
 	ImageVolumeType    string                 // how to handle the image volume, either bind, tmpfs, or ignore
 	Interactive        bool                   //interactive
 	IpcMode            namespaces.IpcMode     //ipc
-	IP6Address         string                 //ipv6
+	IsInitCtr          bool                   // init container of a pod
+	SdNotifyMode       string                 // sdnotify
+	IP6Address         string                 //ip6
 	IPAddress          string                 //ip
 	Labels             map[string]string      //label
 	LinkLocalIP        []string               // link-local-ip
@@ -113,6 +118,8 @@ type CreateConfig struct {
 	ReadOnlyTmpfs      bool     //read-only-tmpfs
 	Resources          CreateResourceConfig
 	RestartPolicy      string
+	RestartBackoff     time.Duration     // restart-backoff
+	RestartBackoffMax  time.Duration     // restart-backoff-max
 	Rm                 bool              //rm
 	StopSignal         syscall.Signal    // stop-signal
 	StopTimeout        uint              // stop-timeout
@@ -136,6 +143,8 @@ type CreateConfig struct {
 	SecurityOpts       []string
 	Rootfs             string
 	Syslog             bool // Whether to enable syslog on exit commands
+	// Bandwidth holds the limits set by --network-{ingress,egress}-{rate,burst}
+	Bandwidth *ocicni.BandwidthConfig
 }
 
 func u32Ptr(i int64) *uint32     { u := uint32(i); return &u }
@@ -186,7 +195,7 @@ func (c *CreateConfig) createExitCommand(runtime *libpod.Runtime) ([]string, err
 }
 
 // GetContainerCreateOptions takes a CreateConfig and returns a slice of CtrCreateOptions
-func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *libpod.Pod, mounts []spec.Mount, namedVolumes []*libpod.ContainerNamedVolume) ([]libpod.CtrCreateOption, error) {
+func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *libpod.Pod, mounts []spec.Mount, namedVolumes []*libpod.ContainerNamedVolume, overlayVolumes []*libpod.ContainerOverlayVolume) ([]libpod.CtrCreateOption, error) {
 	var options []libpod.CtrCreateOption
 	var portBindings []ocicni.PortMapping
 	var err error
@@ -213,16 +222,19 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 		}
 	}
 
-	if len(mounts) != 0 || len(namedVolumes) != 0 {
+	if len(mounts) != 0 || len(namedVolumes) != 0 || len(overlayVolumes) != 0 {
 		destinations := []string{}
 
-		// Take all mount and named volume destinations.
+		// Take all mount, named volume and overlay volume destinations.
 		for _, mount := range mounts {
 			destinations = append(destinations, mount.Destination)
 		}
 		for _, volume := range namedVolumes {
 			destinations = append(destinations, volume.Dest)
 		}
+		for _, volume := range overlayVolumes {
+			destinations = append(destinations, volume.Dest)
+		}
 
 		options = append(options, libpod.WithUserVolumes(destinations))
 	}
@@ -231,6 +243,10 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 		options = append(options, libpod.WithNamedVolumes(namedVolumes))
 	}
 
+	if len(overlayVolumes) != 0 {
+		options = append(options, libpod.WithOverlayVolumes(overlayVolumes))
+	}
+
 	if len(c.UserCommand) != 0 {
 		options = append(options, libpod.WithCommand(c.UserCommand))
 	}
@@ -271,8 +287,27 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 		options = append(options, libpod.WithNetNSFrom(connectedCtr))
 	} else if !c.NetMode.IsHost() && !c.NetMode.IsNone() {
 		hasUserns := c.UsernsMode.IsContainer() || c.UsernsMode.IsNS() || len(c.IDMappings.UIDMap) > 0 || len(c.IDMappings.GIDMap) > 0
-		postConfigureNetNS := c.NetMode.IsSlirp4netns() || (hasUserns && !c.UsernsMode.IsHost())
-		options = append(options, libpod.WithNetNS(portBindings, postConfigureNetNS, string(c.NetMode), networks))
+		// Rootless containers on CNI networks forward their ports with a
+		// forwarder tied to the container process
+		postConfigureNetNS := c.NetMode.IsSlirp4netns() || (hasUserns && !c.UsernsMode.IsHost()) || rootless.IsRootless()
+		netMode := string(c.NetMode)
+		if c.NetMode.IsSlirp4netns() {
+			netMode = "slirp4netns"
+		}
+		options = append(options, libpod.WithNetNS(portBindings, postConfigureNetNS, netMode, networks))
+		if slirpOptions := c.NetMode.Slirp4netnsOptions(); len(slirpOptions) > 0 {
+			options = append(options, libpod.WithNetworkOptions(map[string][]string{"slirp4netns": slirpOptions}))
+		}
+		if len(c.NetworkAlias) > 0 {
+			options = append(options, libpod.WithNetworkAliases(c.NetworkAlias))
+		}
+		if c.Bandwidth != nil {
+			options = append(options, libpod.WithBandwidth(*c.Bandwidth))
+		}
+	} else if len(c.NetworkAlias) > 0 {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "network aliases require a network namespace created by podman")
+	} else if c.Bandwidth != nil {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "bandwidth limits require a network namespace created by podman")
 	}
 
 	if c.CgroupMode.IsNS() {
@@ -383,6 +418,16 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 		options = append(options, libpod.WithStaticIP(ip))
 	}
 
+	if c.IP6Address != "" {
+		ip := net.ParseIP(c.IP6Address)
+		if ip == nil {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "cannot parse %s as IP address", c.IP6Address)
+		} else if ip.To4() != nil {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "%s is not an IPv6 address", c.IP6Address)
+		}
+		options = append(options, libpod.WithStaticIPv6(ip))
+	}
+
 	options = append(options, libpod.WithPrivileged(c.Privileged))
 
 	useImageVolumes := c.ImageVolumeType == TypeBind
@@ -407,6 +452,15 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 	if c.Rootfs != "" {
 		options = append(options, libpod.WithRootFS(c.Rootfs))
 	}
+	if c.IsInitCtr {
+		options = append(options, libpod.WithInitCtr())
+	}
+	if c.SdNotifyMode != "" {
+		options = append(options, libpod.WithSdNotifyMode(c.SdNotifyMode))
+	}
+	if len(c.CreateCommand) > 0 {
+		options = append(options, libpod.WithCreateCommand(c.CreateCommand))
+	}
 	// Default used if not overridden on command line
 
 	if c.CgroupParent != "" {
@@ -414,10 +468,6 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 	}
 
 	if c.RestartPolicy != "" {
-		if c.RestartPolicy == "unless-stopped" {
-			return nil, errors.Wrapf(define.ErrInvalidArg, "the unless-stopped restart policy is not supported")
-		}
-
 		split := strings.Split(c.RestartPolicy, ":")
 		if len(split) > 1 {
 			numTries, err := strconv.Atoi(split[1])
@@ -432,6 +482,10 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 		options = append(options, libpod.WithRestartPolicy(split[0]))
 	}
 
+	if c.RestartBackoff != 0 || c.RestartBackoffMax != 0 {
+		options = append(options, libpod.WithRestartBackoff(c.RestartBackoff, c.RestartBackoffMax))
+	}
+
 	// Always use a cleanup process to clean up Podman after termination
 	exitCmd, err := c.createExitCommand(runtime)
 	if err != nil {
@@ -31,24 +31,24 @@ var (
 	noDestError      = errors.Errorf("must set volume destination")
 )
 
-// Parse all volume-related options in the create config into a set of mounts
-// and named volumes to add to the container.
+// Parse all volume-related options in the create config into a set of mounts,
+// named volumes and overlay volumes to add to the container.
 // Handles --volumes-from, --volumes, --tmpfs, --init, and --init-path flags.
 // TODO: Named volume options -  should we default to rprivate? It bakes into a
 // bind mount under the hood...
 // TODO: handle options parsing/processing via containers/storage/pkg/mount
-func (config *CreateConfig) parseVolumes(runtime *libpod.Runtime) ([]spec.Mount, []*libpod.ContainerNamedVolume, error) {
+func (config *CreateConfig) parseVolumes(runtime *libpod.Runtime) ([]spec.Mount, []*libpod.ContainerNamedVolume, []*libpod.ContainerOverlayVolume, error) {
 	// Add image volumes.
 	baseMounts, baseVolumes, err := config.getImageVolumes()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Add --volumes-from.
 	// Overrides image volumes unconditionally.
 	vFromMounts, vFromVolumes, err := config.getVolumesFrom(runtime)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	for dest, mount := range vFromMounts {
 		baseMounts[dest] = mount
@@ -61,21 +61,21 @@ func (config *CreateConfig) parseVolumes(runtime *libpod.Runtime) ([]spec.Mount,
 	// Do not override yet.
 	unifiedMounts, unifiedVolumes, err := config.getMounts()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Next --volumes flag.
 	// Do not override yet.
 	volumeMounts, volumeVolumes, err := config.getVolumeMounts()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Next --tmpfs flag.
 	// Do not override yet.
 	tmpfsMounts, err := config.getTmpfsMounts()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Unify mounts from --mount, --volume, --tmpfs.
@@ -83,20 +83,20 @@ func (config *CreateConfig) parseVolumes(runtime *libpod.Runtime) ([]spec.Mount,
 	// Start with --volume.
 	for dest, mount := range volumeMounts {
 		if _, ok := unifiedMounts[dest]; ok {
-			return nil, nil, errors.Wrapf(errDuplicateDest, dest)
+			return nil, nil, nil, errors.Wrapf(errDuplicateDest, dest)
 		}
 		unifiedMounts[dest] = mount
 	}
 	for dest, volume := range volumeVolumes {
 		if _, ok := unifiedVolumes[dest]; ok {
-			return nil, nil, errors.Wrapf(errDuplicateDest, dest)
+			return nil, nil, nil, errors.Wrapf(errDuplicateDest, dest)
 		}
 		unifiedVolumes[dest] = volume
 	}
 	// Now --tmpfs
 	for dest, tmpfs := range tmpfsMounts {
 		if _, ok := unifiedMounts[dest]; ok {
-			return nil, nil, errors.Wrapf(errDuplicateDest, dest)
+			return nil, nil, nil, errors.Wrapf(errDuplicateDest, dest)
 		}
 		unifiedMounts[dest] = tmpfs
 	}
@@ -104,14 +104,14 @@ func (config *CreateConfig) parseVolumes(runtime *libpod.Runtime) ([]spec.Mount,
 	for _, mount := range config.Mounts {
 		dest := mount.Destination
 		if _, ok := unifiedMounts[dest]; ok {
-			return nil, nil, errors.Wrapf(errDuplicateDest, dest)
+			return nil, nil, nil, errors.Wrapf(errDuplicateDest, dest)
 		}
 		unifiedMounts[dest] = mount
 	}
 	for _, volume := range config.NamedVolumes {
 		dest := volume.Dest
 		if _, ok := unifiedVolumes[dest]; ok {
-			return nil, nil, errors.Wrapf(errDuplicateDest, dest)
+			return nil, nil, nil, errors.Wrapf(errDuplicateDest, dest)
 		}
 		unifiedVolumes[dest] = volume
 	}
@@ -122,16 +122,16 @@ func (config *CreateConfig) parseVolumes(runtime *libpod.Runtime) ([]spec.Mount,
 		if initPath == "" {
 			rtc, err := runtime.GetConfig()
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 			initPath = rtc.InitPath
 		}
 		initMount, err := config.addContainerInitBinary(initPath)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		if _, ok := unifiedMounts[initMount.Destination]; ok {
-			return nil, nil, errors.Wrapf(errDuplicateDest, "conflict with mount added by --init to %q", initMount.Destination)
+			return nil, nil, nil, errors.Wrapf(errDuplicateDest, "conflict with mount added by --init to %q", initMount.Destination)
 		}
 		unifiedMounts[initMount.Destination] = initMount
 	}
@@ -190,17 +190,18 @@ func (config *CreateConfig) parseVolumes(runtime *libpod.Runtime) ([]spec.Mount,
 	// Check for conflicts between named volumes and mounts
 	for dest := range baseMounts {
 		if _, ok := baseVolumes[dest]; ok {
-			return nil, nil, errors.Wrapf(errDuplicateDest, "conflict at mount destination %v", dest)
+			return nil, nil, nil, errors.Wrapf(errDuplicateDest, "conflict at mount destination %v", dest)
 		}
 	}
 	for dest := range baseVolumes {
 		if _, ok := baseMounts[dest]; ok {
-			return nil, nil, errors.Wrapf(errDuplicateDest, "conflict at mount destination %v", dest)
+			return nil, nil, nil, errors.Wrapf(errDuplicateDest, "conflict at mount destination %v", dest)
 		}
 	}
 
 	// Final step: maps to arrays
 	finalMounts := make([]spec.Mount, 0, len(baseMounts))
+	finalOverlayVolumes := make([]*libpod.ContainerOverlayVolume, 0)
 	for _, mount := range baseMounts {
 		// All user-added tmpfs mounts need their options processed.
 		// Exception: mounts added by the ReadOnlyTmpfs option, which
@@ -208,16 +209,38 @@ func (config *CreateConfig) parseVolumes(runtime *libpod.Runtime) ([]spec.Mount,
 		if mount.Type == TypeTmpfs && !readonlyTmpfs[mount.Destination] {
 			opts, err := util.ProcessTmpfsOptions(mount.Options)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 			mount.Options = opts
 		}
 		if mount.Type == TypeBind {
 			absSrc, err := filepath.Abs(mount.Source)
 			if err != nil {
-				return nil, nil, errors.Wrapf(err, "error getting absolute path of %s", mount.Source)
+				return nil, nil, nil, errors.Wrapf(err, "error getting absolute path of %s", mount.Source)
 			}
 			mount.Source = absSrc
+
+			// Overlay volumes are mounted by libpod, not
+			// through the spec. Only the relabel options
+			// can be combined with them.
+			if util.StringInSlice("O", mount.Options) {
+				var options []string
+				for _, opt := range mount.Options {
+					switch opt {
+					case "O":
+					case "z", "Z":
+						options = append(options, opt)
+					default:
+						return nil, nil, nil, errors.Errorf("overlay volume at %s cannot be combined with mount options other than z or Z", mount.Destination)
+					}
+				}
+				finalOverlayVolumes = append(finalOverlayVolumes, &libpod.ContainerOverlayVolume{
+					Source:  mount.Source,
+					Dest:    mount.Destination,
+					Options: options,
+				})
+				continue
+			}
 		}
 		finalMounts = append(finalMounts, mount)
 	}
@@ -228,8 +251,9 @@ func (config *CreateConfig) parseVolumes(runtime *libpod.Runtime) ([]spec.Mount,
 
 	logrus.Debugf("Got mounts: %v", finalMounts)
 	logrus.Debugf("Got volumes: %v", finalVolumes)
+	logrus.Debugf("Got overlay volumes: %v", finalOverlayVolumes)
 
-	return finalMounts, finalVolumes, nil
+	return finalMounts, finalVolumes, finalOverlayVolumes, nil
 }
 
 // Parse volumes from - a set of containers whose volumes we will mount in.
@@ -287,11 +311,11 @@ func (config *CreateConfig) getVolumesFrom(runtime *libpod.Runtime) (map[string]
 
 		// Now we get the container's spec and loop through its volumes
 		// and append them in if we can find them.
-		spec := ctr.Spec()
-		if spec == nil {
+		ctrSpec := ctr.Spec()
+		if ctrSpec == nil {
 			return nil, nil, errors.Errorf("error retrieving container %s spec for volumes-from", ctr.ID())
 		}
-		for _, mnt := range spec.Mounts {
+		for _, mnt := range ctrSpec.Mounts {
 			if mnt.Type != TypeBind {
 				continue
 			}
@@ -328,6 +352,29 @@ func (config *CreateConfig) getVolumesFrom(runtime *libpod.Runtime) (map[string]
 			finalNamedVolumes[namedVol.Dest] = namedVol
 		}
 
+		// Overlay volumes are mounted by libpod rather than through the
+		// spec. They are passed on as overlay bind mounts, which cannot
+		// take other options.
+		for _, overlayVol := range ctr.OverlayVolumes() {
+			if _, exists := userVolumes[overlayVol.Dest]; exists {
+				userVolumes[overlayVol.Dest] = true
+			}
+
+			if len(options) != 0 {
+				return nil, nil, errors.Errorf("overlay volume at %s of container %s cannot be combined with other mount options", overlayVol.Dest, ctr.ID())
+			}
+
+			if _, ok := finalMounts[overlayVol.Dest]; ok {
+				logrus.Debugf("Overriding mount to %s with new overlay volume from container %s", overlayVol.Dest, ctr.ID())
+			}
+			finalMounts[overlayVol.Dest] = spec.Mount{
+				Destination: overlayVol.Dest,
+				Type:        TypeBind,
+				Source:      overlayVol.Source,
+				Options:     []string{"O"},
+			}
+		}
+
 		// Check if we missed any volumes
 		for volDest, found := range userVolumes {
 			if !found {
@@ -417,6 +464,8 @@ func getBindMount(args []string) (spec.Mount, error) {
 		switch kv[0] {
 		case "bind-nonrecursive":
 			newMount.Options = append(newMount.Options, "bind")
+		case "overlay":
+			newMount.Options = append(newMount.Options, "O")
 		case "ro", "rw":
 			if setRORW {
 				return newMount, errors.Wrapf(optionArgError, "cannot pass 'ro' or 'rw' options more than once")
@@ -641,6 +690,9 @@ func (config *CreateConfig) getVolumeMounts() (map[string]spec.Mount, map[string
 			mounts[newMount.Destination] = newMount
 		} else {
 			// This is a named volume
+			if util.StringInSlice("O", options) {
+				return nil, nil, errors.Errorf("overlay is only supported for host directories, not for volume %s", src)
+			}
 			newNamedVol := new(libpod.ContainerNamedVolume)
 			newNamedVol.Name = src
 			newNamedVol.Dest = dest
@@ -3,6 +3,7 @@ package createconfig
 import (
 	"testing"
 
+	"github.com/containers/libpod/libpod"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,6 +23,34 @@ func TestGetVolumeMountsOneVolume(t *testing.T) {
 	assert.EqualValues(t, data, specMount[data.Destination])
 }
 
+func TestParseVolumesOverlay(t *testing.T) {
+	config := CreateConfig{
+		Volumes:    []string{"/tmp:/foobar:O", "/tmp:/ro:ro", "/tmp:/labelled:O,Z"},
+		MountsFlag: []string{"type=bind,src=/srv,target=/srv,overlay"},
+	}
+	mounts, _, overlayVolumes, err := config.parseVolumes(nil)
+	assert.NoError(t, err)
+	assert.Len(t, mounts, 1)
+	assert.Equal(t, "/ro", mounts[0].Destination)
+	assert.ElementsMatch(t, []*libpod.ContainerOverlayVolume{
+		{Source: "/tmp", Dest: "/foobar"},
+		{Source: "/tmp", Dest: "/labelled", Options: []string{"Z"}},
+		{Source: "/srv", Dest: "/srv"},
+	}, overlayVolumes)
+
+	config = CreateConfig{
+		Volumes: []string{"/tmp:/foobar:O,ro"},
+	}
+	_, _, _, err = config.parseVolumes(nil)
+	assert.Error(t, err)
+
+	config = CreateConfig{
+		Volumes: []string{"myvol:/foobar:O"},
+	}
+	_, _, _, err = config.parseVolumes(nil)
+	assert.Error(t, err)
+}
+
 func TestGetTmpfsMounts(t *testing.T) {
 	data := spec.Mount{
 		Destination: "/homer",
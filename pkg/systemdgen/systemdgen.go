@@ -1,27 +1,206 @@
 package systemdgen
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"text/template"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
-var template = `[Unit]
-Description=%s Podman Container
+var containerTemplate = `[Unit]
+Description={{.ContainerName}} Podman Container
+{{- if .BoundToServices}}
+BindsTo={{join .BoundToServices}}
+{{- end}}
+{{- if .RequiredServices}}
+Requires={{join .RequiredServices}}
+{{- end}}
+{{- if or .BoundToServices .RequiredServices}}
+After={{join .BoundToServices .RequiredServices}}
+{{- end}}
+{{- if .SocketName}}
+Requires={{.SocketName}}.socket
+After={{.SocketName}}.socket
+{{- end}}
 [Service]
-Restart=%s
-ExecStart=%s start %s
-ExecStop=%s stop -t %d %s
+Restart={{.RestartPolicy}}
+{{- if .CreateCommand}}
+ExecStartPre=-{{.Executable}} rm -f {{.ContainerName}}
+ExecStart={{.RunCommand}}
+ExecStop={{.Executable}} stop -t {{.StopTimeout}} {{.ContainerName}}
+ExecStopPost=-{{.Executable}} rm -f {{.ContainerName}}
+{{- else}}
+ExecStart={{.Executable}} start {{.ContainerName}}
+ExecStop={{.Executable}} stop -t {{.StopTimeout}} {{.ContainerName}}
+{{- end}}
 KillMode=none
+{{- if .Notify}}
+Type=notify
+NotifyAccess=all
+{{- else}}
 Type=forking
-PIDFile=%s
+{{- end}}
+PIDFile={{.PIDFile}}
+[Install]
+WantedBy=multi-user.target`
+
+// newContainerPIDFile is where containers created by a unit write their conmon
+// PID file, in the runtime directory of systemd and named after the unit
+const newContainerPIDFile = "%t/%n-pid"
+
+// runCommandDroppedFlags are flags of the create command which are dropped
+// when running a new container from a unit, as the unit sets or manages them.
+// The container is always detached and removed by the unit itself.
+var runCommandDroppedFlags = []string{"--conmon-pidfile", "--cidfile", "--detach", "--rm"}
+
+// runCommandDroppedShortFlags are the shorthands of runCommandDroppedFlags
+var runCommandDroppedShortFlags = "d"
+
+// globalBoolFlags are the global flags of podman which take no value. All
+// other global flags take one.
+var globalBoolFlags = []string{"--help", "--syslog", "--trace"}
+
+// runBoolFlags are the flags of podman create and run which take no value.
+// All other flags take one.
+var runBoolFlags = []string{
+	"--detach", "--env-host", "--help", "--http-proxy", "--init", "--interactive", "--no-hosts",
+	"--oom-kill-disable", "--privileged", "--publish-all", "--quiet", "--read-only", "--read-only-tmpfs",
+	"--rm", "--rootfs", "--sig-proxy", "--systemd", "--tty",
+}
+
+// runBoolShortFlags are the shorthands of podman create and run which take no
+// value
+var runBoolShortFlags = "diPqt"
+
+var socketTemplate = `[Unit]
+Description={{.ContainerName}} Podman Container Socket
+[Socket]
+{{- range .ListenStreams}}
+ListenStream={{.}}
+{{- end}}
+{{- range .ListenDatagrams}}
+ListenDatagram={{.}}
+{{- end}}
+[Install]
+WantedBy=sockets.target`
+
+var podTemplate = `[Unit]
+Description={{.PodName}} Podman Pod
+{{- if .RequiredServices}}
+Requires={{join .RequiredServices}}
+Before={{join .RequiredServices}}
+{{- end}}
+[Service]
+Restart={{.RestartPolicy}}
+ExecStart={{.Executable}} start {{.InfraName}}
+ExecStop={{.Executable}} stop -t {{.StopTimeout}} {{.InfraName}}
+KillMode=none
+Type=forking
+PIDFile={{.PIDFile}}
 [Install]
 WantedBy=multi-user.target`
 
 var restartPolicies = []string{"no", "on-success", "on-failure", "on-abnormal", "on-watchdog", "on-abort", "always"}
 
+// Types of the generated units
+const (
+	// UnitTypeService is the type of service units
+	UnitTypeService = "service"
+	// UnitTypeSocket is the type of socket units
+	UnitTypeSocket = "socket"
+)
+
+// Unit is a generated systemd unit
+type Unit struct {
+	// ServiceName is the name of the systemd service, without the .service
+	// suffix. Socket units share the name of the service they activate
+	ServiceName string
+	// Type is the type of the unit, UnitTypeService or UnitTypeSocket
+	Type string
+	// Content is the content of the unit file
+	Content string
+}
+
+// FileName returns the name of the unit's file
+func (u *Unit) FileName() string {
+	return fmt.Sprintf("%s.%s", u.ServiceName, u.Type)
+}
+
+// ContainerInfo contains the data required to generate the systemd unit of a
+// container
+type ContainerInfo struct {
+	// ContainerName is the name or ID of the container
+	ContainerName string
+	// RestartPolicy is the systemd restart policy of the service
+	RestartPolicy string
+	// PIDFile points to the PID file of the container's conmon process
+	PIDFile string
+	// StopTimeout is the timeout podman waits before killing the container
+	StopTimeout int
+	// Executable is the path of the podman executable, defaults to the
+	// running executable
+	Executable string
+	// BoundToServices are the services this service is bound to and ordered
+	// after, like the service of the container's pod
+	BoundToServices []string
+	// RequiredServices are the services this service requires and is
+	// ordered after, like the services of the containers it depends on
+	RequiredServices []string
+	// CreateCommand is the command line the container was created with.
+	// If set, the unit runs a new container from it when started and
+	// removes the container when stopped, instead of starting and stopping
+	// an existing container. ContainerName must be the container's name
+	// and PIDFile is ignored.
+	CreateCommand []string
+	// Notify makes the unit wait for the container to report its readiness
+	// through sd_notify, instead of treating it as ready once conmon has
+	// been forked
+	Notify bool
+	// SocketName is the name of the socket unit activating the service,
+	// without the .socket suffix
+	SocketName string
+}
+
+// SocketInfo contains the data required to generate the socket unit which
+// activates the service of a container
+type SocketInfo struct {
+	// ContainerName is the name or ID of the container
+	ContainerName string
+	// ListenStreams are the addresses of the stream sockets, in the format
+	// of ListenStream= in systemd.socket(5)
+	ListenStreams []string
+	// ListenDatagrams are the addresses of the datagram sockets, in the
+	// format of ListenDatagram= in systemd.socket(5)
+	ListenDatagrams []string
+}
+
+// PodInfo contains the data required to generate the systemd unit of a pod
+type PodInfo struct {
+	// PodName is the name or ID of the pod
+	PodName string
+	// InfraName is the name or ID of the pod's infra container, which is
+	// started and stopped by the pod's service
+	InfraName string
+	// RestartPolicy is the systemd restart policy of the service
+	RestartPolicy string
+	// PIDFile points to the PID file of the infra container's conmon process
+	PIDFile string
+	// StopTimeout is the timeout podman waits before killing the infra
+	// container
+	StopTimeout int
+	// Executable is the path of the podman executable, defaults to the
+	// running executable
+	Executable string
+	// RequiredServices are the services of the pod's containers, which are
+	// started after the pod's service
+	RequiredServices []string
+}
+
 // ValidateRestartPolicy checks that the user-provided policy is valid
 func ValidateRestartPolicy(restart string) error {
 	for _, i := range restartPolicies {
@@ -40,12 +219,266 @@ func CreateSystemdUnitAsString(name, cid, restart, pidFile string, stopTimeout i
 }
 
 func createSystemdUnitAsString(exe, name, cid, restart, pidFile string, stopTimeout int) (string, error) {
-	if err := ValidateRestartPolicy(restart); err != nil {
+	info := ContainerInfo{
+		ContainerName: name,
+		RestartPolicy: restart,
+		PIDFile:       pidFile,
+		StopTimeout:   stopTimeout,
+		Executable:    exe,
+	}
+	return CreateContainerSystemdUnit(&info)
+}
+
+// CreateContainerSystemdUnit creates the systemd unit of a container
+func CreateContainerSystemdUnit(info *ContainerInfo) (string, error) {
+	if err := ValidateRestartPolicy(info.RestartPolicy); err != nil {
 		return "", err
 	}
+	if info.Executable == "" {
+		info.Executable = getPodmanExecutable()
+	}
+	data := struct {
+		*ContainerInfo
+		RunCommand string
+	}{ContainerInfo: info}
+	if len(info.CreateCommand) > 0 {
+		runCommand, err := createRunCommand(info.Executable, info.ContainerName, info.CreateCommand)
+		if err != nil {
+			return "", err
+		}
+		data.RunCommand = runCommand
+		data.PIDFile = newContainerPIDFile
+	}
+	return executeTemplate(containerTemplate, data)
+}
+
+// createRunCommand turns the command line a container was created with into
+// the command line of a unit running a new, detached container with the same
+// name, which writes its conmon PID file to where the unit expects it. Only
+// the flags before the image are changed, everything after it is the
+// container's command and kept as it is.
+func createRunCommand(exe, name string, createCommand []string) (string, error) {
+	if len(createCommand) < 2 {
+		return "", errors.Errorf("command %q did not create a container", strings.Join(createCommand, " "))
+	}
+
+	// Everything between the executable and the create or run command
+	// are global options, which are kept
+	index := skipFlags(createCommand, 1, globalBoolFlags, "")
+	if index < len(createCommand) && createCommand[index] == "container" {
+		index++
+	}
+	if index >= len(createCommand) || (createCommand[index] != "create" && createCommand[index] != "run") {
+		return "", errors.Errorf("command %q did not create a container", strings.Join(createCommand, " "))
+	}
+
+	command := []string{exe}
+	for _, arg := range createCommand[1:index] {
+		command = append(command, escapeSystemdArg(arg))
+	}
+	command = append(command, "run", "--conmon-pidfile", newContainerPIDFile, "--detach")
+
+	var args []string
+	hasName := false
+	image := skipFlags(createCommand, index+1, runBoolFlags, runBoolShortFlags)
+	for i := index + 1; i < image; i++ {
+		arg := createCommand[i]
+		if arg == "--" {
+			args = append(args, arg)
+			continue
+		}
+		takesValue := flagTakesValue(arg, runBoolFlags, runBoolShortFlags)
+		if strings.HasPrefix(arg, "--") {
+			flag := strings.SplitN(arg, "=", 2)[0]
+			if flag == "--name" {
+				hasName = true
+			}
+			if isDroppedRunFlag(flag) {
+				if takesValue {
+					i++
+				}
+				continue
+			}
+		} else if arg != "-" && strings.HasPrefix(arg, "-") {
+			arg = dropShortFlags(arg)
+			if arg == "-" {
+				continue
+			}
+		}
+		args = append(args, escapeSystemdArg(arg))
+		if takesValue && i+1 < image {
+			i++
+			args = append(args, escapeSystemdArg(createCommand[i]))
+		}
+	}
+	if !hasName {
+		command = append(command, "--name", escapeSystemdArg(name))
+	}
+	command = append(command, args...)
+	for _, arg := range createCommand[image:] {
+		command = append(command, escapeSystemdArg(arg))
+	}
 
-	unit := fmt.Sprintf(template, name, restart, exe, name, exe, stopTimeout, name, pidFile)
-	return unit, nil
+	return strings.Join(command, " "), nil
+}
+
+// skipFlags returns the index of the first positional argument at or after
+// start, skipping flags and their values. A "--" ending the flags is skipped
+// as well.
+func skipFlags(args []string, start int, boolFlags []string, boolShortFlags string) int {
+	for i := start; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return i + 1
+		}
+		if arg == "-" || !strings.HasPrefix(arg, "-") {
+			return i
+		}
+		if flagTakesValue(arg, boolFlags, boolShortFlags) {
+			i++
+		}
+	}
+	return len(args)
+}
+
+// flagTakesValue returns whether the flag is followed by its value in the
+// next argument
+func flagTakesValue(arg string, boolFlags []string, boolShortFlags string) bool {
+	if strings.HasPrefix(arg, "--") {
+		if strings.Contains(arg, "=") {
+			return false
+		}
+		for _, flag := range boolFlags {
+			if arg == flag {
+				return false
+			}
+		}
+		return true
+	}
+	// Shorthands can be combined, the first one taking a value ends them
+	// and takes the rest of the argument or the next one as its value
+	shorthands := strings.TrimPrefix(arg, "-")
+	for i, c := range shorthands {
+		if !strings.ContainsRune(boolShortFlags, c) {
+			return i == len(shorthands)-1
+		}
+	}
+	return false
+}
+
+// isDroppedRunFlag returns whether the long flag is dropped from the command
+// line of a new container
+func isDroppedRunFlag(flag string) bool {
+	for _, dropped := range runCommandDroppedFlags {
+		if flag == dropped {
+			return true
+		}
+	}
+	return false
+}
+
+// dropShortFlags removes the dropped shorthands from combined shorthands,
+// up to the first one taking a value
+func dropShortFlags(arg string) string {
+	shorthands := strings.TrimPrefix(arg, "-")
+	kept := "-"
+	for i, c := range shorthands {
+		if !strings.ContainsRune(runBoolShortFlags, c) {
+			return kept + shorthands[i:]
+		}
+		if !strings.ContainsRune(runCommandDroppedShortFlags, c) {
+			kept += string(c)
+		}
+	}
+	return kept
+}
+
+// escapeSystemdArg escapes an argument of a systemd command line, so systemd
+// neither splits it nor expands specifiers or environment variables in it
+func escapeSystemdArg(arg string) string {
+	arg = strings.Replace(arg, "%", "%%", -1)
+	arg = strings.Replace(arg, "$", "$$", -1)
+	if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\;") {
+		arg = strconv.Quote(arg)
+	}
+	return arg
+}
+
+// NewSocketInfo creates the socket info of a container from the addresses
+// to listen on, each in the format [stream:|datagram:]ADDRESS. Addresses
+// without prefix are stream sockets
+func NewSocketInfo(containerName string, listen []string) (*SocketInfo, error) {
+	info := SocketInfo{ContainerName: containerName}
+	for _, l := range listen {
+		kind, addr := "stream", l
+		if split := strings.SplitN(l, ":", 2); len(split) == 2 && (split[0] == "stream" || split[0] == "datagram") {
+			kind, addr = split[0], split[1]
+		}
+		if addr == "" {
+			return nil, errors.Errorf("invalid socket %q, no address to listen on", l)
+		}
+		if kind == "datagram" {
+			info.ListenDatagrams = append(info.ListenDatagrams, addr)
+		} else {
+			info.ListenStreams = append(info.ListenStreams, addr)
+		}
+	}
+	if len(info.ListenStreams) == 0 && len(info.ListenDatagrams) == 0 {
+		return nil, errors.Errorf("no addresses to listen on")
+	}
+	return &info, nil
+}
+
+// CreateSocketSystemdUnit creates the socket unit activating the service of a
+// container
+func CreateSocketSystemdUnit(info *SocketInfo) (string, error) {
+	return executeTemplate(socketTemplate, info)
+}
+
+// CreatePodSystemdUnit creates the systemd unit of a pod, which starts and
+// stops the pod's infra container
+func CreatePodSystemdUnit(info *PodInfo) (string, error) {
+	if err := ValidateRestartPolicy(info.RestartPolicy); err != nil {
+		return "", err
+	}
+	if info.Executable == "" {
+		info.Executable = getPodmanExecutable()
+	}
+	return executeTemplate(podTemplate, info)
+}
+
+// ContainerServiceName returns the name of the systemd service of a container
+func ContainerServiceName(name string) string {
+	return fmt.Sprintf("container-%s", name)
+}
+
+// PodServiceName returns the name of the systemd service of a pod
+func PodServiceName(name string) string {
+	return fmt.Sprintf("pod-%s", name)
+}
+
+func executeTemplate(unitTemplate string, data interface{}) (string, error) {
+	funcs := template.FuncMap{
+		// join lists services, adding the .service suffix
+		"join": func(services ...[]string) string {
+			var names []string
+			for _, s := range services {
+				for _, name := range s {
+					names = append(names, name+".service")
+				}
+			}
+			return strings.Join(names, " ")
+		},
+	}
+	tmpl, err := template.New("systemd").Funcs(funcs).Parse(unitTemplate)
+	if err != nil {
+		return "", errors.Wrapf(err, "error parsing systemd unit template")
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(err, "error generating systemd unit")
+	}
+	return buf.String(), nil
 }
 
 func getPodmanExecutable() string {
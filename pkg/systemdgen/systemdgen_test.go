@@ -122,3 +122,236 @@ WantedBy=multi-user.target`
 		})
 	}
 }
+
+func TestCreateContainerSystemdUnitInPod(t *testing.T) {
+	want := `[Unit]
+Description=web Podman Container
+BindsTo=pod-webpod.service
+Requires=container-db.service
+After=pod-webpod.service container-db.service
+[Service]
+Restart=always
+ExecStart=/usr/bin/podman start web
+ExecStop=/usr/bin/podman stop -t 10 web
+KillMode=none
+Type=forking
+PIDFile=/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid
+[Install]
+WantedBy=multi-user.target`
+
+	info := ContainerInfo{
+		ContainerName:    "web",
+		RestartPolicy:    "always",
+		PIDFile:          "/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
+		StopTimeout:      10,
+		Executable:       "/usr/bin/podman",
+		BoundToServices:  []string{PodServiceName("webpod")},
+		RequiredServices: []string{ContainerServiceName("db")},
+	}
+	got, err := CreateContainerSystemdUnit(&info)
+	if err != nil {
+		t.Fatalf("CreateContainerSystemdUnit() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("CreateContainerSystemdUnit() = %v, want %v", got, want)
+	}
+}
+
+func TestCreatePodSystemdUnit(t *testing.T) {
+	want := `[Unit]
+Description=webpod Podman Pod
+Requires=container-web.service container-db.service
+Before=container-web.service container-db.service
+[Service]
+Restart=on-failure
+ExecStart=/usr/bin/podman start webpod-infra
+ExecStop=/usr/bin/podman stop -t 10 webpod-infra
+KillMode=none
+Type=forking
+PIDFile=/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid
+[Install]
+WantedBy=multi-user.target`
+
+	info := PodInfo{
+		PodName:          "webpod",
+		InfraName:        "webpod-infra",
+		RestartPolicy:    "on-failure",
+		PIDFile:          "/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
+		StopTimeout:      10,
+		Executable:       "/usr/bin/podman",
+		RequiredServices: []string{ContainerServiceName("web"), ContainerServiceName("db")},
+	}
+	got, err := CreatePodSystemdUnit(&info)
+	if err != nil {
+		t.Fatalf("CreatePodSystemdUnit() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("CreatePodSystemdUnit() = %v, want %v", got, want)
+	}
+
+	info.RestartPolicy = "never"
+	if _, err := CreatePodSystemdUnit(&info); err == nil {
+		t.Errorf("CreatePodSystemdUnit() expected error for bad restart policy")
+	}
+}
+
+func TestCreateContainerSystemdUnitNew(t *testing.T) {
+	want := `[Unit]
+Description=web Podman Container
+[Service]
+Restart=always
+ExecStartPre=-/usr/bin/podman rm -f web
+ExecStart=/usr/bin/podman --log-level debug run --conmon-pidfile %t/%n-pid --detach --name web -e "GREETING=hello world" -e PRICE=100$$ alpine date +%%s
+ExecStop=/usr/bin/podman stop -t 10 web
+ExecStopPost=-/usr/bin/podman rm -f web
+KillMode=none
+Type=forking
+PIDFile=%t/%n-pid
+[Install]
+WantedBy=multi-user.target`
+
+	info := ContainerInfo{
+		ContainerName: "web",
+		RestartPolicy: "always",
+		StopTimeout:   10,
+		Executable:    "/usr/bin/podman",
+		CreateCommand: []string{"podman", "--log-level", "debug", "create", "--conmon-pidfile", "/tmp/pid", "--cidfile=/tmp/cid", "-e", "GREETING=hello world", "-e", "PRICE=100$", "alpine", "date", "+%s"},
+	}
+	got, err := CreateContainerSystemdUnit(&info)
+	if err != nil {
+		t.Fatalf("CreateContainerSystemdUnit() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("CreateContainerSystemdUnit() = %v, want %v", got, want)
+	}
+}
+
+func TestCreateRunCommand(t *testing.T) {
+	tests := []struct {
+		name          string
+		createCommand []string
+		want          string
+		wantErr       bool
+	}{
+		{"run with name",
+			[]string{"podman", "run", "-d", "--name=foo", "alpine", "top"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --detach --name=foo alpine top",
+			false,
+		},
+		{"container create",
+			[]string{"podman", "container", "create", "alpine"},
+			"/usr/bin/podman container run --conmon-pidfile %t/%n-pid --detach --name foo alpine",
+			false,
+		},
+		{"global flag values",
+			[]string{"podman", "--root", "run", "--runroot=/run/x", "--syslog", "create", "alpine"},
+			"/usr/bin/podman --root run --runroot=/run/x --syslog run --conmon-pidfile %t/%n-pid --detach --name foo alpine",
+			false,
+		},
+		{"image arguments looking like flags",
+			[]string{"podman", "run", "--cidfile", "/tmp/cid", "alpine", "cmd", "--cidfile", "/tmp/cid", "--rm", "-d", "--name", "bar"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --detach --name foo alpine cmd --cidfile /tmp/cid --rm -d --name bar",
+			false,
+		},
+		{"image named like a subcommand",
+			[]string{"podman", "create", "-e", "run", "create", "run"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --detach --name foo -e run create run",
+			false,
+		},
+		{"interactive with tty and rm",
+			[]string{"podman", "run", "-it", "--rm", "fedora", "bash"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --detach --name foo -it fedora bash",
+			false,
+		},
+		{"combined shorthands",
+			[]string{"podman", "run", "-dit", "-tdp", "8080:80", "--rm=true", "--detach=false", "fedora"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --detach --name foo -it -tp 8080:80 fedora",
+			false,
+		},
+		{"end of flags",
+			[]string{"podman", "run", "--tty", "--", "fedora", "-d"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --detach --name foo --tty -- fedora -d",
+			false,
+		},
+		{"no create command",
+			[]string{"podman", "start", "foo"},
+			"",
+			true,
+		},
+		{"create only as a flag value",
+			[]string{"podman", "--log-level", "create", "start", "create"},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := createRunCommand("/usr/bin/podman", "foo", tt.createCommand)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createRunCommand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("createRunCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateContainerSystemdUnitNotify(t *testing.T) {
+	want := `[Unit]
+Description=foobar Podman Container
+[Service]
+Restart=on-failure
+ExecStart=/usr/bin/podman start foobar
+ExecStop=/usr/bin/podman stop -t 10 foobar
+KillMode=none
+Type=notify
+NotifyAccess=all
+PIDFile=/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid
+[Install]
+WantedBy=multi-user.target`
+
+	info := ContainerInfo{
+		ContainerName: "foobar",
+		RestartPolicy: "on-failure",
+		PIDFile:       "/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
+		StopTimeout:   10,
+		Executable:    "/usr/bin/podman",
+		Notify:        true,
+	}
+	got, err := CreateContainerSystemdUnit(&info)
+	if err != nil {
+		t.Fatalf("CreateContainerSystemdUnit() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("CreateContainerSystemdUnit() = %v, want %v", got, want)
+	}
+}
+
+func TestCreateSocketSystemdUnit(t *testing.T) {
+	want := `[Unit]
+Description=foobar Podman Container Socket
+[Socket]
+ListenStream=8080
+ListenStream=/run/foobar.sock
+ListenDatagram=127.0.0.1:53
+[Install]
+WantedBy=sockets.target`
+
+	info, err := NewSocketInfo("foobar", []string{"8080", "datagram:127.0.0.1:53", "stream:/run/foobar.sock"})
+	if err != nil {
+		t.Fatalf("NewSocketInfo() error = %v", err)
+	}
+	got, err := CreateSocketSystemdUnit(info)
+	if err != nil {
+		t.Fatalf("CreateSocketSystemdUnit() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("CreateSocketSystemdUnit() = %v, want %v", got, want)
+	}
+
+	if _, err := NewSocketInfo("foobar", []string{"datagram:"}); err == nil {
+		t.Errorf("NewSocketInfo() expected error for socket without address")
+	}
+}
@@ -68,30 +68,32 @@ func (i *LibpodAPI) Ps(call iopodman.VarlinkCall, opts iopodman.PsOpts) error {
 
 	for _, ctr := range psContainerOutputs {
 		container := iopodman.PsContainer{
-			Id:        ctr.ID,
-			Image:     ctr.Image,
-			Command:   ctr.Command,
-			Created:   ctr.Created,
-			Ports:     ctr.Ports,
-			Names:     ctr.Names,
-			IsInfra:   ctr.IsInfra,
-			Status:    ctr.Status,
-			State:     ctr.State.String(),
-			PidNum:    int64(ctr.Pid),
-			Pod:       ctr.Pod,
-			CreatedAt: ctr.CreatedAt.Format(time.RFC3339Nano),
-			ExitedAt:  ctr.ExitedAt.Format(time.RFC3339Nano),
-			StartedAt: ctr.StartedAt.Format(time.RFC3339Nano),
-			Labels:    ctr.Labels,
-			NsPid:     ctr.PID,
-			Cgroup:    ctr.Cgroup,
-			Ipc:       ctr.Cgroup,
-			Mnt:       ctr.MNT,
-			Net:       ctr.NET,
-			PidNs:     ctr.PIDNS,
-			User:      ctr.User,
-			Uts:       ctr.UTS,
-			Mounts:    ctr.Mounts,
+			Id:          ctr.ID,
+			Image:       ctr.Image,
+			Command:     ctr.Command,
+			Created:     ctr.Created,
+			Ports:       ctr.Ports,
+			Names:       ctr.Names,
+			IsInfra:     ctr.IsInfra,
+			Status:      ctr.Status,
+			State:       ctr.State.String(),
+			PidNum:      int64(ctr.Pid),
+			Pod:         ctr.Pod,
+			CreatedAt:   ctr.CreatedAt.Format(time.RFC3339Nano),
+			ExitedAt:    ctr.ExitedAt.Format(time.RFC3339Nano),
+			StartedAt:   ctr.StartedAt.Format(time.RFC3339Nano),
+			Labels:      ctr.Labels,
+			NsPid:       ctr.PID,
+			Cgroup:      ctr.Cgroup,
+			Ipc:         ctr.Cgroup,
+			Mnt:         ctr.MNT,
+			Net:         ctr.NET,
+			PidNs:       ctr.PIDNS,
+			User:        ctr.User,
+			Uts:         ctr.UTS,
+			Mounts:      ctr.Mounts,
+			Restarts:    int64(ctr.Restarts),
+			LastRestart: ctr.LastRestart.Format(time.RFC3339Nano),
 		}
 		if ctr.Size != nil {
 			container.RootFsSize = ctr.Size.RootFsSize
@@ -329,19 +331,21 @@ func (i *LibpodAPI) GetContainerStats(call iopodman.VarlinkCall, name string) er
 		return call.ReplyErrorOccurred(err.Error())
 	}
 	cs := iopodman.ContainerStats{
-		Id:           ctr.ID(),
-		Name:         ctr.Name(),
-		Cpu:          containerStats.CPU,
-		Cpu_nano:     int64(containerStats.CPUNano),
-		System_nano:  int64(containerStats.SystemNano),
-		Mem_usage:    int64(containerStats.MemUsage),
-		Mem_limit:    int64(containerStats.MemLimit),
-		Mem_perc:     containerStats.MemPerc,
-		Net_input:    int64(containerStats.NetInput),
-		Net_output:   int64(containerStats.NetOutput),
-		Block_input:  int64(containerStats.BlockInput),
-		Block_output: int64(containerStats.BlockOutput),
-		Pids:         int64(containerStats.PIDs),
+		Id:               ctr.ID(),
+		Name:             ctr.Name(),
+		Cpu:              containerStats.CPU,
+		Cpu_nano:         int64(containerStats.CPUNano),
+		System_nano:      int64(containerStats.SystemNano),
+		Mem_usage:        int64(containerStats.MemUsage),
+		Mem_limit:        int64(containerStats.MemLimit),
+		Mem_perc:         containerStats.MemPerc,
+		Net_input:        int64(containerStats.NetInput),
+		Net_output:       int64(containerStats.NetOutput),
+		Block_input:      int64(containerStats.BlockInput),
+		Block_output:     int64(containerStats.BlockOutput),
+		Pids:             int64(containerStats.PIDs),
+		Net_ingress_rate: int64(containerStats.NetIngressRate),
+		Net_egress_rate:  int64(containerStats.NetEgressRate),
 	}
 	return call.ReplyGetContainerStats(cs)
 }
@@ -667,19 +671,21 @@ func (i *LibpodAPI) GetContainerStatsWithHistory(call iopodman.VarlinkCall, prev
 		return call.ReplyErrorOccurred(err.Error())
 	}
 	cStats := iopodman.ContainerStats{
-		Id:           stats.ContainerID,
-		Name:         stats.Name,
-		Cpu:          stats.CPU,
-		Cpu_nano:     int64(stats.CPUNano),
-		System_nano:  int64(stats.SystemNano),
-		Mem_usage:    int64(stats.MemUsage),
-		Mem_limit:    int64(stats.MemLimit),
-		Mem_perc:     stats.MemPerc,
-		Net_input:    int64(stats.NetInput),
-		Net_output:   int64(stats.NetOutput),
-		Block_input:  int64(stats.BlockInput),
-		Block_output: int64(stats.BlockOutput),
-		Pids:         int64(stats.PIDs),
+		Id:               stats.ContainerID,
+		Name:             stats.Name,
+		Cpu:              stats.CPU,
+		Cpu_nano:         int64(stats.CPUNano),
+		System_nano:      int64(stats.SystemNano),
+		Mem_usage:        int64(stats.MemUsage),
+		Mem_limit:        int64(stats.MemLimit),
+		Mem_perc:         stats.MemPerc,
+		Net_input:        int64(stats.NetInput),
+		Net_output:       int64(stats.NetOutput),
+		Block_input:      int64(stats.BlockInput),
+		Block_output:     int64(stats.BlockOutput),
+		Pids:             int64(stats.PIDs),
+		Net_ingress_rate: int64(stats.NetIngressRate),
+		Net_egress_rate:  int64(stats.NetEgressRate),
 	}
 	return call.ReplyGetContainerStatsWithHistory(cStats)
 }
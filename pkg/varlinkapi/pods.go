@@ -50,6 +50,19 @@ func (i *LibpodAPI) CreatePod(call iopodman.VarlinkCall, create iopodman.PodCrea
 			return err
 		}
 		options = append(options, nsOptions...)
+		netOptions, err := shared.GetPodNetworkOptions(shared.PodNetworkOptions{
+			Network:      create.Network,
+			IP:           create.Ip,
+			Hostname:     create.Hostname,
+			DNS:          create.Dns,
+			DNSSearch:    create.DnsSearch,
+			AddHost:      create.AddHost,
+			NetworkAlias: create.NetworkAlias,
+		})
+		if err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
+		options = append(options, netOptions...)
 	}
 	options = append(options, libpod.WithPodCgroups())
 
@@ -271,19 +284,21 @@ func (i *LibpodAPI) GetPodStats(call iopodman.VarlinkCall, name string) error {
 	containersStats := make([]iopodman.ContainerStats, 0)
 	for ctrID, containerStats := range podStats {
 		cs := iopodman.ContainerStats{
-			Id:           ctrID,
-			Name:         containerStats.Name,
-			Cpu:          containerStats.CPU,
-			Cpu_nano:     int64(containerStats.CPUNano),
-			System_nano:  int64(containerStats.SystemNano),
-			Mem_usage:    int64(containerStats.MemUsage),
-			Mem_limit:    int64(containerStats.MemLimit),
-			Mem_perc:     containerStats.MemPerc,
-			Net_input:    int64(containerStats.NetInput),
-			Net_output:   int64(containerStats.NetOutput),
-			Block_input:  int64(containerStats.BlockInput),
-			Block_output: int64(containerStats.BlockOutput),
-			Pids:         int64(containerStats.PIDs),
+			Id:               ctrID,
+			Name:             containerStats.Name,
+			Cpu:              containerStats.CPU,
+			Cpu_nano:         int64(containerStats.CPUNano),
+			System_nano:      int64(containerStats.SystemNano),
+			Mem_usage:        int64(containerStats.MemUsage),
+			Mem_limit:        int64(containerStats.MemLimit),
+			Mem_perc:         containerStats.MemPerc,
+			Net_input:        int64(containerStats.NetInput),
+			Net_output:       int64(containerStats.NetOutput),
+			Block_input:      int64(containerStats.BlockInput),
+			Block_output:     int64(containerStats.BlockOutput),
+			Pids:             int64(containerStats.PIDs),
+			Net_ingress_rate: int64(containerStats.NetIngressRate),
+			Net_egress_rate:  int64(containerStats.NetEgressRate),
 		}
 		containersStats = append(containersStats, cs)
 	}
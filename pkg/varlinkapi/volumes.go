@@ -3,8 +3,14 @@
 package varlinkapi
 
 import (
+	"io/ioutil"
+	"os"
+
 	"github.com/containers/libpod/cmd/podman/varlink"
 	"github.com/containers/libpod/libpod"
+	"github.com/containers/libpod/libpod/define"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // VolumeCreate creates a libpod volume based on input from a varlink connection
@@ -62,13 +68,25 @@ func (i *LibpodAPI) GetVolumes(call iopodman.VarlinkCall, args []string, all boo
 	}
 	// Build the iopodman.volume struct for the return
 	for _, v := range reply {
+		mountPoint, err := v.ResolveMountPoint()
+		if err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
 		newVol := iopodman.Volume{
 			Driver:     v.Driver(),
 			Labels:     v.Labels(),
-			MountPoint: v.MountPoint(),
+			MountPoint: mountPoint,
 			Name:       v.Name(),
 			Options:    v.Options(),
 			Scope:      v.Scope(),
+			Quota:      int64(v.Quota()),
+		}
+		if v.Quota() > 0 {
+			usage, err := v.Usage()
+			if err != nil {
+				return call.ReplyErrorOccurred(err.Error())
+			}
+			newVol.Usage = int64(usage)
 		}
 		volumes = append(volumes, newVol)
 	}
@@ -90,3 +108,55 @@ func (i *LibpodAPI) VolumesPrune(call iopodman.VarlinkCall) error {
 	}
 	return call.ReplyVolumesPrune(prunedNames, errs)
 }
+
+// ExportVolume writes the contents of a volume to a tarfile
+func (i *LibpodAPI) ExportVolume(call iopodman.VarlinkCall, name, outPath string, pause bool) error {
+	vol, err := i.Runtime.GetVolume(name)
+	if err != nil {
+		return call.ReplyVolumeNotFound(name, err.Error())
+	}
+	if outPath == "" {
+		outputFile, err := ioutil.TempFile("", "varlink_recv")
+		if err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
+		outputFile.Close()
+		outPath = outputFile.Name()
+	}
+	if err := vol.Export(outPath, pause); err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyExportVolume(outPath)
+}
+
+// ImportVolume extracts a tarfile into a volume, creating the volume if it
+// does not exist
+func (i *LibpodAPI) ImportVolume(call iopodman.VarlinkCall, name, source string, delete bool) error {
+	if delete {
+		// The source was uploaded by the client for this call, so it
+		// is removed whether or not the import succeeds
+		defer func() {
+			if err := os.Remove(source); err != nil && !os.IsNotExist(err) {
+				logrus.Errorf("Error removing %s after importing it into volume %s: %v", source, name, err)
+			}
+		}()
+	}
+	created := false
+	vol, err := i.Runtime.GetVolume(name)
+	if errors.Cause(err) == define.ErrNoSuchVolume {
+		vol, err = i.Runtime.NewVolume(getContext(), libpod.WithVolumeName(name))
+		created = true
+	}
+	if err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	if err := vol.Import(source); err != nil {
+		if created {
+			if err := i.Runtime.RemoveVolume(getContext(), vol, false); err != nil {
+				logrus.Errorf("Error removing volume %s after failing to import into it: %v", vol.Name(), err)
+			}
+		}
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyImportVolume(vol.Name())
+}
@@ -231,4 +231,21 @@ var _ = Describe("Podman create", func() {
 		Expect(ctrJSON[0].Config.Cmd[0]).To(Equal("redis-server"))
 		Expect(ctrJSON[0].Config.Entrypoint).To(Equal("docker-entrypoint.sh"))
 	})
+
+	It("podman create with --sdnotify", func() {
+		session := podmanTest.Podman([]string{"create", "--sdnotify=container", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		check := podmanTest.Podman([]string{"inspect", "-l"})
+		check.WaitWithDefaultTimeout()
+		data := check.InspectContainerToJSON()
+		Expect(data[0].Config.SdNotifyMode).To(Equal("container"))
+	})
+
+	It("podman create with invalid --sdnotify", func() {
+		session := podmanTest.Podman([]string{"create", "--sdnotify=foo", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
 })
@@ -238,4 +238,25 @@ var _ = Describe("Podman generate kube", func() {
 		Expect(inspect.ExitCode()).To(Equal(0))
 		Expect(inspect.OutputToString()).To(ContainSubstring(vol1))
 	})
+	It("podman generate kube with resource limits", func() {
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "top", "--memory", "100m", "--memory-reservation", "50m", "--cpus", "0.5", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		kube := podmanTest.Podman([]string{"generate", "kube", "top"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		pod := new(v1.Pod)
+		err := yaml.Unmarshal(kube.Out.Contents(), pod)
+		Expect(err).To(BeNil())
+
+		resources := pod.Spec.Containers[0].Resources
+		memLimit := resources.Limits[v1.ResourceMemory]
+		Expect(memLimit.Value()).To(Equal(int64(100 * 1024 * 1024)))
+		memRequest := resources.Requests[v1.ResourceMemory]
+		Expect(memRequest.Value()).To(Equal(int64(50 * 1024 * 1024)))
+		cpuLimit := resources.Limits[v1.ResourceCPU]
+		Expect(cpuLimit.MilliValue()).To(Equal(int64(500)))
+	})
 })
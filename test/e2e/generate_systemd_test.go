@@ -3,10 +3,13 @@
 package integration
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	. "github.com/containers/libpod/test/utils"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
-	"os"
 )
 
 var _ = Describe("Podman generate systemd", func() {
@@ -71,4 +74,94 @@ var _ = Describe("Podman generate systemd", func() {
 		Expect(session.ExitCode()).To(Equal(0))
 	})
 
+	It("podman generate systemd pod", func() {
+		_, ec, _ := podmanTest.CreatePod("foo")
+		Expect(ec).To(Equal(0))
+
+		n := podmanTest.Podman([]string{"create", "--pod", "foo", "--name", "foo-1", ALPINE, "top"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"generate", "systemd", "--name", "foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("# pod-foo.service"))
+		Expect(session.OutputToString()).To(ContainSubstring("# container-foo-1.service"))
+		Expect(session.OutputToString()).To(ContainSubstring("Description=foo Podman Pod"))
+		Expect(session.OutputToString()).To(ContainSubstring("Requires=container-foo-1.service"))
+		Expect(session.OutputToString()).To(ContainSubstring("BindsTo=pod-foo.service"))
+	})
+
+	It("podman generate systemd pod with files", func() {
+		_, ec, _ := podmanTest.CreatePod("foo")
+		Expect(ec).To(Equal(0))
+
+		n := podmanTest.Podman([]string{"create", "--pod", "foo", "--name", "foo-1", ALPINE, "top"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		cwd, err := os.Getwd()
+		Expect(err).To(BeNil())
+		err = os.Chdir(tempdir)
+		Expect(err).To(BeNil())
+		defer os.Chdir(cwd)
+
+		session := podmanTest.Podman([]string{"generate", "systemd", "--name", "--files", "foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		for _, service := range []string{"pod-foo.service", "container-foo-1.service"} {
+			content, err := ioutil.ReadFile(filepath.Join(tempdir, service))
+			Expect(err).To(BeNil())
+			Expect(string(content)).To(ContainSubstring("[Unit]"))
+		}
+	})
+
+	It("podman generate systemd --new", func() {
+		n := podmanTest.Podman([]string{"create", "--name", "nginx", "--conmon-pidfile", "/tmp/nginx.pid", nginx})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"generate", "systemd", "--new", "nginx"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring(" run --conmon-pidfile %t/%n-pid --detach --name nginx "))
+		Expect(session.OutputToString()).To(ContainSubstring("ExecStopPost=-"))
+		Expect(session.OutputToString()).To(Not(ContainSubstring("/tmp/nginx.pid")))
+	})
+
+	It("podman generate systemd --new on pod", func() {
+		_, ec, _ := podmanTest.CreatePod("foo")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"generate", "systemd", "--new", "foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman generate systemd with --sdnotify=container", func() {
+		n := podmanTest.Podman([]string{"create", "--name", "foobar", "--sdnotify=container", ALPINE, "top"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"generate", "systemd", "--name", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("Type=notify"))
+		Expect(session.OutputToString()).To(ContainSubstring("NotifyAccess=all"))
+	})
+
+	It("podman generate systemd with --socket", func() {
+		n := podmanTest.Podman([]string{"create", "--name", "foobar", ALPINE, "top"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"generate", "systemd", "--name", "--socket", "8080", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("# container-foobar.socket"))
+		Expect(session.OutputToString()).To(ContainSubstring("Requires=container-foobar.socket"))
+		Expect(session.OutputToString()).To(ContainSubstring("ListenStream=8080"))
+	})
+
 })
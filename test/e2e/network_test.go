@@ -0,0 +1,318 @@
+package integration
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/libpod/pkg/rootless"
+	. "github.com/containers/libpod/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman network", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		SkipIfRemote()
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman network create, ls and rm", func() {
+		name := "podmantestnet1"
+		create := podmanTest.Podman([]string{"network", "create", "--subnet", "10.99.99.0/24", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+		defer podmanTest.Podman([]string{"network", "rm", name}).WaitWithDefaultTimeout()
+
+		ls := podmanTest.Podman([]string{"network", "ls", "-q"})
+		ls.WaitWithDefaultTimeout()
+		Expect(ls.ExitCode()).To(Equal(0))
+		Expect(ls.OutputToStringArray()).To(ContainElement(name))
+
+		inspect := podmanTest.Podman([]string{"network", "inspect", name})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(ContainSubstring("10.99.99.0/24"))
+
+		rm := podmanTest.Podman([]string{"network", "rm", name})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(0))
+
+		ls = podmanTest.Podman([]string{"network", "ls", "-q"})
+		ls.WaitWithDefaultTimeout()
+		Expect(ls.ExitCode()).To(Equal(0))
+		Expect(ls.OutputToStringArray()).To(Not(ContainElement(name)))
+	})
+
+	It("podman network create with existing name fails", func() {
+		name := "podmantestnet2"
+		create := podmanTest.Podman([]string{"network", "create", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+		defer podmanTest.Podman([]string{"network", "rm", name}).WaitWithDefaultTimeout()
+
+		create = podmanTest.Podman([]string{"network", "create", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman network inspect bogus network fails", func() {
+		inspect := podmanTest.Podman([]string{"network", "inspect", "podmanbogusnet"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman network rm of network in use fails", func() {
+		name := "podmantestnet3"
+		create := podmanTest.Podman([]string{"network", "create", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+		defer podmanTest.Podman([]string{"network", "rm", name}).WaitWithDefaultTimeout()
+
+		session := podmanTest.Podman([]string{"create", "--network", name, ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		rm := podmanTest.Podman([]string{"network", "rm", name})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman network connect and disconnect running container", func() {
+		SkipIfRootless()
+		name := "podmantestnet4"
+		create := podmanTest.Podman([]string{"network", "create", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+		defer podmanTest.Podman([]string{"network", "rm", name}).WaitWithDefaultTimeout()
+
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", "test", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		connect := podmanTest.Podman([]string{"network", "connect", name, "test"})
+		connect.WaitWithDefaultTimeout()
+		Expect(connect.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{range $name, $net := .NetworkSettings.Networks}}{{$name}}={{$net.IPAddress}} {{end}}", "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(MatchRegexp(name + `=\d+\.\d+\.\d+\.\d+`))
+
+		exec := podmanTest.Podman([]string{"exec", "test", "ip", "link", "show", "eth1"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec.ExitCode()).To(Equal(0))
+
+		connect = podmanTest.Podman([]string{"network", "connect", name, "test"})
+		connect.WaitWithDefaultTimeout()
+		Expect(connect.ExitCode()).To(Not(Equal(0)))
+
+		disconnect := podmanTest.Podman([]string{"network", "disconnect", name, "test"})
+		disconnect.WaitWithDefaultTimeout()
+		Expect(disconnect.ExitCode()).To(Equal(0))
+
+		inspect = podmanTest.Podman([]string{"inspect", "--format", "{{range $name, $net := .NetworkSettings.Networks}}{{$name}} {{end}}", "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Not(ContainSubstring(name)))
+
+		exec = podmanTest.Podman([]string{"exec", "test", "ip", "link", "show", "eth1"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec.ExitCode()).To(Not(Equal(0)))
+
+		stop := podmanTest.Podman([]string{"stop", "test"})
+		stop.WaitWithDefaultTimeout()
+		Expect(stop.ExitCode()).To(Equal(0))
+	})
+
+	It("podman network connect stopped container", func() {
+		SkipIfRootless()
+		name := "podmantestnet5"
+		create := podmanTest.Podman([]string{"network", "create", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+		defer podmanTest.Podman([]string{"network", "rm", name}).WaitWithDefaultTimeout()
+
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "ip", "link", "show", "eth1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		connect := podmanTest.Podman([]string{"network", "connect", name, "test"})
+		connect.WaitWithDefaultTimeout()
+		Expect(connect.ExitCode()).To(Equal(0))
+
+		start := podmanTest.Podman([]string{"start", "--attach", "test"})
+		start.WaitWithDefaultTimeout()
+		Expect(start.ExitCode()).To(Equal(0))
+	})
+
+	It("podman network connect rootless slirp4netns container fails", func() {
+		if !rootless.IsRootless() {
+			Skip("slirp4netns containers can only be connected to networks by root")
+		}
+		name := "podmantestnet9"
+		create := podmanTest.Podman([]string{"network", "create", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+		defer podmanTest.Podman([]string{"network", "rm", name}).WaitWithDefaultTimeout()
+
+		session := podmanTest.Podman([]string{"create", "--name", "test", "--network", "slirp4netns", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		connect := podmanTest.Podman([]string{"network", "connect", name, "test"})
+		connect.WaitWithDefaultTimeout()
+		Expect(connect.ExitCode()).To(Not(Equal(0)))
+		Expect(connect.ErrorToString()).To(ContainSubstring("slirp4netns"))
+	})
+
+	It("podman network disconnect from only network fails", func() {
+		SkipIfRootless()
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{range $name, $net := .NetworkSettings.Networks}}{{$name}}{{end}}", "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+
+		disconnect := podmanTest.Podman([]string{"network", "disconnect", inspect.OutputToString(), "test"})
+		disconnect.WaitWithDefaultTimeout()
+		Expect(disconnect.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman network resolves container names and aliases", func() {
+		dnsname := false
+		for _, dir := range []string{"/usr/libexec/cni", "/usr/lib/cni", "/opt/cni/bin"} {
+			if _, err := os.Stat(filepath.Join(dir, "dnsname")); err == nil {
+				dnsname = true
+			}
+		}
+		if !dnsname {
+			Skip("dnsname CNI plugin not installed")
+		}
+
+		name := "podmantestnet6"
+		create := podmanTest.Podman([]string{"network", "create", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+		defer podmanTest.Podman([]string{"network", "rm", name}).WaitWithDefaultTimeout()
+
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", "server", "--network", name, "--network-alias", "web", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		for _, host := range []string{"server", "web"} {
+			lookup := podmanTest.Podman([]string{"run", "--rm", "--network", name, ALPINE, "nslookup", host})
+			lookup.WaitWithDefaultTimeout()
+			Expect(lookup.ExitCode()).To(Equal(0))
+		}
+	})
+
+	It("podman network resolves pod aliases", func() {
+		dnsname := false
+		for _, dir := range []string{"/usr/libexec/cni", "/usr/lib/cni", "/opt/cni/bin"} {
+			if _, err := os.Stat(filepath.Join(dir, "dnsname")); err == nil {
+				dnsname = true
+			}
+		}
+		if !dnsname {
+			Skip("dnsname CNI plugin not installed")
+		}
+
+		name := "podmantestnet9"
+		create := podmanTest.Podman([]string{"network", "create", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+		defer podmanTest.Podman([]string{"network", "rm", name}).WaitWithDefaultTimeout()
+
+		pod := podmanTest.Podman([]string{"pod", "create", "--network", name, "--network-alias", "web"})
+		pod.WaitWithDefaultTimeout()
+		Expect(pod.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"run", "-dt", "--pod", pod.OutputToString(), ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		lookup := podmanTest.Podman([]string{"run", "--rm", "--network", name, ALPINE, "nslookup", "web"})
+		lookup.WaitWithDefaultTimeout()
+		Expect(lookup.ExitCode()).To(Equal(0))
+	})
+
+	It("podman network containers reach each other", func() {
+		name := "podmantestnet7"
+		create := podmanTest.Podman([]string{"network", "create", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+		defer podmanTest.Podman([]string{"network", "rm", name}).WaitWithDefaultTimeout()
+
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", "server", "--network", name, ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{range $net := .NetworkSettings.Networks}}{{$net.IPAddress}}{{end}}", "server"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(MatchRegexp(`^\d+\.\d+\.\d+\.\d+$`))
+
+		ping := podmanTest.Podman([]string{"run", "--rm", "--network", name, ALPINE, "ping", "-c", "1", inspect.OutputToString()})
+		ping.WaitWithDefaultTimeout()
+		Expect(ping.ExitCode()).To(Equal(0))
+	})
+
+	It("podman network publishes ports", func() {
+		name := "podmantestnet8"
+		create := podmanTest.Podman([]string{"network", "create", name})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+		defer podmanTest.Podman([]string{"network", "rm", name}).WaitWithDefaultTimeout()
+
+		session := podmanTest.Podman([]string{"run", "-d", "-p", "127.0.0.1:5679:80", "--network", name, ALPINE, "sh", "-c", "echo podman | nc -l -p 80"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		var (
+			conn net.Conn
+			err  error
+		)
+		for i := 0; i < 10; i++ {
+			conn, err = net.Dial("tcp", "127.0.0.1:5679")
+			if err == nil {
+				break
+			}
+			time.Sleep(500 * time.Millisecond)
+		}
+		Expect(err).To(BeNil())
+		defer conn.Close()
+		output, err := ioutil.ReadAll(conn)
+		Expect(err).To(BeNil())
+		Expect(string(output)).To(ContainSubstring("podman"))
+	})
+
+	It("podman network alias requires network namespace", func() {
+		session := podmanTest.Podman([]string{"create", "--network", "host", "--network-alias", "web", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+})
@@ -61,12 +61,101 @@ spec:
     workingDir: /
   {{ end }}
 {{ end }}
+{{ with .Volumes }}
+  volumes:
+  {{ range . }}
+  - name: {{ .Name }}
+    hostPath:
+      path: {{ .Path }}
+      type: DirectoryOrCreate
+  {{ end }}
+{{ end }}
+status: {}
+`
+
+var securityYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+spec:
+  containers:
+  - command:
+    - top
+    image: {{ .Image }}
+    name: {{ .Name }}
+    resources:
+      limits:
+        cpu: 500m
+        memory: 100Mi
+      requests:
+        cpu: 250m
+        memory: 50Mi
+    securityContext:
+      runAsUser: 1000
+      runAsGroup: 1001
+      readOnlyRootFilesystem: true
+      allowPrivilegeEscalation: false
+status: {}
+`
+
+var initContainerYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+spec:
+  initContainers:
+  - command:
+    - {{ .InitCmd }}
+    image: {{ .Image }}
+    name: init
+  containers:
+  - command:
+    - top
+    image: {{ .Image }}
+    name: testCtr
+status: {}
+`
+
+var podNamespacesYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+spec:
+  hostname: kubehost
+  shareProcessNamespace: true
+  dnsConfig:
+    nameservers:
+    - 1.2.3.4
+    searches:
+    - example.com
+    options:
+    - name: ndots
+      value: "2"
+  hostAliases:
+  - ip: 10.0.0.1
+    hostnames:
+    - foo
+    - bar
+  containers:
+  - command:
+    - top
+    image: {{ .Image }}
+    name: {{ .Name }}
 status: {}
 `
 
+type InitContainerPod struct {
+	Image   string
+	InitCmd string
+}
+
 type Pod struct {
 	Name       string
 	Containers []Container
+	Volumes    []Volume
 }
 
 type Container struct {
@@ -78,13 +167,21 @@ type Container struct {
 	CapDrop []string
 }
 
+type Volume struct {
+	Name string
+	Path string
+}
+
 func generateKubeYaml(ctrs []Container, fileName string) error {
+	return generatePodKubeYaml(Pod{Name: "test", Containers: ctrs}, fileName)
+}
+
+func generatePodKubeYaml(testPod Pod, fileName string) error {
 	f, err := os.Create(fileName)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
-	testPod := Pod{"test", ctrs}
 
 	t, err := template.New("pod").Parse(yamlTemplate)
 	if err != nil {
@@ -179,4 +276,214 @@ var _ = Describe("Podman generate kube", func() {
 		Expect(inspect.ExitCode()).To(Equal(0))
 		Expect(inspect.OutputToString()).To(ContainSubstring(capDrop))
 	})
+
+	It("podman play kube --down removes the pod", func() {
+		ctrName := "testCtr"
+		testContainer := Container{[]string{"top"}, ALPINE, ctrName, false, nil, nil}
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+
+		err := generateKubeYaml([]Container{testContainer}, tempFile)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		down := podmanTest.Podman([]string{"play", "kube", "--down", tempFile})
+		down.WaitWithDefaultTimeout()
+		Expect(down.ExitCode()).To(Equal(0))
+
+		ps := podmanTest.Podman([]string{"pod", "ps", "-q"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(len(ps.OutputToStringArray())).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", ctrName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman play kube --down removes created HostPath directories", func() {
+		hostPath := filepath.Join(podmanTest.TempDir, "created,dir")
+		existing := filepath.Join(podmanTest.TempDir, "existing")
+		err := os.Mkdir(existing, 0755)
+		Expect(err).To(BeNil())
+
+		testPod := Pod{
+			Name:       "test",
+			Containers: []Container{{[]string{"top"}, ALPINE, "testCtr", false, nil, nil}},
+			Volumes:    []Volume{{"created", hostPath}, {"existing", existing}},
+		}
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err = generatePodKubeYaml(testPod, tempFile)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+		_, err = os.Stat(hostPath)
+		Expect(err).To(BeNil())
+
+		down := podmanTest.Podman([]string{"play", "kube", "--down", tempFile})
+		down.WaitWithDefaultTimeout()
+		Expect(down.ExitCode()).To(Equal(0))
+
+		_, err = os.Stat(hostPath)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		_, err = os.Stat(existing)
+		Expect(err).To(BeNil())
+	})
+
+	It("podman play kube --down leaves pods from other files alone", func() {
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err := generateKubeYaml([]Container{{[]string{"top"}, ALPINE, "testCtr", false, nil, nil}}, tempFile)
+		Expect(err).To(BeNil())
+		otherFile := filepath.Join(podmanTest.TempDir, "other.yaml")
+		err = generateKubeYaml([]Container{{[]string{"top"}, ALPINE, "otherCtr", false, nil, nil}}, otherFile)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+		other := podmanTest.Podman([]string{"play", "kube", otherFile})
+		other.WaitWithDefaultTimeout()
+		Expect(other.ExitCode()).To(Equal(0))
+
+		down := podmanTest.Podman([]string{"play", "kube", "--down", tempFile})
+		down.WaitWithDefaultTimeout()
+		Expect(down.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "testCtr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Not(Equal(0)))
+		inspect = podmanTest.Podman([]string{"inspect", "otherCtr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+	})
+
+	It("podman play kube --down with nothing to remove", func() {
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err := generateKubeYaml([]Container{{[]string{"top"}, ALPINE, "testCtr", false, nil, nil}}, tempFile)
+		Expect(err).To(BeNil())
+
+		down := podmanTest.Podman([]string{"play", "kube", "--down", tempFile})
+		down.WaitWithDefaultTimeout()
+		Expect(down.ExitCode()).To(Not(Equal(0)))
+	})
+	It("podman play kube with resources and security context", func() {
+		ctrName := "testCtr"
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		f, err := os.Create(tempFile)
+		Expect(err).To(BeNil())
+		t, err := template.New("pod").Parse(securityYaml)
+		Expect(err).To(BeNil())
+		err = t.Execute(f, Container{Image: ALPINE, Name: ctrName})
+		Expect(err).To(BeNil())
+		f.Close()
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{ .HostConfig.Memory }} {{ .HostConfig.MemoryReservation }} {{ .HostConfig.CpuShares }} {{ .HostConfig.ReadonlyRootfs }} {{ .Config.User }}", ctrName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("104857600 52428800 256 true 1000:1001"))
+	})
+	It("podman play kube with init containers", func() {
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		f, err := os.Create(tempFile)
+		Expect(err).To(BeNil())
+		t, err := template.New("pod").Parse(initContainerYaml)
+		Expect(err).To(BeNil())
+		err = t.Execute(f, InitContainerPod{Image: ALPINE, InitCmd: "true"})
+		Expect(err).To(BeNil())
+		f.Close()
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{ .State.Status }} {{ .State.ExitCode }}", "init"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("exited 0"))
+
+		podInspect := podmanTest.Podman([]string{"pod", "inspect", "test"})
+		podInspect.WaitWithDefaultTimeout()
+		Expect(podInspect.ExitCode()).To(Equal(0))
+		podData := podInspect.InspectPodToJSON()
+		Expect(len(podData.InitContainers)).To(Equal(1))
+
+		kubeGen := podmanTest.Podman([]string{"generate", "kube", "test"})
+		kubeGen.WaitWithDefaultTimeout()
+		Expect(kubeGen.ExitCode()).To(Equal(0))
+		Expect(kubeGen.OutputToString()).To(ContainSubstring("initContainers"))
+	})
+
+	It("podman play kube with failing init container", func() {
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		f, err := os.Create(tempFile)
+		Expect(err).To(BeNil())
+		t, err := template.New("pod").Parse(initContainerYaml)
+		Expect(err).To(BeNil())
+		err = t.Execute(f, InitContainerPod{Image: ALPINE, InitCmd: "false"})
+		Expect(err).To(BeNil())
+		f.Close()
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Not(Equal(0)))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{ .State.Status }}", "testCtr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Not(Equal("running")))
+	})
+	It("podman play kube with pod namespace settings", func() {
+		ctrName := "testCtr"
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		f, err := os.Create(tempFile)
+		Expect(err).To(BeNil())
+		t, err := template.New("pod").Parse(podNamespacesYaml)
+		Expect(err).To(BeNil())
+		err = t.Execute(f, Container{Image: ALPINE, Name: ctrName})
+		Expect(err).To(BeNil())
+		f.Close()
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		hostname := podmanTest.Podman([]string{"exec", ctrName, "hostname"})
+		hostname.WaitWithDefaultTimeout()
+		Expect(hostname.ExitCode()).To(Equal(0))
+		Expect(hostname.OutputToString()).To(Equal("kubehost"))
+
+		resolv := podmanTest.Podman([]string{"exec", ctrName, "cat", "/etc/resolv.conf"})
+		resolv.WaitWithDefaultTimeout()
+		Expect(resolv.ExitCode()).To(Equal(0))
+		Expect(resolv.OutputToString()).To(ContainSubstring("nameserver 1.2.3.4"))
+		Expect(resolv.OutputToString()).To(ContainSubstring("search example.com"))
+		Expect(resolv.OutputToString()).To(ContainSubstring("ndots:2"))
+
+		hosts := podmanTest.Podman([]string{"exec", ctrName, "cat", "/etc/hosts"})
+		hosts.WaitWithDefaultTimeout()
+		Expect(hosts.ExitCode()).To(Equal(0))
+		Expect(hosts.OutputToString()).To(ContainSubstring("10.0.0.1 foo"))
+		Expect(hosts.OutputToString()).To(ContainSubstring("10.0.0.1 bar"))
+
+		// with a shared PID namespace the infra container's process is visible
+		ps := podmanTest.Podman([]string{"exec", ctrName, "ps"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(ps.OutputToString()).To(ContainSubstring("pause"))
+
+		kubeGen := podmanTest.Podman([]string{"generate", "kube", "test"})
+		kubeGen.WaitWithDefaultTimeout()
+		Expect(kubeGen.ExitCode()).To(Equal(0))
+		Expect(kubeGen.OutputToString()).To(ContainSubstring("shareProcessNamespace: true"))
+		Expect(kubeGen.OutputToString()).To(ContainSubstring("hostname: kubehost"))
+		Expect(kubeGen.OutputToString()).To(ContainSubstring("1.2.3.4"))
+	})
 })
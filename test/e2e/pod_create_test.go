@@ -117,4 +117,62 @@ var _ = Describe("Podman pod create", func() {
 		session.WaitWithDefaultTimeout()
 		Expect(session.ExitCode()).To(Equal(125))
 	})
+
+	It("podman create pod with --add-host, --dns and --hostname", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--add-host", "foobar:192.0.2.10", "--dns", "192.0.2.53", "--dns-search", "example.com", "--hostname", "podhost"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		podID := session.OutputToString()
+
+		hosts := podmanTest.Podman([]string{"run", "--pod", podID, ALPINE, "cat", "/etc/hosts"})
+		hosts.WaitWithDefaultTimeout()
+		Expect(hosts.ExitCode()).To(Equal(0))
+		Expect(hosts.OutputToString()).To(ContainSubstring("192.0.2.10 foobar"))
+
+		resolv := podmanTest.Podman([]string{"run", "--pod", podID, ALPINE, "cat", "/etc/resolv.conf"})
+		resolv.WaitWithDefaultTimeout()
+		Expect(resolv.ExitCode()).To(Equal(0))
+		Expect(resolv.OutputToString()).To(ContainSubstring("nameserver 192.0.2.53"))
+		Expect(resolv.OutputToString()).To(ContainSubstring("search example.com"))
+
+		hostname := podmanTest.Podman([]string{"run", "--pod", podID, ALPINE, "hostname"})
+		hostname.WaitWithDefaultTimeout()
+		Expect(hostname.ExitCode()).To(Equal(0))
+		Expect(hostname.OutputToString()).To(Equal("podhost"))
+	})
+
+	It("podman create pod with --ip", func() {
+		SkipIfRootless()
+		session := podmanTest.Podman([]string{"pod", "create", "--ip", "10.88.64.130"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		podID := session.OutputToString()
+
+		ip := podmanTest.Podman([]string{"run", "--pod", podID, ALPINE, "ip", "addr"})
+		ip.WaitWithDefaultTimeout()
+		Expect(ip.ExitCode()).To(Equal(0))
+		Expect(ip.OutputToString()).To(ContainSubstring("10.88.64.130"))
+	})
+
+	It("podman create pod with invalid --ip or --network should fail", func() {
+		for _, args := range [][]string{{"--ip", "foobar"}, {"--ip", "fd00::1"}, {"--network", "none"}, {"--network", "host", "--ip", "10.88.64.131"}, {"--network", "host", "--network-alias", "web"}, {"--infra=false", "--dns", "192.0.2.53"}} {
+			session := podmanTest.Podman(append([]string{"pod", "create"}, args...))
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(125))
+		}
+	})
+
+	It("podman run in pod with conflicting network options should fail", func() {
+		session := podmanTest.Podman([]string{"pod", "create"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		podID := session.OutputToString()
+
+		for _, args := range [][]string{{"--dns", "192.0.2.53"}, {"--ip", "10.88.64.132"}, {"--hostname", "foo"}} {
+			run := podmanTest.Podman(append(append([]string{"run", "--pod", podID}, args...), ALPINE, "ls"))
+			run.WaitWithDefaultTimeout()
+			Expect(run.ExitCode()).To(Not(Equal(0)))
+			Expect(run.ErrorToString()).To(ContainSubstring("set it when creating the pod"))
+		}
+	})
 })
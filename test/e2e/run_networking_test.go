@@ -3,7 +3,10 @@
 package integration
 
 import (
+	"io/ioutil"
+	"net"
 	"os"
+	"time"
 
 	. "github.com/containers/libpod/test/utils"
 	. "github.com/onsi/ginkgo"
@@ -53,6 +56,81 @@ var _ = Describe("Podman run networking", func() {
 		Expect(session.ExitCode()).To(Equal(0))
 	})
 
+	It("podman run slirp4netns network with custom cidr", func() {
+		session := podmanTest.Podman([]string{"run", "--network", "slirp4netns:cidr=10.5.0.0/24,mtu=1500", ALPINE, "ip", "addr"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("10.5.0.100"))
+		Expect(session.OutputToString()).To(ContainSubstring("mtu 1500"))
+	})
+
+	It("podman run slirp4netns network with invalid option", func() {
+		session := podmanTest.Podman([]string{"run", "--network", "slirp4netns:bogus=1", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).ToNot(Equal(0))
+	})
+
+	It("podman run slirp4netns network with bandwidth limits", func() {
+		session := podmanTest.Podman([]string{"run", "--network", "slirp4netns", "--network-egress-rate", "10mbit", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+	})
+
+	It("podman run network bandwidth limits shown by inspect", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "limited", "--network-egress-rate", "10mbit", "--network-ingress-rate", "100m", "--network-ingress-burst", "1m", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.HostConfig.NetworkBandwidth.EgressRate}} {{.HostConfig.NetworkBandwidth.EgressBurst}} {{.HostConfig.NetworkBandwidth.IngressRate}} {{.HostConfig.NetworkBandwidth.IngressBurst}}", "limited"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("10000000 1000000 100000000 1000000"))
+	})
+
+	It("podman run network bandwidth limits with invalid values", func() {
+		session := podmanTest.Podman([]string{"create", "--network-egress-rate", "10mb", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+
+		session = podmanTest.Podman([]string{"create", "--network-ingress-burst", "1m", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+
+		session = podmanTest.Podman([]string{"create", "--network", "host", "--network-egress-rate", "10mbit", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman run slirp4netns network with rootlessport port handler", func() {
+		session := podmanTest.Podman([]string{"run", "-d", "-p", "127.0.0.1:5678:80", "--network", "slirp4netns:port_handler=rootlessport", ALPINE, "sh", "-c", "echo podman | nc -l -p 80"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		var (
+			conn net.Conn
+			err  error
+		)
+		for i := 0; i < 10; i++ {
+			conn, err = net.Dial("tcp", "127.0.0.1:5678")
+			if err == nil {
+				break
+			}
+			time.Sleep(500 * time.Millisecond)
+		}
+		Expect(err).To(BeNil())
+		defer conn.Close()
+		output, err := ioutil.ReadAll(conn)
+		Expect(err).To(BeNil())
+		Expect(string(output)).To(ContainSubstring("podman"))
+	})
+
+	It("podman run slirp4netns network preserving client addresses requires rootlessport", func() {
+		session := podmanTest.Podman([]string{"run", "--network", "slirp4netns:preserve_client_addr=true", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).ToNot(Equal(0))
+		Expect(session.ErrorToString()).To(ContainSubstring("port_handler=rootlessport"))
+	})
+
 	It("podman run network expose port 222", func() {
 		SkipIfRootless()
 		session := podmanTest.Podman([]string{"run", "-dt", "--expose", "222-223", "-P", ALPINE, "/bin/sh"})
@@ -77,6 +155,17 @@ var _ = Describe("Podman run networking", func() {
 		Expect(ncBusy.ExitCode()).ToNot(Equal(0))
 	})
 
+	It("podman run network expose port on IPv6 host address", func() {
+		SkipIfRootless()
+		session := podmanTest.Podman([]string{"create", "-p", "[::1]:8080:80", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		results := podmanTest.Podman([]string{"ps", "-a", "--format", "{{.Ports}}"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToString()).To(ContainSubstring("[::1]:8080->80/tcp"))
+	})
+
 	It("podman run network expose ports in image metadata", func() {
 		session := podmanTest.Podman([]string{"create", "-dt", "-P", nginx})
 		session.Wait(90)
@@ -49,6 +49,18 @@ var _ = Describe("Podman run with --ip flag", func() {
 		Expect(result.ExitCode()).ToNot(Equal(0))
 	})
 
+	It("Podman run --ip6 with garbage address", func() {
+		result := podmanTest.Podman([]string{"run", "-ti", "--ip6", "114232346", ALPINE, "ls"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).ToNot(Equal(0))
+	})
+
+	It("Podman run --ip6 with v4 address", func() {
+		result := podmanTest.Podman([]string{"run", "-ti", "--ip6", "10.88.64.128", ALPINE, "ls"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).ToNot(Equal(0))
+	})
+
 	It("Podman run --ip with non-allocatable IP", func() {
 		result := podmanTest.Podman([]string{"run", "-ti", "--ip", "203.0.113.124", ALPINE, "ls"})
 		result.WaitWithDefaultTimeout()
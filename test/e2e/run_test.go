@@ -838,4 +838,38 @@ USER mail`
 		}
 		Expect(found).To(BeTrue())
 	})
+	It("podman run with restart-policy counts restarts", func() {
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "crashloop", "--restart", "on-failure:2", "--restart-backoff", "200ms", "--restart-backoff-max", "400ms", ALPINE, "false"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		restarts := ""
+		for i := 0; i < 20; i++ {
+			time.Sleep(500 * time.Millisecond)
+			inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.RestartCount}}", "crashloop"})
+			inspect.WaitWithDefaultTimeout()
+			Expect(inspect.ExitCode()).To(Equal(0))
+			restarts = inspect.OutputToString()
+			if restarts == "2" {
+				break
+			}
+		}
+		Expect(restarts).To(Equal("2"))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.HostConfig.RestartPolicy.Backoff}} {{.HostConfig.RestartPolicy.MaximumBackoff}}", "crashloop"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("200ms 400ms"))
+
+		ps := podmanTest.Podman([]string{"ps", "-a", "--format", "{{.Restarts}}"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(ps.OutputToString()).To(Equal("2"))
+	})
+
+	It("podman run with invalid restart-backoff should fail", func() {
+		session := podmanTest.Podman([]string{"run", "--restart", "always", "--restart-backoff", "5s", "--restart-backoff-max", "1s", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
 })
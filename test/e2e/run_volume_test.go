@@ -4,6 +4,7 @@ package integration
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -154,4 +155,92 @@ var _ = Describe("Podman run with volumes", func() {
 		session.WaitWithDefaultTimeout()
 		Expect(session.ExitCode()).To(Not(Equal(0)))
 	})
+
+	It("podman run with overlay volume flag", func() {
+		mountPath := filepath.Join(podmanTest.TempDir, "secrets")
+		err := os.Mkdir(mountPath, 0755)
+		Expect(err).To(BeNil())
+		err = ioutil.WriteFile(filepath.Join(mountPath, "test1"), []byte("hello"), 0644)
+		Expect(err).To(BeNil())
+
+		session := podmanTest.Podman([]string{"run", "--name", "test", "-v", fmt.Sprintf("%s:/data:O", mountPath), ALPINE, "sh", "-c", "cat /data/test1 && echo changed > /data/test1 && touch /data/test2"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("hello"))
+
+		content, err := ioutil.ReadFile(filepath.Join(mountPath, "test1"))
+		Expect(err).To(BeNil())
+		Expect(string(content)).To(Equal("hello"))
+		Expect(filepath.Join(mountPath, "test2")).To(Not(BeAnExistingFile()))
+
+		// Changes persist until the container is removed
+		session = podmanTest.Podman([]string{"start", "--attach", "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("changed"))
+
+		session = podmanTest.Podman([]string{"rm", "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--mount", fmt.Sprintf("type=bind,src=%s,target=/data,overlay", mountPath), ALPINE, "ls", "/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("test1"))
+	})
+
+	It("podman run with overlay volume keeps the owner of the source", func() {
+		SkipIfRootless()
+		mountPath := filepath.Join(podmanTest.TempDir, "secrets")
+		err := os.Mkdir(mountPath, 0750)
+		Expect(err).To(BeNil())
+		err = os.Chown(mountPath, 1000, 2000)
+		Expect(err).To(BeNil())
+
+		session := podmanTest.Podman([]string{"run", "--rm", "-v", fmt.Sprintf("%s:/data:O", mountPath), ALPINE, "stat", "-c", "%u:%g %a", "/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("1000:2000 750"))
+	})
+
+	It("podman run with overlay volume from another container", func() {
+		SkipIfRootless()
+		mountPath := filepath.Join(podmanTest.TempDir, "secrets")
+		err := os.Mkdir(mountPath, 0755)
+		Expect(err).To(BeNil())
+		err = ioutil.WriteFile(filepath.Join(mountPath, "test1"), []byte("hello"), 0644)
+		Expect(err).To(BeNil())
+
+		session := podmanTest.Podman([]string{"create", "--name", "test", "-v", fmt.Sprintf("%s:/data:O", mountPath), ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--volumes-from", "test", ALPINE, "sh", "-c", "cat /data/test1 && touch /data/test2"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("hello"))
+		Expect(filepath.Join(mountPath, "test2")).To(Not(BeAnExistingFile()))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--volumes-from", "test:ro", ALPINE, "ls", "/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman run with overlay volume flag and relabel option", func() {
+		mountPath := filepath.Join(podmanTest.TempDir, "secrets")
+		os.Mkdir(mountPath, 0755)
+		session := podmanTest.Podman([]string{"run", "--rm", "-v", fmt.Sprintf("%s:/data:O,z", mountPath), ALPINE, "touch", "/data/test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		_, err := os.Stat(filepath.Join(mountPath, "test"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("podman run with overlay volume flag and other options", func() {
+		mountPath := filepath.Join(podmanTest.TempDir, "secrets")
+		os.Mkdir(mountPath, 0755)
+		session := podmanTest.Podman([]string{"run", "--rm", "-v", fmt.Sprintf("%s:/data:O,ro", mountPath), ALPINE, "ls", "/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
 })
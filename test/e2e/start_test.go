@@ -135,4 +135,67 @@ var _ = Describe("Podman start", func() {
 		session.WaitWithDefaultTimeout()
 		Expect(session.ExitCode()).To(Equal(125))
 	})
+
+	It("podman start --all --filter restart-policy", func() {
+		SkipIfRemote()
+		session := podmanTest.Podman([]string{"create", "--name", "always", "--restart", "always", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		session = podmanTest.Podman([]string{"create", "--name", "never", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"start", "--all", "--filter", "restart-policy=always"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		ps := podmanTest.Podman([]string{"ps", "--format", "{{.Names}}"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(ps.OutputToStringArray()).To(Equal([]string{"always"}))
+	})
+
+	It("podman start --all --filter with no matching containers", func() {
+		SkipIfRemote()
+		session := podmanTest.Podman([]string{"create", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"start", "--all", "--filter", "restart-policy=always"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(len(session.OutputToStringArray())).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
+	})
+
+	It("podman start --all with unless-stopped restart policy", func() {
+		SkipIfRemote()
+		session := podmanTest.Podman([]string{"create", "--restart", "unless-stopped", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// Nothing has run before a reboot yet
+		session = podmanTest.Podman([]string{"start", "--all", "--filter", "restart-policy=unless-stopped", "--filter", "running-before-reboot=true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"start", "--all", "--filter", "restart-policy=unless-stopped"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+	})
+
+	It("podman start --filter without --all should fail", func() {
+		session := podmanTest.Podman([]string{"start", "--filter", "restart-policy=always", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman start --all with invalid restart policy filter should fail", func() {
+		SkipIfRemote()
+		session := podmanTest.Podman([]string{"start", "--all", "--filter", "restart-policy=sometimes"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
 })
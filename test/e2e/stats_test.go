@@ -80,6 +80,17 @@ var _ = Describe("Podman stats", func() {
 		Expect(session.IsJSONOutputValid()).To(BeTrue())
 	})
 
+	It("podman stats shows bandwidth limits", func() {
+		session := podmanTest.Podman([]string{"run", "-d", "--network-egress-rate", "10mbit", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		cid := session.OutputToString()
+		session = podmanTest.Podman([]string{"stats", "--no-stream", "--format", "{{.NetLimit}}", cid})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("-- / 10Mbit"))
+	})
+
 	It("podman stats on a container with no net ns", func() {
 		session := podmanTest.Podman([]string{"run", "-d", "--net", "none", ALPINE, "top"})
 		session.WaitWithDefaultTimeout()
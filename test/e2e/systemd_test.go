@@ -3,8 +3,11 @@
 package integration
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
+	"time"
 
 	. "github.com/containers/libpod/test/utils"
 	. "github.com/onsi/ginkgo"
@@ -77,4 +80,63 @@ WantedBy=multi-user.target
 		status := SystemExec("bash", []string{"-c", "systemctl status redis"})
 		Expect(status.OutputToString()).To(ContainSubstring("active (running)"))
 	})
+
+	It("podman start container with --sdnotify=container by systemd", func() {
+		podman := func(args ...string) string {
+			return podmanTest.PodmanBinary + " " + strings.Join(podmanTest.MakeOptions(args, false), " ")
+		}
+		unit := fmt.Sprintf(`[Unit]
+Description=notify container
+[Service]
+ExecStart=%s
+ExecStop=%s
+KillMode=none
+Type=notify
+NotifyAccess=all
+`, podman("start", "notify"), podman("stop", "-t", "0", "notify"))
+
+		sysFile := ioutil.WriteFile("/etc/systemd/system/podman-notify.service", []byte(unit), 0644)
+		Expect(sysFile).To(BeNil())
+		defer func() {
+			SystemExec("bash", []string{"-c", "systemctl stop podman-notify"})
+			os.Remove("/etc/systemd/system/podman-notify.service")
+			SystemExec("bash", []string{"-c", "systemctl daemon-reload"})
+		}()
+
+		// The container never reports its readiness, so the unit stays
+		// activating once podman start returned
+		create := podmanTest.Podman([]string{"create", "--name", "notify", "--sdnotify=container", ALPINE, "top"})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(Equal(0))
+
+		reload := SystemExec("bash", []string{"-c", "systemctl daemon-reload"})
+		Expect(reload.ExitCode()).To(Equal(0))
+
+		start := SystemExec("bash", []string{"-c", "systemctl start --no-block podman-notify"})
+		Expect(start.ExitCode()).To(Equal(0))
+
+		Eventually(func() string {
+			inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Status}}", "notify"})
+			inspect.WaitWithDefaultTimeout()
+			return inspect.OutputToString()
+		}, 30*time.Second, time.Second).Should(Equal("running"))
+		// Give podman start time to exit
+		time.Sleep(2 * time.Second)
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.ConmonPid}}", "notify"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+
+		mainPID := SystemExec("bash", []string{"-c", "systemctl show --value -p MainPID podman-notify"})
+		Expect(mainPID.ExitCode()).To(Equal(0))
+		Expect(mainPID.OutputToString()).To(Equal(inspect.OutputToString()))
+
+		state := SystemExec("bash", []string{"-c", "systemctl show --value -p ActiveState podman-notify"})
+		Expect(state.ExitCode()).To(Equal(0))
+		Expect(state.OutputToString()).To(Equal("activating"))
+
+		inspect = podmanTest.Podman([]string{"inspect", "--format", "{{.State.Status}}", "notify"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.OutputToString()).To(Equal("running"))
+	})
 })
@@ -1,7 +1,10 @@
 package integration
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
 
 	. "github.com/containers/libpod/test/utils"
 	. "github.com/onsi/ginkgo"
@@ -57,4 +60,114 @@ var _ = Describe("Podman volume create", func() {
 		Expect(match).To(BeTrue())
 		Expect(len(check.OutputToStringArray())).To(Equal(1))
 	})
+
+	It("podman create volume with invalid local option", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "foo=bar", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman create volume with tmpfs mount options", func() {
+		SkipIfRootless()
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "type=tmpfs", "--opt", "o=size=2m,mode=0700", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"volume", "inspect", "--format", "{{.Mountpoint}}", "myvol"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		mountPoint := inspect.OutputToString()
+
+		session = podmanTest.Podman([]string{"run", "-d", "--name", "test", "-v", "myvol:/data", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"exec", "test", "df", "-k", "/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("2048"))
+
+		mounts, err := ioutil.ReadFile("/proc/self/mountinfo")
+		Expect(err).To(BeNil())
+		Expect(string(mounts)).To(ContainSubstring(mountPoint))
+
+		session = podmanTest.Podman([]string{"stop", "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		mounts, err = ioutil.ReadFile("/proc/self/mountinfo")
+		Expect(err).To(BeNil())
+		Expect(string(mounts)).To(Not(ContainSubstring(mountPoint)))
+	})
+
+	It("podman create volume with bind mount options", func() {
+		SkipIfRootless()
+		err := ioutil.WriteFile(filepath.Join(tempdir, "test"), []byte("hello"), 0644)
+		Expect(err).To(BeNil())
+
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "type=none", "--opt", "o=bind", "--opt", "device=" + tempdir, "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "cat", "/data/test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("hello"))
+	})
+
+	It("podman create volume with a size limit", func() {
+		SkipIfRootless()
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "size=10m", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"volume", "inspect", "--format", "{{.Quota}}", "myvol"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("10485760"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "ls", "-A", "/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal(""))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "dd", "if=/dev/zero", "of=/data/test", "bs=1M", "count=20"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "sh", "-c", "rm /data/test && dd if=/dev/zero of=/data/test bs=1M count=2"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect = podmanTest.Podman([]string{"volume", "inspect", "--format", "{{.Usage}}", "myvol"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		usage, err := strconv.ParseUint(inspect.OutputToString(), 10, 64)
+		Expect(err).To(BeNil())
+		Expect(usage).To(BeNumerically(">=", 2*1024*1024))
+		Expect(usage).To(BeNumerically("<=", 10*1024*1024))
+
+		session = podmanTest.Podman([]string{"run", "-d", "-v", "myvol:/data", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect = podmanTest.Podman([]string{"volume", "inspect", "--format", "{{.Usage}}", "myvol"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		usage, err = strconv.ParseUint(inspect.OutputToString(), 10, 64)
+		Expect(err).To(BeNil())
+		Expect(usage).To(BeNumerically(">=", 2*1024*1024))
+		Expect(usage).To(BeNumerically("<", 3*1024*1024))
+
+		session = podmanTest.Podman([]string{"system", "df", "-v"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("QUOTA"))
+	})
+
+	It("podman create volume with a size limit and mount options", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "size=10m", "--opt", "type=tmpfs", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
 })
@@ -0,0 +1,122 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/libpod/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman volume export and import", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.CleanupVolume()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman volume export and import into a new volume", func() {
+		session := podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "sh", "-c", "echo hello > /data/test && chown 1000:2000 /data/test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		tarball := filepath.Join(podmanTest.TempDir, "myvol.tar")
+		session = podmanTest.Podman([]string{"volume", "export", "--output", tarball, "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"volume", "import", "newvol", tarball})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "newvol:/data", ALPINE, "stat", "-c", "%u:%g %n", "/data/test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("1000:2000 /data/test"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "newvol:/data", ALPINE, "cat", "/data/test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("hello"))
+	})
+
+	It("podman volume import into a volume with a size limit", func() {
+		SkipIfRootless()
+		session := podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "sh", "-c", "echo hello > /data/test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		tarball := filepath.Join(podmanTest.TempDir, "myvol.tar")
+		session = podmanTest.Podman([]string{"volume", "export", "--output", tarball, "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"volume", "create", "--opt", "size=10m", "newvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"volume", "import", "newvol", tarball})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "newvol:/data", ALPINE, "cat", "/data/test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("hello"))
+	})
+
+	It("podman volume import into a volume which is not empty", func() {
+		session := podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", ALPINE, "touch", "/data/test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		tarball := filepath.Join(podmanTest.TempDir, "myvol.tar")
+		session = podmanTest.Podman([]string{"volume", "export", "--output", tarball, "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"volume", "import", "myvol", tarball})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+		Expect(session.ErrorToString()).To(ContainSubstring("not empty"))
+	})
+
+	It("podman volume export with a running writer", func() {
+		SkipIfRootless()
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "writer", "-v", "myvol:/data", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		tarball := filepath.Join(podmanTest.TempDir, "myvol.tar")
+		session = podmanTest.Podman([]string{"volume", "export", "--output", tarball, "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"volume", "export", "--pause", "--output", tarball, "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"inspect", "--format", "{{.State.Status}}", "writer"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("running"))
+	})
+})
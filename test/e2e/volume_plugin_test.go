@@ -0,0 +1,120 @@
+package integration
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	. "github.com/containers/libpod/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Podman volume plugins", func() {
+	var (
+		tempdir    string
+		podmanTest *PodmanTestIntegration
+		plugin     *gexec.Session
+		pluginData string
+		configFile string
+	)
+
+	BeforeEach(func() {
+		SkipIfRemote()
+		testVolume, err := filepath.Abs("../testvolume/testvolume")
+		Expect(err).To(BeNil())
+		if _, err := os.Stat(testVolume); err != nil {
+			Skip("test volume plugin is not built")
+		}
+
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+
+		pluginDir := filepath.Join(tempdir, "plugins")
+		pluginData = filepath.Join(tempdir, "plugin-data")
+		socket := filepath.Join(pluginDir, "testvolume.sock")
+		plugin, err = gexec.Start(exec.Command(testVolume, "-sock", socket, "-data", pluginData), GinkgoWriter, GinkgoWriter)
+		Expect(err).To(BeNil())
+		Eventually(func() error {
+			_, err := os.Stat(socket)
+			return err
+		}, 10*time.Second).Should(BeNil())
+
+		configFile = filepath.Join(tempdir, "libpod.conf")
+		config := fmt.Sprintf("volume_plugin_dir = %q\n", pluginDir)
+		Expect(ioutil.WriteFile(configFile, []byte(config), 0644)).To(BeNil())
+	})
+
+	AfterEach(func() {
+		podmanTest.Podman([]string{"--config", configFile, "rm", "-fa"}).WaitWithDefaultTimeout()
+		podmanTest.Podman([]string{"--config", configFile, "volume", "rm", "-fa"}).WaitWithDefaultTimeout()
+		plugin.Terminate().Wait(10)
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+	})
+
+	It("podman volume create with missing plugin", func() {
+		session := podmanTest.Podman([]string{"--config", configFile, "volume", "create", "--driver", "missing", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+		Expect(session.ErrorToString()).To(ContainSubstring("missing plugin"))
+	})
+
+	It("podman run with volume of a volume plugin", func() {
+		session := podmanTest.Podman([]string{"--config", configFile, "volume", "create", "--driver", "testvolume", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(filepath.Join(pluginData, "myvol")).To(BeADirectory())
+
+		inspect := podmanTest.Podman([]string{"--config", configFile, "volume", "inspect", "--format", "{{.Driver}} {{.Mountpoint}}", "myvol"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("testvolume " + filepath.Join(pluginData, "myvol")))
+
+		session = podmanTest.Podman([]string{"--config", configFile, "run", "--rm", "-v", "myvol:/data", ALPINE, "sh", "-c", "echo hello > /data/test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		content, err := ioutil.ReadFile(filepath.Join(pluginData, "myvol", "test"))
+		Expect(err).To(BeNil())
+		Expect(string(content)).To(Equal("hello\n"))
+
+		session = podmanTest.Podman([]string{"--config", configFile, "volume", "rm", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(filepath.Join(pluginData, "myvol")).To(Not(BeADirectory()))
+	})
+
+	It("podman stop unmounts volumes of volume plugins", func() {
+		session := podmanTest.Podman([]string{"--config", configFile, "volume", "create", "--driver", "testvolume", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"--config", configFile, "run", "-d", "--name", "test", "-v", "myvol:/data", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"--config", configFile, "stop", "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"--config", configFile, "rm", "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// The plugin refuses to remove volumes that are still mounted
+		session = podmanTest.Podman([]string{"--config", configFile, "volume", "rm", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+	})
+})
@@ -0,0 +1,197 @@
+// testvolume is a minimal Docker volume plugin used by the tests. Its volumes
+// are directories under the data directory; mounts are tracked per container
+// ID and mounted volumes cannot be removed.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+)
+
+type request struct {
+	Name string
+	ID   string
+	Opts map[string]string
+}
+
+type volume struct {
+	Name       string
+	Mountpoint string            `json:",omitempty"`
+	Status     map[string]string `json:",omitempty"`
+}
+
+type plugin struct {
+	dataDir string
+
+	lock    sync.Mutex
+	volumes map[string]*volume
+	mounts  map[string]map[string]bool
+}
+
+func (p *plugin) path(name string) string {
+	return filepath.Join(p.dataDir, name)
+}
+
+func (p *plugin) get(name string) (*volume, error) {
+	vol, ok := p.volumes[name]
+	if !ok {
+		return nil, fmt.Errorf("no such volume %s", name)
+	}
+	return vol, nil
+}
+
+// handle decodes a request, runs fn on it with the plugin locked and encodes
+// its response, reporting errors in the Err field as plugins do
+func (p *plugin) handle(fn func(req *request) (map[string]interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := new(request)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		p.lock.Lock()
+		resp, err := fn(req)
+		p.lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1.2+json")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			resp = map[string]interface{}{"Err": err.Error()}
+		}
+		if resp == nil {
+			resp = map[string]interface{}{}
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			fmt.Fprintf(os.Stderr, "error encoding response: %v\n", err)
+		}
+	}
+}
+
+func (p *plugin) activate(req *request) (map[string]interface{}, error) {
+	return map[string]interface{}{"Implements": []string{"VolumeDriver"}}, nil
+}
+
+func (p *plugin) create(req *request) (map[string]interface{}, error) {
+	if _, ok := p.volumes[req.Name]; ok {
+		return nil, fmt.Errorf("volume %s already exists", req.Name)
+	}
+	if err := os.MkdirAll(p.path(req.Name), 0755); err != nil {
+		return nil, err
+	}
+	p.volumes[req.Name] = &volume{Name: req.Name, Status: req.Opts}
+	p.mounts[req.Name] = make(map[string]bool)
+	return nil, nil
+}
+
+func (p *plugin) remove(req *request) (map[string]interface{}, error) {
+	if _, err := p.get(req.Name); err != nil {
+		return nil, err
+	}
+	if len(p.mounts[req.Name]) > 0 {
+		return nil, fmt.Errorf("volume %s is mounted", req.Name)
+	}
+	if err := os.RemoveAll(p.path(req.Name)); err != nil {
+		return nil, err
+	}
+	delete(p.volumes, req.Name)
+	delete(p.mounts, req.Name)
+	return nil, nil
+}
+
+func (p *plugin) mount(req *request) (map[string]interface{}, error) {
+	if _, err := p.get(req.Name); err != nil {
+		return nil, err
+	}
+	p.mounts[req.Name][req.ID] = true
+	return map[string]interface{}{"Mountpoint": p.path(req.Name)}, nil
+}
+
+func (p *plugin) unmount(req *request) (map[string]interface{}, error) {
+	if _, err := p.get(req.Name); err != nil {
+		return nil, err
+	}
+	if !p.mounts[req.Name][req.ID] {
+		return nil, fmt.Errorf("volume %s is not mounted for %s", req.Name, req.ID)
+	}
+	delete(p.mounts[req.Name], req.ID)
+	return nil, nil
+}
+
+func (p *plugin) hostPath(req *request) (map[string]interface{}, error) {
+	if _, err := p.get(req.Name); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"Mountpoint": p.path(req.Name)}, nil
+}
+
+func (p *plugin) getVolume(req *request) (map[string]interface{}, error) {
+	vol, err := p.get(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"Volume": &volume{Name: vol.Name, Mountpoint: p.path(vol.Name), Status: vol.Status}}, nil
+}
+
+func (p *plugin) list(req *request) (map[string]interface{}, error) {
+	volumes := make([]*volume, 0, len(p.volumes))
+	for _, vol := range p.volumes {
+		volumes = append(volumes, &volume{Name: vol.Name, Mountpoint: p.path(vol.Name)})
+	}
+	return map[string]interface{}{"Volumes": volumes}, nil
+}
+
+func (p *plugin) capabilities(req *request) (map[string]interface{}, error) {
+	return map[string]interface{}{"Capabilities": map[string]string{"Scope": "local"}}, nil
+}
+
+func main() {
+	socketPath := flag.String("sock", "/run/docker/plugins/testvolume.sock", "socket to listen on")
+	dataDir := flag.String("data", "/tmp/testvolume", "directory to create volumes in")
+	flag.Parse()
+
+	p := &plugin{
+		dataDir: *dataDir,
+		volumes: make(map[string]*volume),
+		mounts:  make(map[string]map[string]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/Plugin.Activate", p.handle(p.activate))
+	mux.HandleFunc("/VolumeDriver.Create", p.handle(p.create))
+	mux.HandleFunc("/VolumeDriver.Remove", p.handle(p.remove))
+	mux.HandleFunc("/VolumeDriver.Mount", p.handle(p.mount))
+	mux.HandleFunc("/VolumeDriver.Unmount", p.handle(p.unmount))
+	mux.HandleFunc("/VolumeDriver.Path", p.handle(p.hostPath))
+	mux.HandleFunc("/VolumeDriver.Get", p.handle(p.getVolume))
+	mux.HandleFunc("/VolumeDriver.List", p.handle(p.list))
+	mux.HandleFunc("/VolumeDriver.Capabilities", p.handle(p.capabilities))
+
+	if err := os.MkdirAll(filepath.Dir(*socketPath), 0755); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	listener, err := net.Listen("unix", *socketPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		listener.Close()
+	}()
+
+	// Serve returns once the listener is closed, which removes the socket
+	_ = http.Serve(listener, mux)
+}